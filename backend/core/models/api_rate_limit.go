@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// ApiRateLimit records the requests consumed by all devlake processes sharing the same database in the
+// current window for a connection, so they would not exceed the quota of the connection altogether
+type ApiRateLimit struct {
+	LimiterKey  string `gorm:"primaryKey;type:varchar(255)"`
+	WindowStart time.Time
+	Used        int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ApiRateLimit) TableName() string {
+	return "_devlake_api_rate_limits"
+}
@@ -88,3 +88,17 @@ type SyncPolicy struct {
 	SkipCollectors bool       `json:"skipCollectors"`
 	TimeAfter      *time.Time `json:"timeAfter"`
 }
+
+// BlueprintTriggerFilter narrows a blueprint run down to a subset of its connections, scopes and subtasks
+type BlueprintTriggerFilter struct {
+	// Connections to run, empty means all connections, and a connection without scopes means all its scopes in the blueprint
+	Connections []*BlueprintConnection `json:"connections"`
+	// Subtasks limits the data source tasks to the given subtasks, empty means no limitation
+	Subtasks []string `json:"subtasks"`
+}
+
+// BlueprintTrigger is the request body for triggering a blueprint manually
+type BlueprintTrigger struct {
+	SyncPolicy
+	Filter *BlueprintTriggerFilter `json:"filter"`
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CollectorCheckpoint records a page collected by the ApiCollector, so a failed collection could be resumed from
+// where it stopped instead of starting over, they are removed once the collection finished successfully
+type CollectorCheckpoint struct {
+	RawDataTable  string `gorm:"primaryKey;type:varchar(255)"`
+	RawDataParams string `gorm:"primaryKey;type:varchar(255)"`
+	// InputHash identifies the input of the collector, empty if the collector has no input
+	InputHash string `gorm:"primaryKey;type:varchar(64)"`
+	// QueryHash identifies the url, sync mode and since of the collection, pages of another collection are not resumed
+	QueryHash string `gorm:"primaryKey;type:varchar(64)"`
+	Page      int    `gorm:"primaryKey;autoIncrement:false"`
+	// Count is the number of records in the page
+	Count int
+	// TotalPages of the input, available only when the number of pages could be determined by the first page
+	TotalPages int
+	// NextCustomData is the cursor to the next page, available only when the pages were collected sequentially
+	NextCustomData json.RawMessage `gorm:"type:json"`
+	// Finished indicates this is the last page of the input
+	Finished  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CollectorCheckpoint) TableName() string {
+	return "_devlake_collector_checkpoints"
+}
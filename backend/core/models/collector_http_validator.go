@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "time"
+
+// CollectorHttpValidator records the validators (ETag/Last-Modified) of the response of a request sent by the
+// ApiCollector, so the request could be sent conditionally next time, and unchanged resources would not be
+// downloaded again
+type CollectorHttpValidator struct {
+	RawDataTable  string `gorm:"primaryKey;type:varchar(255)"`
+	RawDataParams string `gorm:"primaryKey;type:varchar(255)"`
+	// RequestHash identifies the request by its path and query
+	RequestHash string `gorm:"primaryKey;type:varchar(64)"`
+	// Url of the raw data collected by the request
+	Url          string
+	ETag         string `gorm:"type:varchar(255)"`
+	LastModified string `gorm:"type:varchar(255)"`
+	// SeenAt is when the request was sent last time, raw data of requests not sent by a full collection are stale
+	SeenAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CollectorHttpValidator) TableName() string {
+	return "_devlake_collector_http_validators"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codequality
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// CqFileCoverage is the test coverage of a file at a commit, parsed from the coverage report(LCOV, Cobertura or
+// Go coverprofile) produced by the tests of the project
+type CqFileCoverage struct {
+	domainlayer.DomainEntity
+	ProjectKey      string `gorm:"index;type:varchar(255)"` //domain project key
+	CommitSha       string `gorm:"index;type:varchar(40)"`
+	FilePath        string
+	LinesToCover    int
+	CoveredLines    int
+	UncoveredLines  int
+	Coverage        float64 // percentage of covered lines
+	BranchesToCover int
+	CoveredBranches int
+	ReportFormat    string `gorm:"type:varchar(20)"`
+	ReportedDate    *time.Time
+}
+
+func (CqFileCoverage) TableName() string {
+	return "cq_file_coverages"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codequality
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	QUALITY_GATE_OK    = "OK"
+	QUALITY_GATE_WARN  = "WARN"
+	QUALITY_GATE_ERROR = "ERROR"
+)
+
+// CqProjectAnalysis is a project level snapshot taken by an analysis of the code quality tool, it keeps the
+// quality gate status and the main measures of the project at the analyzed commit, so trends can be plotted over time
+type CqProjectAnalysis struct {
+	domainlayer.DomainEntity
+	ProjectKey             string `gorm:"index;type:varchar(255)"` //domain project key
+	AnalysisDate           *time.Time
+	CommitSha              string `gorm:"index;type:varchar(128)"`
+	ProjectVersion         string `gorm:"type:varchar(255)"`
+	QualityGateStatus      string `gorm:"type:varchar(20)"` // OK, WARN or ERROR
+	Bugs                   *int
+	Vulnerabilities        *int
+	CodeSmells             *int
+	SecurityHotspots       *int
+	SqaleIndex             *int
+	SqaleRating            *float64
+	ReliabilityRating      string `gorm:"type:varchar(20)"`
+	SecurityRating         string `gorm:"type:varchar(20)"`
+	Coverage               *float64
+	LinesToCover           *int
+	UncoveredLines         *int
+	DuplicatedLinesDensity *float64
+	Ncloc                  *int
+}
+
+func (CqProjectAnalysis) TableName() string {
+	return "cq_project_analyses"
+}
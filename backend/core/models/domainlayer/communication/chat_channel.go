@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package communication
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	CHANNEL_TYPE_CHANNEL = "CHANNEL"
+	CHANNEL_TYPE_GROUP   = "GROUP"
+	CHANNEL_TYPE_DIRECT  = "DIRECT"
+)
+
+// ChatChannel is a conversation on a chat platform, e.g. a Slack channel or a Feishu group chat
+type ChatChannel struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Type        string `gorm:"type:varchar(100)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255)"` // user id on the chat platform
+	CreatedDate *time.Time
+}
+
+func (ChatChannel) TableName() string {
+	return "chat_channels"
+}
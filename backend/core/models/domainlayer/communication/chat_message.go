@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package communication
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+type ChatMessage struct {
+	domainlayer.DomainEntity
+	ChannelId   string `gorm:"index;type:varchar(255)"`
+	ThreadId    string `gorm:"index;type:varchar(255)"` // id of the root message of the thread, empty if the message is not in a thread
+	AuthorId    string `gorm:"type:varchar(255)"`       // user id on the chat platform
+	Content     string
+	Type        string `gorm:"type:varchar(100)"`
+	ReplyCount  int
+	IsDeleted   bool
+	CreatedDate time.Time
+	UpdatedDate *time.Time
+}
+
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package communication
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// ChatThread is a root message with its replies, Id is the same as the id of the root message
+type ChatThread struct {
+	domainlayer.DomainEntity
+	ChannelId        string `gorm:"index;type:varchar(255)"`
+	AuthorId         string `gorm:"type:varchar(255)"`
+	ReplyCount       int
+	ParticipantCount int
+	CreatedDate      time.Time
+	FirstReplyDate   *time.Time
+	LastReplyDate    *time.Time
+	// minutes between the root message and the first reply from someone other than its author
+	ResponseTimeMinutes *uint
+}
+
+func (ChatThread) TableName() string {
+	return "chat_threads"
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	REFERENCE_TYPE_ISSUE        = "ISSUE"
+	REFERENCE_TYPE_PULL_REQUEST = "PULL_REQUEST"
+	REFERENCE_TYPE_DEPLOYMENT   = "DEPLOYMENT"
+)
+
+// ChatMessageReference links a chat message to the issue, pull request or deployment it mentions
+type ChatMessageReference struct {
+	MessageId     string `gorm:"primaryKey;type:varchar(255)"`
+	ReferenceId   string `gorm:"primaryKey;type:varchar(255)"` // id of the issue, pull request or deployment
+	ReferenceType string `gorm:"type:varchar(100)"`
+	ReferenceKey  string `gorm:"type:varchar(255)"` // the issue key, url or deployment id as written in the message
+	common.NoPKModel
+}
+
+func (ChatMessageReference) TableName() string {
+	return "chat_message_references"
+}
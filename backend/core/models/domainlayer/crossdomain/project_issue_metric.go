@@ -25,6 +25,10 @@ type ProjectIssueMetric struct {
 	domainlayer.DomainEntity
 	ProjectName  string `gorm:"primaryKey;type:varchar(100)"`
 	DeploymentId string
+	// minutes from the creation of the incident to its first acknowledgement and final resolution, derived from
+	// the status changelogs
+	TimeToAcknowledgeMinutes *uint
+	TimeToRestoreMinutes     *uint
 }
 
 func (ProjectIssueMetric) TableName() string {
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"crypto/md5"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// this is for the field `result` in table.cicd_test_case_results
+const (
+	TEST_PASSED  = "PASSED"
+	TEST_FAILED  = "FAILED"
+	TEST_ERROR   = "ERROR"
+	TEST_SKIPPED = "SKIPPED"
+)
+
+// CicdTestSuite is a test suite executed by a cicd pipeline or task
+type CicdTestSuite struct {
+	domainlayer.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	PipelineId  string `gorm:"index;type:varchar(255)"`
+	TaskId      string `gorm:"index;type:varchar(255)"`
+	Name        string `gorm:"type:varchar(255)"`
+	Tests       int
+	Failures    int
+	Errors      int
+	Skipped     int
+	DurationSec float64
+	StartedDate *time.Time
+}
+
+func (CicdTestSuite) TableName() string {
+	return "cicd_test_suites"
+}
+
+// CicdTestCase identifies a test case of the cicd scope across executions, the results of each execution are
+// stored in CicdTestCaseResult
+type CicdTestCase struct {
+	domainlayer.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	SuiteName   string `gorm:"type:varchar(255)"`
+	ClassName   string `gorm:"type:varchar(500)"`
+	Name        string `gorm:"type:varchar(500)"`
+	File        string `gorm:"type:varchar(500)"`
+}
+
+func (CicdTestCase) TableName() string {
+	return "cicd_test_cases"
+}
+
+// CicdTestCaseId generates the id of the test case, so the same test case executed by different pipelines of the
+// scope would share the same id
+func CicdTestCaseId(cicdScopeId, suiteName, className, name string) string {
+	return fmt.Sprintf("%s:%x", cicdScopeId, md5.Sum([]byte(suiteName+"\n"+className+"\n"+name)))
+}
+
+// CicdTestCaseResult is the result of a test case in an execution of the test suite
+type CicdTestCaseResult struct {
+	domainlayer.DomainEntity
+	TestCaseId     string `gorm:"index;type:varchar(255)"`
+	TestSuiteId    string `gorm:"index;type:varchar(255)"`
+	CicdScopeId    string `gorm:"index;type:varchar(255)"`
+	PipelineId     string `gorm:"index;type:varchar(255)"`
+	TaskId         string `gorm:"index;type:varchar(255)"`
+	Result         string `gorm:"type:varchar(100)"`
+	OriginalResult string `gorm:"type:varchar(100)"`
+	DurationSec    float64
+	Message        string
+	ExecutedDate   *time.Time
+}
+
+func (CicdTestCaseResult) TableName() string {
+	return "cicd_test_case_results"
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
@@ -52,9 +53,18 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&codequality.CqIssueCodeBlock{},
 		&codequality.CqIssue{},
 		&codequality.CqProject{},
+		&codequality.CqFileCoverage{},
+		&codequality.CqProjectAnalysis{},
+		// communication
+		&communication.ChatChannel{},
+		&communication.ChatMessage{},
+		&communication.ChatThread{},
+		&communication.ChatMessageReaction{},
+		&communication.ChatChannelParticipant{},
 		// crossdomain
 		&crossdomain.Account{},
 		&crossdomain.BoardRepo{},
+		&crossdomain.ChatMessageReference{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
@@ -73,6 +83,9 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&devops.CiCDPipelineCommit{},
 		&devops.CicdScope{},
 		&devops.CICDDeployment{},
+		&devops.CicdTestSuite{},
+		&devops.CicdTestCase{},
+		&devops.CicdTestCaseResult{},
 		// didgen no table
 		// ticket
 		&ticket.Board{},
@@ -88,5 +101,13 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.IssueAssignee{},
 		&ticket.IssueRelationship{},
 		&ticket.IssueCustomArrayField{},
+		&ticket.IssueStatusInterval{},
+		&ticket.IssueFlowMetric{},
+		&ticket.SprintAnalytics{},
+		&ticket.SprintIssueAnalytics{},
+		&ticket.SprintBurndown{},
+		&ticket.IssueHierarchy{},
+		&ticket.IncidentResponse{},
+		&ticket.OnCallShift{},
 	}
 }
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IncidentResponse records how an incident was acknowledged, escalated and reassigned before it got resolved
+type IncidentResponse struct {
+	IssueId                  string `gorm:"primaryKey;type:varchar(255)"`
+	TriggeredDate            *time.Time
+	AcknowledgedDate         *time.Time
+	AcknowledgedBy           string `gorm:"type:varchar(255)"`
+	TimeToAcknowledgeMinutes *uint
+	ResolvedDate             *time.Time
+	EscalationCount          int
+	ReassignmentCount        int
+
+	common.NoPKModel
+}
+
+func (IncidentResponse) TableName() string {
+	return "incident_responses"
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueFlowMetric summarizes the status intervals of an issue. The cycle starts when the issue first enters
+// IN_PROGRESS and ends when it enters DONE for the last time, the metrics are left empty for issues which are
+// not done yet
+type IssueFlowMetric struct {
+	IssueId           string `gorm:"primaryKey;type:varchar(255)"`
+	StartedDate       *time.Time
+	DoneDate          *time.Time
+	CycleTimeMinutes  *uint
+	ActiveTimeMinutes *uint
+	WaitTimeMinutes   *uint
+	FlowEfficiency    *float64
+	ReopenCount       int
+
+	common.NoPKModel
+}
+
+func (IssueFlowMetric) TableName() string {
+	return "issue_flow_metrics"
+}
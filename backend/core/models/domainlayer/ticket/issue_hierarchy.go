@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueHierarchy places an issue in the tree built from its parent issue and its epic, and rolls up the
+// estimates, the time spent and the progress of the issue and all of its descendants
+type IssueHierarchy struct {
+	IssueId                      string `gorm:"primaryKey;type:varchar(255)"`
+	ParentIssueId                string `gorm:"index;type:varchar(255)"`
+	RootIssueId                  string `gorm:"index;type:varchar(255)"`
+	Depth                        int
+	ChildCount                   int
+	TotalIssues                  int
+	DoneIssues                   int
+	TotalStoryPoints             float64
+	DoneStoryPoints              float64
+	TotalOriginalEstimateMinutes int64
+	TotalTimeSpentMinutes        int64
+	TotalTimeRemainingMinutes    int64
+	PercentComplete              float64
+
+	common.NoPKModel
+}
+
+func (IssueHierarchy) TableName() string {
+	return "issue_hierarchies"
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueStatusInterval is a period of time an issue stayed in one status, built from the status changelogs
+type IssueStatusInterval struct {
+	IssueId         string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate       time.Time `gorm:"primaryKey"`
+	EndDate         *time.Time
+	OriginalStatus  string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	AssigneeId      string `gorm:"type:varchar(255)"`
+	DurationMinutes *uint
+	IsCurrentStatus bool
+
+	common.NoPKModel
+}
+
+func (IssueStatusInterval) TableName() string {
+	return "issue_status_intervals"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// OnCallShift is a period a person was on call for a board through one level of an escalation policy. The end
+// date is empty when the person is on call permanently
+type OnCallShift struct {
+	domainlayer.DomainEntity
+	BoardId              string `gorm:"index;type:varchar(255)"`
+	ScheduleId           string `gorm:"type:varchar(255)"`
+	ScheduleName         string `gorm:"type:varchar(255)"`
+	EscalationPolicyId   string `gorm:"type:varchar(255)"`
+	EscalationPolicyName string `gorm:"type:varchar(255)"`
+	EscalationLevel      int
+	AccountId            string `gorm:"index;type:varchar(255)"`
+	AccountName          string `gorm:"type:varchar(255)"`
+	StartDate            *time.Time
+	EndDate              *time.Time
+	DurationMinutes      *uint
+}
+
+func (OnCallShift) TableName() string {
+	return "oncall_shifts"
+}
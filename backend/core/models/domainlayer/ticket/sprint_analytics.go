@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// SprintAnalytics summarizes how the scope of a sprint evolved, replayed from the sprint changelogs of its issues
+type SprintAnalytics struct {
+	SprintId               string `gorm:"primaryKey;type:varchar(255)"`
+	StartedDate            *time.Time
+	EndedDate              *time.Time
+	CommittedIssues        int
+	CommittedStoryPoints   float64
+	AddedIssues            int
+	AddedStoryPoints       float64
+	RemovedIssues          int
+	RemovedStoryPoints     float64
+	CompletedIssues        int
+	CompletedStoryPoints   float64
+	CarriedOverIssues      int
+	CarriedOverStoryPoints float64
+
+	common.NoPKModel
+}
+
+func (SprintAnalytics) TableName() string {
+	return "sprint_analytics"
+}
+
+// SprintIssueAnalytics records how an issue took part in a sprint
+type SprintIssueAnalytics struct {
+	SprintId            string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId             string `gorm:"primaryKey;type:varchar(255)"`
+	StoryPoint          float64
+	AddedDate           *time.Time
+	RemovedDate         *time.Time
+	IsCommitted         bool
+	IsAdded             bool
+	IsRemoved           bool
+	IsCompleted         bool
+	IsCarriedOver       bool
+	CarriedOverSprintId string `gorm:"type:varchar(255)"`
+
+	common.NoPKModel
+}
+
+func (SprintIssueAnalytics) TableName() string {
+	return "sprint_issue_analytics"
+}
+
+// SprintBurndown is the scope and the remaining work of a sprint at the end of each day
+type SprintBurndown struct {
+	SprintId             string    `gorm:"primaryKey;type:varchar(255)"`
+	Date                 time.Time `gorm:"primaryKey"`
+	ScopeIssues          int
+	ScopeStoryPoints     float64
+	RemainingIssues      int
+	RemainingStoryPoints float64
+	CompletedStoryPoints float64
+
+	common.NoPKModel
+}
+
+func (SprintBurndown) TableName() string {
+	return "sprint_burndowns"
+}
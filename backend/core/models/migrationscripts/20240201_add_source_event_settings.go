@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addSourceEventSettings)(nil)
+
+type addSourceEventSettings struct{}
+
+type sourceEventSetting20240201 struct {
+	PluginName      string `gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	Enable          bool
+	Secret          string `gorm:"serializer:encdec"`
+	DebounceSeconds int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (sourceEventSetting20240201) TableName() string {
+	return "_devlake_source_event_settings"
+}
+
+func (*addSourceEventSettings) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sourceEventSetting20240201{},
+	)
+}
+
+func (*addSourceEventSettings) Version() uint64 {
+	return 20240201000001
+}
+
+func (*addSourceEventSettings) Name() string {
+	return "add source event settings table"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addApiRateLimits)(nil)
+
+type addApiRateLimits struct{}
+
+type apiRateLimit20240205 struct {
+	LimiterKey  string `gorm:"primaryKey;type:varchar(255)"`
+	WindowStart time.Time
+	Used        int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (apiRateLimit20240205) TableName() string {
+	return "_devlake_api_rate_limits"
+}
+
+func (*addApiRateLimits) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&apiRateLimit20240205{},
+	)
+}
+
+func (*addApiRateLimits) Version() uint64 {
+	return 20240205000001
+}
+
+func (*addApiRateLimits) Name() string {
+	return "add api rate limits table"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCollectorHttpValidators)(nil)
+
+type addCollectorHttpValidators struct{}
+
+type collectorHttpValidator20240212 struct {
+	RawDataTable  string `gorm:"primaryKey;type:varchar(255)"`
+	RawDataParams string `gorm:"primaryKey;type:varchar(255)"`
+	RequestHash   string `gorm:"primaryKey;type:varchar(64)"`
+	Url           string
+	ETag          string `gorm:"type:varchar(255)"`
+	LastModified  string `gorm:"type:varchar(255)"`
+	SeenAt        time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (collectorHttpValidator20240212) TableName() string {
+	return "_devlake_collector_http_validators"
+}
+
+func (*addCollectorHttpValidators) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&collectorHttpValidator20240212{},
+	)
+}
+
+func (*addCollectorHttpValidators) Version() uint64 {
+	return 20240212000001
+}
+
+func (*addCollectorHttpValidators) Name() string {
+	return "add collector http validators table"
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addResponseTimeToProjectIssueMetrics)(nil)
+
+type projectIssueMetric20240214 struct {
+	TimeToAcknowledgeMinutes *uint
+	TimeToRestoreMinutes     *uint
+}
+
+func (projectIssueMetric20240214) TableName() string {
+	return "project_issue_metrics"
+}
+
+type addResponseTimeToProjectIssueMetrics struct{}
+
+func (u *addResponseTimeToProjectIssueMetrics) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.AutoMigrate(&projectIssueMetric20240214{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (*addResponseTimeToProjectIssueMetrics) Version() uint64 {
+	return 20240214000001
+}
+
+func (*addResponseTimeToProjectIssueMetrics) Name() string {
+	return "add time_to_acknowledge_minutes and time_to_restore_minutes to project_issue_metrics"
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCicdTestResults)(nil)
+
+type cicdTestSuite20240218 struct {
+	archived.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	PipelineId  string `gorm:"index;type:varchar(255)"`
+	TaskId      string `gorm:"index;type:varchar(255)"`
+	Name        string `gorm:"type:varchar(255)"`
+	Tests       int
+	Failures    int
+	Errors      int
+	Skipped     int
+	DurationSec float64
+	StartedDate *time.Time
+}
+
+func (cicdTestSuite20240218) TableName() string {
+	return "cicd_test_suites"
+}
+
+type cicdTestCase20240218 struct {
+	archived.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	SuiteName   string `gorm:"type:varchar(255)"`
+	ClassName   string `gorm:"type:varchar(500)"`
+	Name        string `gorm:"type:varchar(500)"`
+	File        string `gorm:"type:varchar(500)"`
+}
+
+func (cicdTestCase20240218) TableName() string {
+	return "cicd_test_cases"
+}
+
+type cicdTestCaseResult20240218 struct {
+	archived.DomainEntity
+	TestCaseId     string `gorm:"index;type:varchar(255)"`
+	TestSuiteId    string `gorm:"index;type:varchar(255)"`
+	CicdScopeId    string `gorm:"index;type:varchar(255)"`
+	PipelineId     string `gorm:"index;type:varchar(255)"`
+	TaskId         string `gorm:"index;type:varchar(255)"`
+	Result         string `gorm:"type:varchar(100)"`
+	OriginalResult string `gorm:"type:varchar(100)"`
+	DurationSec    float64
+	Message        string
+	ExecutedDate   *time.Time
+}
+
+func (cicdTestCaseResult20240218) TableName() string {
+	return "cicd_test_case_results"
+}
+
+type addCicdTestResults struct{}
+
+func (*addCicdTestResults) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&cicdTestSuite20240218{},
+		&cicdTestCase20240218{},
+		&cicdTestCaseResult20240218{},
+	)
+}
+
+func (*addCicdTestResults) Version() uint64 {
+	return 20240218000001
+}
+
+func (*addCicdTestResults) Name() string {
+	return "add cicd_test_suites, cicd_test_cases and cicd_test_case_results"
+}
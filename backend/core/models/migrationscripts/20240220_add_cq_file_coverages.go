@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCqFileCoverages)(nil)
+
+type cqFileCoverage20240220 struct {
+	archived.DomainEntity
+	ProjectKey      string `gorm:"index;type:varchar(255)"`
+	CommitSha       string `gorm:"index;type:varchar(40)"`
+	FilePath        string
+	LinesToCover    int
+	CoveredLines    int
+	UncoveredLines  int
+	Coverage        float64
+	BranchesToCover int
+	CoveredBranches int
+	ReportFormat    string `gorm:"type:varchar(20)"`
+	ReportedDate    *time.Time
+}
+
+func (cqFileCoverage20240220) TableName() string {
+	return "cq_file_coverages"
+}
+
+type addCqFileCoverages struct{}
+
+func (*addCqFileCoverages) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &cqFileCoverage20240220{})
+}
+
+func (*addCqFileCoverages) Version() uint64 {
+	return 20240220000001
+}
+
+func (*addCqFileCoverages) Name() string {
+	return "add cq_file_coverages"
+}
@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCommunicationTables)(nil)
+
+type chatChannel20240226 struct {
+	archived.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Type        string `gorm:"type:varchar(100)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+}
+
+func (chatChannel20240226) TableName() string {
+	return "chat_channels"
+}
+
+type chatMessage20240226 struct {
+	archived.DomainEntity
+	ChannelId   string `gorm:"index;type:varchar(255)"`
+	ThreadId    string `gorm:"index;type:varchar(255)"`
+	AuthorId    string `gorm:"type:varchar(255)"`
+	Content     string
+	Type        string `gorm:"type:varchar(100)"`
+	ReplyCount  int
+	IsDeleted   bool
+	CreatedDate time.Time
+	UpdatedDate *time.Time
+}
+
+func (chatMessage20240226) TableName() string {
+	return "chat_messages"
+}
+
+type chatThread20240226 struct {
+	archived.DomainEntity
+	ChannelId           string `gorm:"index;type:varchar(255)"`
+	AuthorId            string `gorm:"type:varchar(255)"`
+	ReplyCount          int
+	ParticipantCount    int
+	CreatedDate         time.Time
+	FirstReplyDate      *time.Time
+	LastReplyDate       *time.Time
+	ResponseTimeMinutes *uint
+}
+
+func (chatThread20240226) TableName() string {
+	return "chat_threads"
+}
+
+type chatMessageReaction20240226 struct {
+	archived.NoPKModel
+	MessageId string `gorm:"primaryKey;type:varchar(255)"`
+	Name      string `gorm:"primaryKey;type:varchar(100)"`
+	AuthorId  string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (chatMessageReaction20240226) TableName() string {
+	return "chat_message_reactions"
+}
+
+type chatChannelParticipant20240226 struct {
+	archived.NoPKModel
+	ChannelId        string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId         string `gorm:"primaryKey;type:varchar(255)"`
+	MessageCount     int
+	FirstMessageDate *time.Time
+	LastMessageDate  *time.Time
+}
+
+func (chatChannelParticipant20240226) TableName() string {
+	return "chat_channel_participants"
+}
+
+type chatMessageReference20240226 struct {
+	MessageId     string `gorm:"primaryKey;type:varchar(255)"`
+	ReferenceId   string `gorm:"primaryKey;type:varchar(255)"`
+	ReferenceType string `gorm:"type:varchar(100)"`
+	ReferenceKey  string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (chatMessageReference20240226) TableName() string {
+	return "chat_message_references"
+}
+
+type addCommunicationTables struct{}
+
+func (*addCommunicationTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&chatChannel20240226{},
+		&chatMessage20240226{},
+		&chatThread20240226{},
+		&chatMessageReaction20240226{},
+		&chatChannelParticipant20240226{},
+		&chatMessageReference20240226{},
+	)
+}
+
+func (*addCommunicationTables) Version() uint64 {
+	return 20240226000001
+}
+
+func (*addCommunicationTables) Name() string {
+	return "add communication domain tables"
+}
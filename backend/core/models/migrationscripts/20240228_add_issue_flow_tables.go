@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueFlowTables)(nil)
+
+type issueStatusInterval20240228 struct {
+	IssueId         string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate       time.Time `gorm:"primaryKey"`
+	EndDate         *time.Time
+	OriginalStatus  string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	AssigneeId      string `gorm:"type:varchar(255)"`
+	DurationMinutes *uint
+	IsCurrentStatus bool
+	archived.NoPKModel
+}
+
+func (issueStatusInterval20240228) TableName() string {
+	return "issue_status_intervals"
+}
+
+type issueFlowMetric20240228 struct {
+	IssueId           string `gorm:"primaryKey;type:varchar(255)"`
+	StartedDate       *time.Time
+	DoneDate          *time.Time
+	CycleTimeMinutes  *uint
+	ActiveTimeMinutes *uint
+	WaitTimeMinutes   *uint
+	FlowEfficiency    *float64
+	ReopenCount       int
+	archived.NoPKModel
+}
+
+func (issueFlowMetric20240228) TableName() string {
+	return "issue_flow_metrics"
+}
+
+type addIssueFlowTables struct{}
+
+func (*addIssueFlowTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&issueStatusInterval20240228{},
+		&issueFlowMetric20240228{},
+	)
+}
+
+func (*addIssueFlowTables) Version() uint64 {
+	return 20240228000001
+}
+
+func (*addIssueFlowTables) Name() string {
+	return "add issue status intervals and flow metrics"
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addSprintAnalytics)(nil)
+
+type sprintAnalytics20240229 struct {
+	SprintId               string `gorm:"primaryKey;type:varchar(255)"`
+	StartedDate            *time.Time
+	EndedDate              *time.Time
+	CommittedIssues        int
+	CommittedStoryPoints   float64
+	AddedIssues            int
+	AddedStoryPoints       float64
+	RemovedIssues          int
+	RemovedStoryPoints     float64
+	CompletedIssues        int
+	CompletedStoryPoints   float64
+	CarriedOverIssues      int
+	CarriedOverStoryPoints float64
+	archived.NoPKModel
+}
+
+func (sprintAnalytics20240229) TableName() string {
+	return "sprint_analytics"
+}
+
+type sprintIssueAnalytics20240229 struct {
+	SprintId            string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId             string `gorm:"primaryKey;type:varchar(255)"`
+	StoryPoint          float64
+	AddedDate           *time.Time
+	RemovedDate         *time.Time
+	IsCommitted         bool
+	IsAdded             bool
+	IsRemoved           bool
+	IsCompleted         bool
+	IsCarriedOver       bool
+	CarriedOverSprintId string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (sprintIssueAnalytics20240229) TableName() string {
+	return "sprint_issue_analytics"
+}
+
+type sprintBurndown20240229 struct {
+	SprintId             string    `gorm:"primaryKey;type:varchar(255)"`
+	Date                 time.Time `gorm:"primaryKey"`
+	ScopeIssues          int
+	ScopeStoryPoints     float64
+	RemainingIssues      int
+	RemainingStoryPoints float64
+	CompletedStoryPoints float64
+	archived.NoPKModel
+}
+
+func (sprintBurndown20240229) TableName() string {
+	return "sprint_burndowns"
+}
+
+type addSprintAnalytics struct{}
+
+func (*addSprintAnalytics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sprintAnalytics20240229{},
+		&sprintIssueAnalytics20240229{},
+		&sprintBurndown20240229{},
+	)
+}
+
+func (*addSprintAnalytics) Version() uint64 {
+	return 20240229000001
+}
+
+func (*addSprintAnalytics) Name() string {
+	return "add sprint analytics tables"
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueHierarchies)(nil)
+
+type issueHierarchy20240301 struct {
+	IssueId                      string `gorm:"primaryKey;type:varchar(255)"`
+	ParentIssueId                string `gorm:"index;type:varchar(255)"`
+	RootIssueId                  string `gorm:"index;type:varchar(255)"`
+	Depth                        int
+	ChildCount                   int
+	TotalIssues                  int
+	DoneIssues                   int
+	TotalStoryPoints             float64
+	DoneStoryPoints              float64
+	TotalOriginalEstimateMinutes int64
+	TotalTimeSpentMinutes        int64
+	TotalTimeRemainingMinutes    int64
+	PercentComplete              float64
+	archived.NoPKModel
+}
+
+func (issueHierarchy20240301) TableName() string {
+	return "issue_hierarchies"
+}
+
+type addIssueHierarchies struct{}
+
+func (*addIssueHierarchies) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&issueHierarchy20240301{},
+	)
+}
+
+func (*addIssueHierarchies) Version() uint64 {
+	return 20240301000001
+}
+
+func (*addIssueHierarchies) Name() string {
+	return "add issue_hierarchies table"
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIncidentResponseTables)(nil)
+
+type incidentResponse20240302 struct {
+	IssueId                  string `gorm:"primaryKey;type:varchar(255)"`
+	TriggeredDate            *time.Time
+	AcknowledgedDate         *time.Time
+	AcknowledgedBy           string `gorm:"type:varchar(255)"`
+	TimeToAcknowledgeMinutes *uint
+	ResolvedDate             *time.Time
+	EscalationCount          int
+	ReassignmentCount        int
+	archived.NoPKModel
+}
+
+func (incidentResponse20240302) TableName() string {
+	return "incident_responses"
+}
+
+type onCallShift20240302 struct {
+	archived.DomainEntity
+	BoardId              string `gorm:"index;type:varchar(255)"`
+	ScheduleId           string `gorm:"type:varchar(255)"`
+	ScheduleName         string `gorm:"type:varchar(255)"`
+	EscalationPolicyId   string `gorm:"type:varchar(255)"`
+	EscalationPolicyName string `gorm:"type:varchar(255)"`
+	EscalationLevel      int
+	AccountId            string `gorm:"index;type:varchar(255)"`
+	AccountName          string `gorm:"type:varchar(255)"`
+	StartDate            *time.Time
+	EndDate              *time.Time
+	DurationMinutes      *uint
+}
+
+func (onCallShift20240302) TableName() string {
+	return "oncall_shifts"
+}
+
+type addIncidentResponseTables struct{}
+
+func (*addIncidentResponseTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&incidentResponse20240302{},
+		&onCallShift20240302{},
+	)
+}
+
+func (*addIncidentResponseTables) Version() uint64 {
+	return 20240302000001
+}
+
+func (*addIncidentResponseTables) Name() string {
+	return "add incident_responses and oncall_shifts tables"
+}
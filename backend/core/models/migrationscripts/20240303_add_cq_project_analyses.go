@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addCqProjectAnalyses)(nil)
+
+type cqProjectAnalysis20240303 struct {
+	archived.DomainEntity
+	ProjectKey             string `gorm:"index;type:varchar(255)"`
+	AnalysisDate           *time.Time
+	CommitSha              string `gorm:"index;type:varchar(128)"`
+	ProjectVersion         string `gorm:"type:varchar(255)"`
+	QualityGateStatus      string `gorm:"type:varchar(20)"`
+	Bugs                   *int
+	Vulnerabilities        *int
+	CodeSmells             *int
+	SecurityHotspots       *int
+	SqaleIndex             *int
+	SqaleRating            *float64
+	ReliabilityRating      string `gorm:"type:varchar(20)"`
+	SecurityRating         string `gorm:"type:varchar(20)"`
+	Coverage               *float64
+	LinesToCover           *int
+	UncoveredLines         *int
+	DuplicatedLinesDensity *float64
+	Ncloc                  *int
+}
+
+func (cqProjectAnalysis20240303) TableName() string {
+	return "cq_project_analyses"
+}
+
+type addCqProjectAnalyses struct{}
+
+func (*addCqProjectAnalyses) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&cqProjectAnalysis20240303{})
+}
+
+func (*addCqProjectAnalyses) Version() uint64 {
+	return 20240303000001
+}
+
+func (*addCqProjectAnalyses) Name() string {
+	return "add cq_project_analyses table"
+}
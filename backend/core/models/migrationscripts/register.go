@@ -106,5 +106,18 @@ func All() []plugin.MigrationScript {
 		new(modfiyFieldsSort),
 		new(modifyIssueLeadTimeMinutesToUint),
 		new(addUrgencyToIssues),
+		new(addSourceEventSettings),
+		new(addApiRateLimits),
+		new(addCollectorCheckpoints),
+		new(addCollectorHttpValidators),
+		new(addResponseTimeToProjectIssueMetrics),
+		new(addCicdTestResults),
+		new(addCqFileCoverages),
+		new(addCommunicationTables),
+		new(addIssueFlowTables),
+		new(addSprintAnalytics),
+		new(addIssueHierarchies),
+		new(addIncidentResponseTables),
+		new(addCqProjectAnalyses),
 	}
 }
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+const DEFAULT_SOURCE_EVENT_DEBOUNCE_SECONDS = 60
+
+// SourceEventSetting holds the configuration for receiving events pushed by the source system of a connection
+type SourceEventSetting struct {
+	PluginName   string `json:"pluginName" gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64 `json:"connectionId" gorm:"primaryKey"`
+	Enable       bool   `json:"enable"`
+	// Secret is used to verify the signature of the events
+	Secret string `json:"secret" gorm:"serializer:encdec"`
+	// DebounceSeconds is how long to wait for more events before triggering the pipeline
+	DebounceSeconds int       `json:"debounceSeconds"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+func (SourceEventSetting) TableName() string {
+	return "_devlake_source_event_settings"
+}
+
+// GetDebounceSeconds returns the debounce seconds, falls back to the default value if not set
+func (s *SourceEventSetting) GetDebounceSeconds() int {
+	if s.DebounceSeconds <= 0 {
+		return DEFAULT_SOURCE_EVENT_DEBOUNCE_SECONDS
+	}
+	return s.DebounceSeconds
+}
@@ -71,3 +71,11 @@ type ApiResourceHandler func(input *ApiResourceInput) (*ApiResourceOutput, error
 type PluginApi interface {
 	ApiResources() map[string]map[string]ApiResourceHandler
 }
+
+// PluginRawBodyApi: Implement this interface if some APIs of the plugin need the raw body of json requests, i.e. to
+// verify the signature of the payload. The body of these requests would be kept readable from `ApiResourceInput.Request`
+// after being parsed into `ApiResourceInput.Body`
+type PluginRawBodyApi interface {
+	// RawBodyApiResources returns the methods by the path of the APIs, the path is the same as in ApiResources
+	RawBodyApiResources() map[string][]string
+}
@@ -0,0 +1,32 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// DataSourceEventReceiver is implemented by data source plugins that accept events (webhooks)
+// pushed by the source system, so the affected scopes could be synced right away
+type DataSourceEventReceiver interface {
+	// ReceiveSourceEvent verifies the event with the secret configured for the connection and
+	// returns ids of the scopes affected by the event, empty ids means the event should be ignored
+	ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error)
+}
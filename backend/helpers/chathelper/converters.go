@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chathelper
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// ConvertThreads summarizes the chat_messages converted from the raw tables of the plugin into chat_threads,
+// rawArgs is the same as the one used by the message convertor of the plugin
+func ConvertThreads(rawArgs api.RawDataSubTaskArgs, messageTables ...string) errors.Error {
+	cursor, err := messageCursor(rawArgs, messageTables, dal.Where("thread_id != ''"))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	threads := NewThreadAccumulator()
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawArgs,
+		InputRowType:       reflect.TypeOf(communication.ChatMessage{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			return []interface{}{threads.Add(inputRow.(*communication.ChatMessage))}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// ConvertChannelParticipants summarizes the chat_messages converted from the raw tables of the plugin into
+// chat_channel_participants
+func ConvertChannelParticipants(rawArgs api.RawDataSubTaskArgs, messageTables ...string) errors.Error {
+	cursor, err := messageCursor(rawArgs, messageTables, dal.Where("author_id != ''"))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	participants := NewParticipantAccumulator()
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: rawArgs,
+		InputRowType:       reflect.TypeOf(communication.ChatMessage{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			return []interface{}{participants.Add(inputRow.(*communication.ChatMessage))}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// messageCursor reads the chat_messages converted by the plugin in the order they were posted, the accumulators
+// rely on this order
+func messageCursor(rawArgs api.RawDataSubTaskArgs, messageTables []string, clause dal.Clause) (dal.Rows, errors.Error) {
+	rawSubTask, err := api.NewRawDataSubTask(rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	rawTables := make([]string, len(messageTables))
+	for i, table := range messageTables {
+		rawTables[i] = "_raw_" + table
+	}
+	return rawArgs.Ctx.GetDal().Cursor(
+		dal.From(&communication.ChatMessage{}),
+		dal.Where("_raw_data_table IN ? AND _raw_data_params = ?", rawTables, rawSubTask.GetParams()),
+		clause,
+		dal.Orderby("created_date, id"),
+	)
+}
+
+// ThreadAccumulator builds chat_threads out of their messages, messages must be added in the order they were posted
+type ThreadAccumulator struct {
+	threads map[string]*threadState
+}
+
+type threadState struct {
+	thread  *communication.ChatThread
+	authors map[string]bool
+}
+
+func NewThreadAccumulator() *ThreadAccumulator {
+	return &ThreadAccumulator{threads: make(map[string]*threadState)}
+}
+
+// Add updates the thread of the message and returns it
+func (a *ThreadAccumulator) Add(message *communication.ChatMessage) *communication.ChatThread {
+	state := a.threads[message.ThreadId]
+	if state == nil {
+		state = &threadState{
+			thread: &communication.ChatThread{
+				DomainEntity: domainlayer.DomainEntity{Id: message.ThreadId},
+				ChannelId:    message.ChannelId,
+			},
+			authors: make(map[string]bool),
+		}
+		a.threads[message.ThreadId] = state
+	}
+	thread := state.thread
+	if message.AuthorId != "" {
+		state.authors[message.AuthorId] = true
+		thread.ParticipantCount = len(state.authors)
+	}
+	if message.Id == message.ThreadId {
+		thread.AuthorId = message.AuthorId
+		thread.CreatedDate = message.CreatedDate
+		return thread
+	}
+	replyDate := message.CreatedDate
+	thread.ReplyCount++
+	if thread.FirstReplyDate == nil {
+		thread.FirstReplyDate = &replyDate
+	}
+	thread.LastReplyDate = &replyDate
+	// the root message might not be collected, there is nothing to measure the response against then
+	if thread.ResponseTimeMinutes == nil && !thread.CreatedDate.IsZero() && message.AuthorId != thread.AuthorId {
+		minutes := uint(replyDate.Sub(thread.CreatedDate) / time.Minute)
+		thread.ResponseTimeMinutes = &minutes
+	}
+	return thread
+}
+
+// ParticipantAccumulator counts the messages posted by each user in each channel, messages must be added in the order
+// they were posted
+type ParticipantAccumulator struct {
+	participants map[[2]string]*communication.ChatChannelParticipant
+}
+
+func NewParticipantAccumulator() *ParticipantAccumulator {
+	return &ParticipantAccumulator{participants: make(map[[2]string]*communication.ChatChannelParticipant)}
+}
+
+// Add updates the participant who posted the message and returns it
+func (a *ParticipantAccumulator) Add(message *communication.ChatMessage) *communication.ChatChannelParticipant {
+	key := [2]string{message.ChannelId, message.AuthorId}
+	messageDate := message.CreatedDate
+	participant := a.participants[key]
+	if participant == nil {
+		participant = &communication.ChatChannelParticipant{
+			ChannelId:        message.ChannelId,
+			AuthorId:         message.AuthorId,
+			FirstMessageDate: &messageDate,
+		}
+		a.participants[key] = participant
+	}
+	participant.MessageCount++
+	participant.LastMessageDate = &messageDate
+	return participant
+}
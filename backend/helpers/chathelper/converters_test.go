@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chathelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/stretchr/testify/assert"
+)
+
+func chatMessage(id, threadId, authorId string, createdDate time.Time) *communication.ChatMessage {
+	return &communication.ChatMessage{
+		DomainEntity: domainlayer.DomainEntity{Id: id},
+		ChannelId:    "c1",
+		ThreadId:     threadId,
+		AuthorId:     authorId,
+		CreatedDate:  createdDate,
+	}
+}
+
+func TestThreadAccumulator(t *testing.T) {
+	posted := time.Date(2024, 2, 26, 9, 0, 0, 0, time.UTC)
+	threads := NewThreadAccumulator()
+
+	thread := threads.Add(chatMessage("m1", "m1", "alice", posted))
+	assert.Equal(t, "alice", thread.AuthorId)
+	assert.Nil(t, thread.ResponseTimeMinutes)
+
+	// a follow-up from the author is not a response
+	thread = threads.Add(chatMessage("m2", "m1", "alice", posted.Add(3*time.Minute)))
+	assert.Nil(t, thread.ResponseTimeMinutes)
+
+	thread = threads.Add(chatMessage("m3", "m1", "bob", posted.Add(17*time.Minute+30*time.Second)))
+	thread = threads.Add(chatMessage("m4", "m1", "carol", posted.Add(time.Hour)))
+	assert.Equal(t, 3, thread.ReplyCount)
+	assert.Equal(t, 3, thread.ParticipantCount)
+	assert.Equal(t, posted.Add(3*time.Minute), *thread.FirstReplyDate)
+	assert.Equal(t, posted.Add(time.Hour), *thread.LastReplyDate)
+	assert.Equal(t, uint(17), *thread.ResponseTimeMinutes)
+
+	// replies to a root message which was not collected
+	thread = threads.Add(chatMessage("m6", "m5", "bob", posted))
+	assert.Equal(t, 1, thread.ReplyCount)
+	assert.Nil(t, thread.ResponseTimeMinutes)
+}
+
+func TestParticipantAccumulator(t *testing.T) {
+	posted := time.Date(2024, 2, 26, 9, 0, 0, 0, time.UTC)
+	participants := NewParticipantAccumulator()
+
+	participants.Add(chatMessage("m1", "", "alice", posted))
+	participants.Add(chatMessage("m2", "", "bob", posted.Add(time.Minute)))
+	alice := participants.Add(chatMessage("m3", "", "alice", posted.Add(time.Hour)))
+	assert.Equal(t, 2, alice.MessageCount)
+	assert.Equal(t, posted, *alice.FirstMessageDate)
+	assert.Equal(t, posted.Add(time.Hour), *alice.LastMessageDate)
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chathelper
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+var (
+	issueKeyPattern     = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+	pullRequestPattern  = regexp.MustCompile(`https?://[^\s<>|"']+?/(?:pull|merge_requests|pull-requests)/[0-9]+`)
+	deploymentIdPattern = regexp.MustCompile(`(?i)\bdeploy(?:ment)?(?:\s+id)?\s*[#:]?\s*([a-z0-9][\w.:-]*[0-9][\w.:-]*)`)
+)
+
+// trailing punctuation is not part of a deployment id, e.g. "rolled back deployment 42."
+const deploymentIdCutset = ".:-"
+
+// Reference is an issue key, pull request url or deployment id mentioned in a chat message
+type Reference struct {
+	Type string
+	Key  string
+}
+
+// FindReferences returns the distinct references mentioned in the text, in the order they appear
+func FindReferences(text string) []Reference {
+	var refs []Reference
+	seen := make(map[Reference]bool)
+	add := func(refType, key string) {
+		ref := Reference{Type: refType, Key: key}
+		if key != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	for _, url := range pullRequestPattern.FindAllString(text, -1) {
+		add(crossdomain.REFERENCE_TYPE_PULL_REQUEST, url)
+	}
+	for _, key := range issueKeyPattern.FindAllString(text, -1) {
+		add(crossdomain.REFERENCE_TYPE_ISSUE, key)
+	}
+	for _, match := range deploymentIdPattern.FindAllStringSubmatch(text, -1) {
+		add(crossdomain.REFERENCE_TYPE_DEPLOYMENT, strings.TrimRight(match[1], deploymentIdCutset))
+	}
+	return refs
+}
+
+// ReferenceResolver looks up the domain entities mentioned by chat messages, lookups are cached since the same
+// issue or pull request tends to be mentioned over and over in a channel
+type ReferenceResolver struct {
+	db          dal.Dal
+	projectName string
+	cache       map[Reference][]string
+}
+
+// NewReferenceResolver creates a resolver, issues and deployments are only resolved against the boards and cicd scopes
+// of the project, deployments have to be mentioned by the domain id if projectName is empty
+func NewReferenceResolver(db dal.Dal, projectName string) *ReferenceResolver {
+	return &ReferenceResolver{
+		db:          db,
+		projectName: projectName,
+		cache:       make(map[Reference][]string),
+	}
+}
+
+// Resolve finds the references in the text of the message and links them to the issues, pull requests and
+// deployments they point to, references that match nothing are dropped
+func (r *ReferenceResolver) Resolve(messageId string, text string) ([]*crossdomain.ChatMessageReference, errors.Error) {
+	var result []*crossdomain.ChatMessageReference
+	for _, ref := range FindReferences(text) {
+		ids, err := r.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			result = append(result, &crossdomain.ChatMessageReference{
+				MessageId:     messageId,
+				ReferenceId:   id,
+				ReferenceType: ref.Type,
+				ReferenceKey:  ref.Key,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (r *ReferenceResolver) lookup(ref Reference) ([]string, errors.Error) {
+	if ids, ok := r.cache[ref]; ok {
+		return ids, nil
+	}
+	column := "id"
+	var clauses []dal.Clause
+	switch ref.Type {
+	case crossdomain.REFERENCE_TYPE_ISSUE:
+		clauses = r.issueClauses(ref.Key)
+	case crossdomain.REFERENCE_TYPE_PULL_REQUEST:
+		clauses = []dal.Clause{dal.From(&code.PullRequest{}), dal.Where("url = ?", ref.Key)}
+	case crossdomain.REFERENCE_TYPE_DEPLOYMENT:
+		column, clauses = r.deploymentClauses(ref.Key)
+	default:
+		return nil, errors.Default.New("unknown reference type " + ref.Type)
+	}
+	var ids []string
+	err := r.db.Pluck(column, &ids, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[ref] = ids
+	return ids, nil
+}
+
+// issueClauses matches the issue by the key on the boards of the project, the same key is likely to be used by
+// other jira instances
+func (r *ReferenceResolver) issueClauses(key string) []dal.Clause {
+	if r.projectName == "" {
+		return []dal.Clause{dal.From(&ticket.Issue{}), dal.Where("issue_key = ?", key)}
+	}
+	return []dal.Clause{
+		dal.From(&ticket.Issue{}),
+		dal.Where(
+			`issue_key = ? AND id IN (
+				SELECT bi.issue_id FROM board_issues bi
+				JOIN project_mapping pm ON pm.table = 'boards' AND pm.row_id = bi.board_id
+				WHERE pm.project_name = ?
+			)`,
+			key, r.projectName,
+		),
+	}
+}
+
+// deploymentClauses matches the deployment by the domain id, or by the id on the CI/CD tool, which is the last part of
+// the domain id, within the cicd scopes of the project, the same number is likely to be used by other tools
+func (r *ReferenceResolver) deploymentClauses(key string) (string, []dal.Clause) {
+	if r.projectName == "" {
+		return "id", []dal.Clause{dal.From(&devops.CICDDeployment{}), dal.Where("id = ?", key)}
+	}
+	return "d.id", []dal.Clause{
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id"),
+		// compared exactly rather than by LIKE, the id might contain wildcards like `_`
+		dal.Where("pm.project_name = ? AND (d.id = ? OR RIGHT(d.id, ?) = ?)", r.projectName, key, len(key)+1, ":"+key),
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chathelper
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindReferences(t *testing.T) {
+	text := `DEV-123 is caused by <https://github.com/apache/incubator-devlake/pull/4567|#4567>, ` +
+		`rolled back deployment #20240226.3. see DEV-123 and https://gitlab.com/a/b/-/merge_requests/89`
+	assert.Equal(t, []Reference{
+		{Type: crossdomain.REFERENCE_TYPE_PULL_REQUEST, Key: "https://github.com/apache/incubator-devlake/pull/4567"},
+		{Type: crossdomain.REFERENCE_TYPE_PULL_REQUEST, Key: "https://gitlab.com/a/b/-/merge_requests/89"},
+		{Type: crossdomain.REFERENCE_TYPE_ISSUE, Key: "DEV-123"},
+		{Type: crossdomain.REFERENCE_TYPE_DEPLOYMENT, Key: "20240226.3"},
+	}, FindReferences(text))
+
+	assert.Equal(t, []Reference{
+		{Type: crossdomain.REFERENCE_TYPE_DEPLOYMENT, Key: "a1b2c3"},
+	}, FindReferences("Deployment ID: a1b2c3 failed"))
+
+	assert.Empty(t, FindReferences("deployed to production, all good"))
+}
+
+func TestResolveDeploymentWithinProject(t *testing.T) {
+	db := new(mockdal.Dal)
+	db.On("Pluck", "d.id", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		join := args.Get(3).(dal.Clause).Data.(dal.DalClause)
+		assert.Contains(t, join.Expr, "pm.table = 'cicd_scopes'")
+		where := args.Get(4).(dal.Clause).Data.(dal.DalClause)
+		assert.Equal(t, []interface{}{"devlake", "4711", 5, ":4711"}, where.Params)
+		*args.Get(1).(*[]string) = []string{"github:GithubRun:1:4711"}
+	}).Return(nil).Once()
+
+	resolver := NewReferenceResolver(db, "devlake")
+	refs, err := resolver.Resolve("slack:SlackChannelMessage:1:C1:1", "rolled back deployment 4711")
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.ChatMessageReference{{
+		MessageId:     "slack:SlackChannelMessage:1:C1:1",
+		ReferenceId:   "github:GithubRun:1:4711",
+		ReferenceType: crossdomain.REFERENCE_TYPE_DEPLOYMENT,
+		ReferenceKey:  "4711",
+	}}, refs)
+	// cached
+	_, err = resolver.Resolve("slack:SlackChannelMessage:1:C1:2", "deployment 4711 is back")
+	assert.Nil(t, err)
+	db.AssertExpectations(t)
+}
+
+func TestResolveIssueWithinProject(t *testing.T) {
+	db := new(mockdal.Dal)
+	db.On("Pluck", "id", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(3).(dal.Clause).Data.(dal.DalClause)
+		assert.Contains(t, where.Expr, "pm.table = 'boards'")
+		assert.Equal(t, []interface{}{"DL-42", "devlake"}, where.Params)
+		*args.Get(1).(*[]string) = []string{"jira:JiraIssue:1:10042"}
+	}).Return(nil).Once()
+
+	refs, err := NewReferenceResolver(db, "devlake").Resolve("slack:SlackChannelMessage:1:C1:1", "DL-42 is fixed")
+	assert.Nil(t, err)
+	assert.Equal(t, []*crossdomain.ChatMessageReference{{
+		MessageId:     "slack:SlackChannelMessage:1:C1:1",
+		ReferenceId:   "jira:JiraIssue:1:10042",
+		ReferenceType: crossdomain.REFERENCE_TYPE_ISSUE,
+		ReferenceKey:  "DL-42",
+	}}, refs)
+	db.AssertExpectations(t)
+}
+
+func TestResolveDeploymentWithoutProject(t *testing.T) {
+	// without a project the tool id would match the deployments of every CI/CD tool, e.g. github:GithubRun:1:4711
+	// and gitlab:GitlabDeployment:1:4711, so only the domain id is matched
+	db := new(mockdal.Dal)
+	db.On("Pluck", "id", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(3).(dal.Clause).Data.(dal.DalClause)
+		assert.Equal(t, "id = ?", where.Expr)
+		assert.Equal(t, []interface{}{"4711"}, where.Params)
+	}).Return(nil).Once()
+
+	refs, err := NewReferenceResolver(db, "").Resolve("slack:SlackChannelMessage:1:C1:1", "rolled back deployment 4711")
+	assert.Nil(t, err)
+	assert.Empty(t, refs)
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coveragehelper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+type coberturaReport struct {
+	XMLName  xml.Name           `xml:"coverage"`
+	Packages []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Classes []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Filename string          `xml:"filename,attr"`
+	Lines    []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int64  `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"`
+}
+
+// condition-coverage looks like "50% (1/2)"
+var conditionCoveragePattern = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// parseCobertura parses the cobertura xml report, classes of the same file are merged
+func parseCobertura(content []byte) ([]*FileCoverage, errors.Error) {
+	report := &coberturaReport{}
+	if err := xml.Unmarshal(content, report); err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse the cobertura report")
+	}
+	files := newReportFiles()
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			if class.Filename == "" {
+				return nil, errors.BadInput.New("filename of the class is required")
+			}
+			f := files.get(class.Filename)
+			for _, line := range class.Lines {
+				f.hit(line.Number, line.Hits > 0)
+				if !line.Branch || line.ConditionCoverage == "" {
+					continue
+				}
+				matches := conditionCoveragePattern.FindStringSubmatch(line.ConditionCoverage)
+				if matches == nil {
+					return nil, errors.BadInput.New(fmt.Sprintf("invalid condition-coverage %s", line.ConditionCoverage))
+				}
+				covered, _ := strconv.Atoi(matches[1])
+				total, _ := strconv.Atoi(matches[2])
+				f.coveredBranches += covered
+				f.branchesToCover += total
+			}
+		}
+	}
+	return files.coverages(), nil
+}
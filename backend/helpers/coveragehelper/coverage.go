@@ -0,0 +1,172 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coveragehelper
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+)
+
+// supported formats of coverage reports
+const (
+	FORMAT_LCOV      = "lcov"
+	FORMAT_COBERTURA = "cobertura"
+	FORMAT_GOCOVER   = "gocover"
+)
+
+// FileCoverage is the coverage of a file in the report
+type FileCoverage struct {
+	FilePath        string
+	LinesToCover    int
+	CoveredLines    int
+	BranchesToCover int
+	CoveredBranches int
+}
+
+// fileLines collects the hits of lines of a file, reports may mention the same line more than once
+type fileLines struct {
+	path            string
+	lines           map[int]bool
+	branchesToCover int
+	coveredBranches int
+}
+
+func (f *fileLines) hit(line int, covered bool) {
+	f.lines[line] = f.lines[line] || covered
+}
+
+func (f *fileLines) coverage() *FileCoverage {
+	fc := &FileCoverage{
+		FilePath:        f.path,
+		LinesToCover:    len(f.lines),
+		BranchesToCover: f.branchesToCover,
+		CoveredBranches: f.coveredBranches,
+	}
+	for _, covered := range f.lines {
+		if covered {
+			fc.CoveredLines++
+		}
+	}
+	return fc
+}
+
+// reportFiles keeps the files in the order they first appear in the report
+type reportFiles struct {
+	files []*fileLines
+	index map[string]*fileLines
+}
+
+func newReportFiles() *reportFiles {
+	return &reportFiles{index: make(map[string]*fileLines)}
+}
+
+func (r *reportFiles) get(path string) *fileLines {
+	f, ok := r.index[path]
+	if !ok {
+		f = &fileLines{path: path, lines: make(map[int]bool)}
+		r.index[path] = f
+		r.files = append(r.files, f)
+	}
+	return f
+}
+
+func (r *reportFiles) coverages() []*FileCoverage {
+	result := make([]*FileCoverage, 0, len(r.files))
+	for _, f := range r.files {
+		result = append(result, f.coverage())
+	}
+	return result
+}
+
+// DetectFormat guesses the format of the report by its content, empty string is returned if unknown
+func DetectFormat(content []byte) string {
+	content = bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(content, []byte("mode:")):
+		return FORMAT_GOCOVER
+	case bytes.HasPrefix(content, []byte("<")):
+		return FORMAT_COBERTURA
+	case bytes.HasPrefix(content, []byte("TN:")) || bytes.HasPrefix(content, []byte("SF:")):
+		return FORMAT_LCOV
+	}
+	return ""
+}
+
+// ParseOptions locates the files of the report in the repository
+type ParseOptions struct {
+	// GoModulePath is stripped from the import paths of the files in the go coverprofile, i.e. `github.com/org/repo`
+	GoModulePath string
+	// GoModuleDir is the directory of the go module in the repository, empty if it is the root
+	GoModuleDir string
+}
+
+// Parse parses the report in the given format, the format would be detected by the content if omitted
+func Parse(format string, content []byte, options *ParseOptions) ([]*FileCoverage, errors.Error) {
+	if format == "" {
+		format = DetectFormat(content)
+	}
+	switch format {
+	case FORMAT_LCOV:
+		return parseLcov(content)
+	case FORMAT_COBERTURA:
+		return parseCobertura(content)
+	case FORMAT_GOCOVER:
+		return parseGoCover(content, options)
+	case "":
+		return nil, errors.BadInput.New("unable to detect the format of the coverage report")
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported coverage report format %s", format))
+}
+
+// ToCqFileCoverages converts the parsed report of the commit to domain layer entities, files are sorted by path
+func ToCqFileCoverages(projectKey, commitSha, format string, reportedDate *time.Time, files []*FileCoverage) []*codequality.CqFileCoverage {
+	result := make([]*codequality.CqFileCoverage, 0, len(files))
+	for _, f := range files {
+		coverage := &codequality.CqFileCoverage{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%s:%x", projectKey, commitSha, md5.Sum([]byte(f.FilePath))),
+			},
+			ProjectKey:      projectKey,
+			CommitSha:       commitSha,
+			FilePath:        f.FilePath,
+			LinesToCover:    f.LinesToCover,
+			CoveredLines:    f.CoveredLines,
+			UncoveredLines:  f.LinesToCover - f.CoveredLines,
+			BranchesToCover: f.BranchesToCover,
+			CoveredBranches: f.CoveredBranches,
+			ReportFormat:    format,
+			ReportedDate:    reportedDate,
+		}
+		if f.LinesToCover > 0 {
+			coverage.Coverage = math.Round(float64(f.CoveredLines)*10000/float64(f.LinesToCover)) / 100
+		}
+		result = append(result, coverage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FilePath < result[j].FilePath
+	})
+	return result
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coveragehelper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// a block looks like "github.com/org/repo/file.go:10.2,12.16 2 1"
+var goCoverBlockPattern = regexp.MustCompile(`^(.+):(\d+)\.\d+,(\d+)\.\d+ (\d+) (\d+)$`)
+
+// parseGoCover parses the coverprofile generated by `go test -coverprofile`, all lines spanned by a block are
+// considered, a line is covered if any of the blocks on it was executed. Files are named by import paths in the
+// report, they are relative to the repository once the module path is stripped
+func parseGoCover(content []byte, options *ParseOptions) ([]*FileCoverage, errors.Error) {
+	files := newReportFiles()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		matches := goCoverBlockPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, errors.BadInput.New(fmt.Sprintf("line %d: invalid block %s", lineNo, line))
+		}
+		startLine, _ := strconv.Atoi(matches[2])
+		endLine, _ := strconv.Atoi(matches[3])
+		count, _ := strconv.ParseInt(matches[5], 10, 64)
+		f := files.get(goCoverFilePath(matches[1], options))
+		for number := startLine; number <= endLine; number++ {
+			f.hit(number, count > 0)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read the go coverprofile")
+	}
+	return files.coverages(), nil
+}
+
+// goCoverFilePath converts the import path of the file to the path in the repository, i.e.
+// `github.com/org/repo/pkg/main.go` of the module `github.com/org/repo` in `backend` to `backend/pkg/main.go`
+func goCoverFilePath(importPath string, options *ParseOptions) string {
+	if options == nil || options.GoModulePath == "" || !strings.HasPrefix(importPath, options.GoModulePath+"/") {
+		return importPath
+	}
+	return path.Join(options.GoModuleDir, strings.TrimPrefix(importPath, options.GoModulePath+"/"))
+}
+
+// GoModulePath returns the module path declared by the go.mod, empty string is returned if not found
+func GoModulePath(goMod []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "module" {
+			if module, err := strconv.Unquote(fields[1]); err == nil {
+				return module
+			}
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// GoModulePathFromUrl guesses the module path by the url of the repository, which is the module path of most go
+// repositories, i.e. both `https://github.com/org/repo.git` and `git@github.com:org/repo.git` are `github.com/org/repo`
+func GoModulePathFromUrl(repoUrl string) string {
+	var host, repoPath string
+	if u, err := url.Parse(repoUrl); err == nil && u.Host != "" {
+		host, repoPath = u.Hostname(), u.Path
+	} else if at := strings.Index(repoUrl, "@"); at >= 0 {
+		// scp-like syntax of ssh
+		host, repoPath, _ = strings.Cut(repoUrl[at+1:], ":")
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" {
+		return ""
+	}
+	return host + "/" + repoPath
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coveragehelper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// parseLcov parses the tracefile of lcov, see https://manpages.debian.org/unstable/lcov/geninfo.1.en.html
+func parseLcov(content []byte) ([]*FileCoverage, errors.Error) {
+	files := newReportFiles()
+	var current *fileLines
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		tag, value, _ := strings.Cut(line, ":")
+		switch tag {
+		case "SF":
+			current = files.get(value)
+		case "end_of_record":
+			current = nil
+		case "DA", "BRDA":
+			if current == nil {
+				return nil, errors.BadInput.New(fmt.Sprintf("line %d: %s record outside of a source file", lineNo, tag))
+			}
+			fields := strings.Split(value, ",")
+			if tag == "DA" && len(fields) >= 2 {
+				number, err1 := strconv.Atoi(fields[0])
+				hits, err2 := strconv.ParseInt(fields[1], 10, 64)
+				if err1 != nil || err2 != nil {
+					return nil, errors.BadInput.New(fmt.Sprintf("line %d: invalid DA record %s", lineNo, value))
+				}
+				current.hit(number, hits > 0)
+			} else if tag == "BRDA" && len(fields) == 4 {
+				// the taken count is "-" when the block was never executed
+				current.branchesToCover++
+				if taken, err := strconv.ParseInt(fields[3], 10, 64); err == nil && taken > 0 {
+					current.coveredBranches++
+				}
+			} else {
+				return nil, errors.BadInput.New(fmt.Sprintf("line %d: invalid %s record %s", lineNo, tag, value))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read the lcov report")
+	}
+	return files.coverages(), nil
+}
@@ -62,6 +62,9 @@ import (
 //   3. Verify actual data from specified table against expected data from another `csv` file
 //   4. Repeat step 2 and 3
 //
+// Collectors could be tested offline as well, by recording the api fixtures with `RecordApi` once, and replaying
+// them with `ReplayApi` before creating the api client by `TaskContext`
+//
 // Recommended Usage:
 
 // DataFlowTester use `N`
@@ -227,6 +230,26 @@ func (t *DataFlowTester) SubtaskContext(taskData interface{}) plugin.SubTaskCont
 	return contextimpl.NewStandaloneSubTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, taskData)
 }
 
+// TaskContext creates a task context, i.e. for creating the api client of the plugin
+func (t *DataFlowTester) TaskContext() plugin.TaskContext {
+	return contextimpl.NewDefaultTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, nil, nil)
+}
+
+// RecordApi makes api clients created afterward write all requests/responses into the `fixtureArchive`, which
+// could be replayed by ReplayApi later, secrets like tokens and passwords are scrubbed
+func (t *DataFlowTester) RecordApi(fixtureArchive string) {
+	t.Cfg.Set(`API_REPLAY_ARCHIVE`, ``)
+	t.Cfg.Set(`API_RECORD_ARCHIVE`, fixtureArchive)
+}
+
+// ReplayApi makes api clients created afterward serve requests from the fixtures in `fixtureArchive` without network,
+// so collectors could be tested offline
+func (t *DataFlowTester) ReplayApi(fixtureArchive string) {
+	api.ResetApiFixtureReplayer(fixtureArchive)
+	t.Cfg.Set(`API_RECORD_ARCHIVE`, ``)
+	t.Cfg.Set(`API_REPLAY_ARCHIVE`, fixtureArchive)
+}
+
 func filterColumn(column dal.ColumnMeta, opts TableOptions) bool {
 	for _, ignore := range opts.IgnoreFields {
 		if column.Name() == ignore {
@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -44,6 +45,12 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	numOfWorkers int
 	logger       log.Logger
+	rateLimiter  *ConnectionRateLimiter
+
+	rateLimitHeaderParser RateLimitHeaderParser
+	minTickInterval       time.Duration
+	pauseLock             sync.Mutex
+	pausedUntil           time.Time
 }
 
 const defaultTimeout = 120 * time.Second
@@ -122,14 +129,112 @@ func CreateAsyncApiClient(
 		return nil, errors.Default.Wrap(err, "failed to create scheduler")
 	}
 
+	// requests of all tasks of the same connection are throttled by the shared limiter
+	var connectionRateLimiter *ConnectionRateLimiter
+	if apiClient.GetRateLimitKey() != "" {
+		connectionRateLimiter, err = GetConnectionRateLimiter(apiClient.GetRateLimitKey(), requests, duration)
+		if err != nil {
+			return nil, err
+		}
+		sharedByDb, err := utils.StrToBoolOr(taskCtx.GetConfig("API_RATE_LIMIT_SHARED_BY_DB"), false)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse API_RATE_LIMIT_SHARED_BY_DB")
+		}
+		if sharedByDb {
+			connectionRateLimiter.EnableDbCoordination(taskCtx)
+		}
+	}
+
 	// finally, wrap around api client with async sematic
-	return &ApiAsyncClient{
-		apiClient,
-		scheduler,
-		retry,
-		numOfWorkers,
-		logger,
-	}, nil
+	asyncClient := &ApiAsyncClient{
+		ApiClient:       apiClient,
+		WorkerScheduler: scheduler,
+		maxRetry:        retry,
+		numOfWorkers:    numOfWorkers,
+		logger:          logger,
+		rateLimiter:     connectionRateLimiter,
+		minTickInterval: tickInterval,
+	}
+	asyncClient.rateLimitHeaderParser = rateLimiter.RateLimitHeaderParser
+	if asyncClient.rateLimitHeaderParser == nil {
+		asyncClient.rateLimitHeaderParser = ParseStandardRateLimitHeaders
+	}
+	apiClient.rateLimitObserver = asyncClient.adaptRateLimit
+	return asyncClient, nil
+}
+
+// adaptRateLimit adjusts the pace of requests according to the rate limit status carried by the response:
+// pauses until the quota is replenished when asked to or exhausted, slows down to spread the remaining
+// quota evenly, and speeds up again to the calculated rate limit when the quota is sufficient
+func (apiClient *ApiAsyncClient) adaptRateLimit(res *http.Response) {
+	status := apiClient.rateLimitHeaderParser(res)
+	if status == nil {
+		return
+	}
+	now := time.Now()
+	if status.RetryAfter > 0 {
+		apiClient.logger.Info("rate limited by server, pause for %s", status.RetryAfter.String())
+		apiClient.pauseUntil(now.Add(status.RetryAfter))
+		return
+	}
+	if status.Remaining < 0 || status.ResetAt == nil || !status.ResetAt.After(now) {
+		return
+	}
+	if status.Remaining == 0 {
+		apiClient.logger.Info("rate limit exhausted, pause until %s", status.ResetAt.String())
+		apiClient.pauseUntil(*status.ResetAt)
+		return
+	}
+	// the shared limiter throttles all clients of the connection at once
+	if apiClient.rateLimiter != nil {
+		apiClient.rateLimiter.Throttle(status.Remaining, *status.ResetAt)
+		return
+	}
+	interval := status.ResetAt.Sub(now) / time.Duration(status.Remaining)
+	if interval < apiClient.minTickInterval {
+		interval = apiClient.minTickInterval
+	}
+	if interval != apiClient.GetTickInterval() {
+		apiClient.logger.Debug("adapt tick interval to %s, %d requests remaining", interval.String(), status.Remaining)
+		apiClient.Reset(interval)
+	}
+}
+
+func (apiClient *ApiAsyncClient) pauseUntil(t time.Time) {
+	if apiClient.rateLimiter != nil {
+		apiClient.rateLimiter.PauseUntil(t)
+		return
+	}
+	apiClient.pauseLock.Lock()
+	defer apiClient.pauseLock.Unlock()
+	if t.After(apiClient.pausedUntil) {
+		apiClient.pausedUntil = t
+	}
+}
+
+// waitForRateLimit blocks until the request is allowed by the rate limit
+func (apiClient *ApiAsyncClient) waitForRateLimit() errors.Error {
+	ctx := apiClient.WorkerScheduler.ctx
+	if apiClient.rateLimiter != nil {
+		return apiClient.rateLimiter.Wait(ctx)
+	}
+	apiClient.pauseLock.Lock()
+	wait := time.Until(apiClient.pausedUntil)
+	apiClient.pauseLock.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Convert(ctx.Err())
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// GetConnectionRateLimiter returns the rate limiter shared by api clients of the same connection, nil if not available
+func (apiClient *ApiAsyncClient) GetConnectionRateLimiter() *ConnectionRateLimiter {
+	return apiClient.rateLimiter
 }
 
 // GetMaxRetry returns the maximum retry attempts for a request
@@ -161,6 +266,9 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var respBody []byte
 
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		if err := apiClient.waitForRateLimit(); err != nil {
+			return err
+		}
 		res, err = apiClient.Do(method, path, query, body, header)
 		if err == ErrIgnoreAndContinue {
 			// make sure defer func got be executed
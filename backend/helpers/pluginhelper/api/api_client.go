@@ -60,6 +60,10 @@ type ApiClient struct {
 	afterResponse plugin.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	// rateLimitKey identifies the connection for sharing rate limit among api clients
+	rateLimitKey string
+	// rateLimitObserver inspects every response for adapting the rate limit
+	rateLimitObserver func(res *http.Response)
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -71,11 +75,22 @@ func NewApiClientFromConnection(
 	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
 		panic(fmt.Errorf("connection is not a pointer"))
 	}
+	// secrets might be referenced from environment variables or mounted files instead of stored in the database
+	resolved, err := ResolveSecretReferences(br.GetConfigReader(), connection)
+	if err != nil {
+		return nil, err
+	}
+	connection = resolved.(plugin.ApiConnection)
 	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
 	if err != nil {
 		return nil, err
 	}
 
+	// api clients of the same connection share the rate limit
+	if toolConnection, ok := connection.(plugin.ToolLayerConnection); ok {
+		apiClient.SetRateLimitKey(fmt.Sprintf("%s:%d", toolConnection.TableName(), toolConnection.ConnectionId()))
+	}
+
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
 	if prepareApiClient, ok := connection.(plugin.PrepareApiClient); ok {
 		err = prepareApiClient.PrepareApiClient(apiClient)
@@ -120,6 +135,17 @@ func NewApiClient(
 		headers,
 		timeout,
 	)
+	// serve requests from the fixtures recorded previously, no network access at all
+	if replayArchive := br.GetConfig("API_REPLAY_ARCHIVE"); replayArchive != "" {
+		replayer, err := NewApiFixtureReplayer(replayArchive)
+		if err != nil {
+			return nil, err
+		}
+		apiClient.client.Transport = replayer
+		apiClient.SetContext(ctx)
+		return apiClient, nil
+	}
+
 	// create the Transport
 	apiClient.client.Transport = &http.Transport{}
 
@@ -167,6 +193,15 @@ func NewApiClient(
 			return nil, errors.Default.Wrap(err, "Failed to connect")
 		}
 	}
+
+	// record all requests/responses as fixtures for replaying offline
+	if recordArchive := br.GetConfig("API_RECORD_ARCHIVE"); recordArchive != "" {
+		recorder, err := NewApiFixtureRecorder(apiClient.client.Transport, recordArchive)
+		if err != nil {
+			return nil, err
+		}
+		apiClient.client.Transport = recorder
+	}
 	apiClient.SetContext(ctx)
 
 	return apiClient, nil
@@ -195,6 +230,16 @@ func (apiClient *ApiClient) GetEndpoint() string {
 	return apiClient.endpoint
 }
 
+// SetRateLimitKey sets the key identifying the connection, api clients with the same key share the rate limit
+func (apiClient *ApiClient) SetRateLimitKey(key string) {
+	apiClient.rateLimitKey = key
+}
+
+// GetRateLimitKey returns the key identifying the connection for sharing rate limit
+func (apiClient *ApiClient) GetRateLimitKey() string {
+	return apiClient.rateLimitKey
+}
+
 // SetTimeout FIXME ...
 func (apiClient *ApiClient) SetTimeout(timeout time.Duration) {
 	apiClient.client.Timeout = timeout
@@ -266,7 +311,14 @@ func (apiClient *ApiClient) SetProxy(proxyUrl string) errors.Error {
 		return errors.Convert(err)
 	}
 	if pu.Scheme == "http" || pu.Scheme == "socks5" {
-		apiClient.client.Transport.(*http.Transport).Proxy = http.ProxyURL(pu)
+		transport := apiClient.client.Transport
+		if recorder, ok := transport.(*ApiFixtureRecorder); ok {
+			transport = recorder.next
+		}
+		// proxy is meaningless when replaying fixtures
+		if httpTransport, ok := transport.(*http.Transport); ok {
+			httpTransport.Proxy = http.ProxyURL(pu)
+		}
 	}
 	return nil
 }
@@ -347,6 +399,9 @@ func (apiClient *ApiClient) Do(
 		apiClient.logError(err, "[api-client] failed to request %s with error", req.URL.String())
 		return nil, err
 	}
+	if apiClient.rateLimitObserver != nil {
+		apiClient.rateLimitObserver(res)
+	}
 	// after receive
 	if apiClient.afterResponse != nil {
 		err = apiClient.afterResponse(res)
@@ -0,0 +1,406 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ApiFixture is a request/response pair recorded by the ApiClient in record mode
+type ApiFixture struct {
+	Method          string      `json:"method"`
+	Url             string      `json:"url"`
+	RequestHeaders  http.Header `json:"requestHeaders"`
+	RequestBody     string      `json:"requestBody,omitempty"`
+	StatusCode      int         `json:"statusCode"`
+	ResponseHeaders http.Header `json:"responseHeaders"`
+	ResponseBody    string      `json:"responseBody"`
+	// Base64 indicates the response body is not a valid utf8 string and was encoded by base64
+	Base64 bool `json:"base64,omitempty"`
+}
+
+const scrubbedSecret = "__SCRUBBED__"
+
+// headers and query parameters carrying secrets, names are matched exactly so parameters like `projectKey` or
+// `author` are kept
+var secretNames = map[string]bool{
+	// headers
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"private-token":       true,
+	"circle-token":        true,
+	"api-key":             true,
+	"x-api-key":           true,
+	"x-auth-token":        true,
+	"x-access-token":      true,
+	// query parameters
+	"access_token":  true,
+	"private_token": true,
+	"token":         true,
+	"key":           true,
+	"apikey":        true,
+	"api_key":       true,
+	"password":      true,
+	"secret":        true,
+	"client_secret": true,
+	"signature":     true,
+}
+
+func isSecretName(name string) bool {
+	return secretNames[strings.ToLower(name)]
+}
+
+// fixtureScrubber removes secrets from the request/response before they are written to or matched against fixtures
+type fixtureScrubber struct {
+	secrets []string
+}
+
+// newFixtureScrubber collects secret values carried by the request, so they could be removed from urls and bodies as well
+func newFixtureScrubber(req *http.Request) *fixtureScrubber {
+	s := &fixtureScrubber{}
+	for name, values := range req.Header {
+		if !isSecretName(name) {
+			continue
+		}
+		for _, value := range values {
+			s.addSecret(value)
+			// `Bearer xxx`, `Basic base64(user:pass)`, `token xxx`
+			if i := strings.IndexByte(value, ' '); i > 0 {
+				credential := strings.TrimSpace(value[i+1:])
+				s.addSecret(credential)
+				if strings.EqualFold(value[:i], "basic") {
+					if decoded, err := base64.StdEncoding.DecodeString(credential); err == nil {
+						if _, password, ok := strings.Cut(string(decoded), ":"); ok {
+							s.addSecret(password)
+						}
+					}
+				}
+			}
+		}
+	}
+	for name, values := range req.URL.Query() {
+		if isSecretName(name) {
+			for _, value := range values {
+				s.addSecret(value)
+			}
+		}
+	}
+	// replace longer secrets first, in case one contains another
+	sort.Slice(s.secrets, func(i, j int) bool { return len(s.secrets[i]) > len(s.secrets[j]) })
+	return s
+}
+
+func (s *fixtureScrubber) addSecret(secret string) {
+	// too short to be a secret, and replacing it would damage the content
+	if len(secret) < 6 {
+		return
+	}
+	s.secrets = append(s.secrets, secret)
+}
+
+func (s *fixtureScrubber) scrubText(text string) string {
+	for _, secret := range s.secrets {
+		text = strings.ReplaceAll(text, secret, scrubbedSecret)
+	}
+	return text
+}
+
+func (s *fixtureScrubber) scrubHeaders(header http.Header) http.Header {
+	scrubbed := http.Header{}
+	for name, values := range header {
+		for _, value := range values {
+			if isSecretName(name) {
+				value = scrubbedSecret
+			}
+			scrubbed.Add(name, s.scrubText(value))
+		}
+	}
+	return scrubbed
+}
+
+// scrubUrl returns the path and the sorted query of the request with secrets removed, the scheme and host are
+// dropped so fixtures recorded from a customer's server could be replayed against any endpoint
+func (s *fixtureScrubber) scrubUrl(req *http.Request) string {
+	query := req.URL.Query()
+	for name := range query {
+		if isSecretName(name) {
+			query.Set(name, scrubbedSecret)
+		}
+	}
+	uri := req.URL.EscapedPath()
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	return s.scrubText(uri)
+}
+
+// fixtureKey identifies the request, requests with the same key are recorded in sequence
+func fixtureKey(method, uri, body string) string {
+	hash := sha256.Sum256([]byte(method + " " + uri + "\n" + body))
+	return hex.EncodeToString(hash[:8])
+}
+
+func fixtureEntryName(key string, seq int) string {
+	return fmt.Sprintf("%s-%d.json", key, seq)
+}
+
+// readRequestBody reads the body of the request and restores it for the following consumers
+func readRequestBody(req *http.Request) (string, errors.Error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewBuffer(body))
+	return string(body), nil
+}
+
+// fixtureSequencer counts requests with the same key, shared by all api clients using the same fixture archive
+type fixtureSequencer struct {
+	mu      sync.Mutex
+	archive string
+	seqs    map[string]int
+}
+
+func (s *fixtureSequencer) next(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seqs[key]++
+	return s.seqs[key]
+}
+
+var fixtureSequencers sync.Map
+
+// getFixtureSequencer returns the sequencer of the archive, and whether it was created by this call
+func getFixtureSequencer(kind string, archive string) (*fixtureSequencer, bool) {
+	sequencer, loaded := fixtureSequencers.LoadOrStore(kind+":"+archive, &fixtureSequencer{archive: archive, seqs: map[string]int{}})
+	return sequencer.(*fixtureSequencer), !loaded
+}
+
+// ResetApiFixtureReplayer makes the following requests be served from the first recorded responses again
+func ResetApiFixtureReplayer(archive string) {
+	sequencer, _ := getFixtureSequencer("replay", archive)
+	sequencer.mu.Lock()
+	defer sequencer.mu.Unlock()
+	sequencer.seqs = map[string]int{}
+}
+
+// ApiFixtureRecorder is a http.RoundTripper writing every request/response pair into the fixture archive, a tar
+// file which every fixture is appended to as soon as it was recorded, so an interrupted run still leaves a readable
+// archive behind
+type ApiFixtureRecorder struct {
+	next      http.RoundTripper
+	sequencer *fixtureSequencer
+}
+
+// NewApiFixtureRecorder creates a recorder sending requests with `next` and writing fixtures into the `archive`, the
+// archive is truncated by the first recorder of the process
+func NewApiFixtureRecorder(next http.RoundTripper, archive string) (*ApiFixtureRecorder, errors.Error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	sequencer, created := getFixtureSequencer("record", archive)
+	sequencer.mu.Lock()
+	defer sequencer.mu.Unlock()
+	flag := os.O_CREATE | os.O_WRONLY
+	if created {
+		flag |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(archive, flag, 0644)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to create api fixture archive %s", archive))
+	}
+	if err = file.Close(); err != nil {
+		return nil, errors.Convert(err)
+	}
+	return &ApiFixtureRecorder{next: next, sequencer: sequencer}, nil
+}
+
+// append writes the fixture as the next entry of the archive, the end-of-archive marker is never written since the
+// archive is readable without it
+func (r *ApiFixtureRecorder) append(key string, content []byte) errors.Error {
+	r.sequencer.mu.Lock()
+	defer r.sequencer.mu.Unlock()
+	r.sequencer.seqs[key]++
+	name := fixtureEntryName(key, r.sequencer.seqs[key])
+	file, err := os.OpenFile(r.sequencer.archive, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to open api fixture archive %s", r.sequencer.archive))
+	}
+	defer file.Close()
+	writer := tar.NewWriter(file)
+	err = writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	})
+	if err == nil {
+		_, err = writer.Write(content)
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write api fixture %s", name))
+	}
+	return nil
+}
+
+// RoundTrip sends the request and records the response
+func (r *ApiFixtureRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	res, e := r.next.RoundTrip(req)
+	if e != nil {
+		return nil, e
+	}
+	resBody, e := io.ReadAll(res.Body)
+	res.Body.Close()
+	if e != nil {
+		return nil, e
+	}
+	res.Body = io.NopCloser(bytes.NewBuffer(resBody))
+
+	scrubber := newFixtureScrubber(req)
+	fixture := &ApiFixture{
+		Method:          req.Method,
+		Url:             scrubber.scrubUrl(req),
+		RequestHeaders:  scrubber.scrubHeaders(req.Header),
+		RequestBody:     scrubber.scrubText(reqBody),
+		StatusCode:      res.StatusCode,
+		ResponseHeaders: scrubber.scrubHeaders(res.Header),
+	}
+	// the length might be changed by scrubbing
+	fixture.ResponseHeaders.Del("Content-Length")
+	if utf8.Valid(resBody) {
+		fixture.ResponseBody = scrubber.scrubText(string(resBody))
+	} else {
+		fixture.ResponseBody = base64.StdEncoding.EncodeToString(resBody)
+		fixture.Base64 = true
+	}
+	content, e := json.MarshalIndent(fixture, "", "  ")
+	if e != nil {
+		return nil, e
+	}
+	if err = r.append(fixtureKey(fixture.Method, fixture.Url, fixture.RequestBody), content); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ApiFixtureReplayer is a http.RoundTripper serving responses from the fixture archive without network
+type ApiFixtureReplayer struct {
+	sequencer *fixtureSequencer
+	fixtures  map[string][]byte
+}
+
+// NewApiFixtureReplayer creates a replayer serving fixtures recorded in the `archive`
+func NewApiFixtureReplayer(archive string) (*ApiFixtureReplayer, errors.Error) {
+	file, err := os.Open(archive)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("api fixture archive %s is not accessible", archive))
+	}
+	defer file.Close()
+	fixtures := make(map[string][]byte)
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("api fixture archive %s is malformed", archive))
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("api fixture archive %s is malformed", archive))
+		}
+		fixtures[header.Name] = content
+	}
+	sequencer, _ := getFixtureSequencer("replay", archive)
+	return &ApiFixtureReplayer{sequencer: sequencer, fixtures: fixtures}, nil
+}
+
+// RoundTrip finds the fixture matching the request, the n-th identical request would be served with the n-th
+// recorded response, or the last one if they were requested more times than recorded
+func (r *ApiFixtureReplayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	scrubber := newFixtureScrubber(req)
+	uri := scrubber.scrubUrl(req)
+	key := fixtureKey(req.Method, uri, scrubber.scrubText(reqBody))
+	seq := r.sequencer.next(key)
+	var content []byte
+	for ; seq > 0; seq-- {
+		if content = r.fixtures[fixtureEntryName(key, seq)]; content != nil {
+			break
+		}
+	}
+	if seq == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("no api fixture recorded for %s %s", req.Method, uri))
+	}
+	fixture := &ApiFixture{}
+	if e := json.Unmarshal(content, fixture); e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("malformed api fixture for %s %s", req.Method, uri))
+	}
+	body := []byte(fixture.ResponseBody)
+	if fixture.Base64 {
+		var e error
+		if body, e = base64.StdEncoding.DecodeString(fixture.ResponseBody); e != nil {
+			return nil, errors.Default.Wrap(e, fmt.Sprintf("malformed api fixture for %s %s", req.Method, uri))
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.StatusCode, http.StatusText(fixture.StatusCode)),
+		StatusCode:    fixture.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.ResponseHeaders,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"archive/tar"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiFixtureRecordAndReplay(t *testing.T) {
+	const token = "ghp_secretToken123"
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-Request-Count", fmt.Sprint(hits))
+		fmt.Fprintf(w, `{"path":"%s","token":"%s","hits":%d}`, r.URL.Path, token, hits)
+	}))
+	defer server.Close()
+
+	archive := filepath.Join(t.TempDir(), "fixtures.tar")
+	recorder, err := NewApiFixtureRecorder(nil, archive)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: recorder}
+	send := func(client *http.Client, uri string) (*http.Response, string) {
+		req, e := http.NewRequest(http.MethodGet, uri, nil)
+		assert.Nil(t, e)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, e := client.Do(req)
+		assert.Nil(t, e)
+		body, e := io.ReadAll(res.Body)
+		assert.Nil(t, e)
+		return res, string(body)
+	}
+	_, body := send(client, server.URL+"/repos?page=1&access_token="+token)
+	assert.Contains(t, body, token)
+	send(client, server.URL+"/repos?page=1&access_token="+token)
+	send(client, server.URL+"/users")
+	assert.Equal(t, 3, hits)
+
+	// secrets must not be written to the fixtures
+	file, e := os.Open(archive)
+	assert.Nil(t, e)
+	defer file.Close()
+	reader := tar.NewReader(file)
+	entries := 0
+	for {
+		_, e = reader.Next()
+		if e == io.EOF {
+			break
+		}
+		assert.Nil(t, e)
+		content, e := io.ReadAll(reader)
+		assert.Nil(t, e)
+		assert.NotContains(t, string(content), token)
+		entries++
+	}
+	assert.Equal(t, 3, entries)
+
+	// replay against another endpoint without network
+	replayer, err := NewApiFixtureReplayer(archive)
+	assert.Nil(t, err)
+	client = &http.Client{Transport: replayer}
+	endpoint := "http://replay.invalid"
+	res, body := send(client, endpoint+"/repos?access_token="+token+"&page=1")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get("X-Request-Count"))
+	assert.Contains(t, body, `"hits":1`)
+	_, body = send(client, endpoint+"/repos?page=1&access_token="+token)
+	assert.Contains(t, body, `"hits":2`)
+	// requested more times than recorded, the last one is served
+	_, body = send(client, endpoint+"/repos?page=1&access_token="+token)
+	assert.Contains(t, body, `"hits":2`)
+	_, body = send(client, endpoint+"/users")
+	assert.Contains(t, body, `"hits":3`)
+
+	// not recorded
+	req, e := http.NewRequest(http.MethodGet, endpoint+"/repos?page=2", nil)
+	assert.Nil(t, e)
+	_, e = client.Do(req)
+	assert.NotNil(t, e)
+
+	// start over
+	ResetApiFixtureReplayer(archive)
+	_, body = send(client, endpoint+"/repos?page=1&access_token="+token)
+	assert.Contains(t, body, `"hits":1`)
+}
+
+func TestFixtureScrubber(t *testing.T) {
+	req, e := http.NewRequest(http.MethodGet, "https://example.com/api?apiKey=abcdef123&projectKey=devlake&author=octocat&q=open", nil)
+	assert.Nil(t, e)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:p4ssw0rd!")))
+	req.Header.Set("Accept", "application/json")
+	scrubber := newFixtureScrubber(req)
+	// only the exact secret names are scrubbed
+	assert.Equal(t, "/api?apiKey=__SCRUBBED__&author=octocat&projectKey=devlake&q=open", scrubber.scrubUrl(req))
+	headers := scrubber.scrubHeaders(req.Header)
+	assert.Equal(t, scrubbedSecret, headers.Get("Authorization"))
+	assert.Equal(t, "application/json", headers.Get("Accept"))
+	assert.False(t, strings.Contains(scrubber.scrubText(`{"password":"p4ssw0rd!"}`), "p4ssw0rd!"))
+}
@@ -28,6 +28,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -72,7 +73,8 @@ type ApiCollectorArgs struct {
 	GetTotalPages func(res *http.Response, args *ApiCollectorArgs) (int, errors.Error)
 	// PageSize tells ApiCollector the page size
 	PageSize int
-	// GetNextPageCustomData indicate if this collection request each page in order and build query by the prev request
+	// GetNextPageCustomData indicate if this collection request each page in order and build query by the prev request,
+	// the returned value must survive a JSON round trip (i.e. a string cursor) to be resumed from the checkpoint
 	GetNextPageCustomData func(prevReqData *RequestData, prevPageResponse *http.Response) (interface{}, errors.Error)
 	// Incremental indicate if this is an incremental collection, the existing data won't get deleted if it was true
 	Incremental bool `comment:"indicate if this collection is incremental update"`
@@ -89,6 +91,15 @@ type ApiCollectorArgs struct {
 	AfterResponse  plugin.ApiClientAfterResponse
 	RequestBody    func(reqData *RequestData) map[string]interface{}
 	Method         string
+	// DisableCheckpoint stops the collector from recording the collected pages, a failed collection would start
+	// over instead of resuming from where it stopped
+	DisableCheckpoint bool
+	// ConditionalRequest sends requests with `If-None-Match`/`If-Modified-Since` according to the previous responses,
+	// the raw data of a `304 Not Modified` response would be kept instead of downloaded again. It works for apis
+	// without pagination or paginated without the number of total pages only
+	ConditionalRequest bool
+	// since is the time the stateful collector collects from, the pages collected since another time are not resumed
+	since *time.Time
 }
 
 // ApiCollector FIXME ...
@@ -96,6 +107,19 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	checkpoints *collectorCheckpoints
+	// httpValidators are the validators of the previous responses if ConditionalRequest was enabled
+	httpValidators *collectorHttpValidators
+	// conditional is true when the conditional requests should be sent, which is not the case for full sync
+	conditional bool
+}
+
+// collectedPage is the outcome of a page, which decides how to fetch the following pages
+type collectedPage struct {
+	count          int
+	res            *http.Response
+	checkpoint     *models.CollectorCheckpoint
+	nextCustomData interface{}
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -121,6 +145,9 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 	if args.ResponseParser == nil {
 		return nil, errors.Default.New("ResponseParser is required")
 	}
+	if args.ConditionalRequest && args.PageSize > 0 && (args.GetNextPageCustomData != nil || args.GetTotalPages != nil) {
+		return nil, errors.Default.New("ConditionalRequest is not supported when pagination relies on the response")
+	}
 	apiCollector := &ApiCollector{
 		RawDataSubTask: rawDataSubTask,
 		args:           &args,
@@ -160,13 +187,34 @@ func (collector *ApiCollector) Execute() errors.Error {
 		return errors.Default.Wrap(err, "error auto-migrating collector")
 	}
 
+	startedAt := time.Now()
 	isIncremental := collector.args.Incremental
 	syncPolicy := collector.args.Ctx.TaskContext().SyncPolicy()
 	if syncPolicy != nil && syncPolicy.FullSync {
 		isIncremental = false
 	}
-	// flush data if not incremental collection
-	if !isIncremental {
+	// validators are recorded for full sync as well, but the requests would not be conditional
+	if collector.args.ConditionalRequest {
+		collector.httpValidators, err = loadCollectorHttpValidators(db, collector.table, collector.params)
+		if err != nil {
+			return err
+		}
+		collector.conditional = syncPolicy == nil || !syncPolicy.FullSync
+	}
+	// resume from the pages collected by the previous failed attempt, the raw data were flushed by it already
+	if !collector.args.DisableCheckpoint {
+		queryHash := checkpointQueryHash(collector.args.UrlTemplate, isIncremental, collector.args.since)
+		fullSync := syncPolicy != nil && syncPolicy.FullSync
+		collector.checkpoints, err = loadCollectorCheckpoints(db, collector.table, collector.params, queryHash, fullSync)
+		if err != nil {
+			return err
+		}
+	}
+	if collector.checkpoints.resuming() {
+		logger.Info("resume api collection from checkpoints")
+		startedAt = collector.checkpoints.startedAt()
+	} else if !isIncremental && !collector.conditional {
+		// flush data if not incremental collection
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
@@ -231,6 +279,13 @@ func (collector *ApiCollector) Execute() errors.Error {
 		err = errors.Default.Wrap(err, "Error waiting for async Collector execution")
 	} else {
 		logger.Info("end api collection without error")
+		// raw data of the unchanged resources were kept, the ones no longer exist should be removed
+		if collector.conditional && !isIncremental {
+			err = collector.httpValidators.removeStale(db, collector.table, startedAt)
+		}
+		if err == nil {
+			err = collector.checkpoints.clear()
+		}
 	}
 
 	return err
@@ -248,9 +303,12 @@ func (collector *ApiCollector) exec(input interface{}) {
 		Page: 1,
 		Size: collector.args.PageSize,
 	}
+	inputHash := checkpointInputHash(collector.args.Input != nil, inputJson)
 	// fetch the detail
 	if collector.args.PageSize <= 0 {
-		collector.fetchAsync(reqData, nil)
+		if collector.checkpoints.get(inputHash, 1) == nil {
+			collector.fetchAsync(reqData, nil)
+		}
 		// fetch pages sequentially
 	} else if collector.args.GetNextPageCustomData != nil {
 		collector.fetchPagesSequentially(reqData)
@@ -265,21 +323,28 @@ func (collector *ApiCollector) exec(input interface{}) {
 
 // fetchPagesSequentially fetches data of all pages in order to build RequestData by prev response
 func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
+	// continue with the page next to the last collected one
+	if last := collector.checkpoints.last(collector.inputHash(reqData)); last != nil {
+		if last.Finished {
+			return
+		}
+		var customData interface{}
+		if len(last.NextCustomData) > 0 {
+			if err := json.Unmarshal(last.NextCustomData, &customData); err != nil {
+				panic(errors.Default.Wrap(err, "failed to resume the custom data from the checkpoint"))
+			}
+		}
+		reqData.CustomData = customData
+		reqData.Pager.Page = last.Page + 1
+		reqData.Pager.Skip = collector.args.PageSize * last.Page
+	}
 	var collect func() errors.Error
 	collect = func() errors.Error {
-		collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
-			if count < collector.args.PageSize {
+		collector.fetchAsync(reqData, func(page *collectedPage) errors.Error {
+			if page.checkpoint.Finished {
 				return nil
 			}
-			customData, err := collector.args.GetNextPageCustomData(reqData, res)
-			if err != nil {
-				if errors.Is(err, ErrFinishCollect) {
-					return nil
-				} else {
-					panic(err)
-				}
-			}
-			reqData.CustomData = customData
+			reqData.CustomData = page.nextCustomData
 			reqData.Pager.Skip += collector.args.PageSize
 			reqData.Pager.Page += 1
 			collector.args.ApiClient.NextTick(collect)
@@ -292,28 +357,38 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 
 // fetchPagesDetermined fetches data of all pages for APIs that return paging information
 func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
+	// the total number of pages was recorded along with the first page
+	if first := collector.checkpoints.get(collector.inputHash(reqData), 1); first != nil {
+		collector.fetchOtherPagesDetermined(reqData, first.TotalPages)
+		return
+	}
 	// fetch first page
-	collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
-		totalPages, err := collector.args.GetTotalPages(res, collector.args)
-		if err != nil {
-			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
-		}
-		// spawn a none blocking go routine to fetch other pages
-		collector.args.ApiClient.NextTick(func() errors.Error {
-			for page := 2; page <= totalPages; page++ {
-				reqDataTemp := &RequestData{
-					Pager: &Pager{
-						Page: page,
-						Skip: collector.args.PageSize * (page - 1),
-						Size: collector.args.PageSize,
-					},
-					Input:     reqData.Input,
-					InputJSON: reqData.InputJSON,
-				}
-				collector.fetchAsync(reqDataTemp, nil)
+	collector.fetchAsync(reqData, func(page *collectedPage) errors.Error {
+		collector.fetchOtherPagesDetermined(reqData, page.checkpoint.TotalPages)
+		return nil
+	})
+}
+
+// fetchOtherPagesDetermined fetches the pages after the first page, skipping those already collected
+func (collector *ApiCollector) fetchOtherPagesDetermined(reqData *RequestData, totalPages int) {
+	inputHash := collector.inputHash(reqData)
+	// spawn a none blocking go routine to fetch other pages
+	collector.args.ApiClient.NextTick(func() errors.Error {
+		for page := 2; page <= totalPages; page++ {
+			if collector.checkpoints.get(inputHash, page) != nil {
+				continue
 			}
-			return nil
-		})
+			reqDataTemp := &RequestData{
+				Pager: &Pager{
+					Page: page,
+					Skip: collector.args.PageSize * (page - 1),
+					Size: collector.args.PageSize,
+				},
+				Input:     reqData.Input,
+				InputJSON: reqData.InputJSON,
+			}
+			collector.fetchAsync(reqDataTemp, nil)
+		}
 		return nil
 	})
 }
@@ -341,6 +416,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 			}
 		}
 	}
+	inputHash := collector.inputHash(reqData)
 	for i := 0; i < concurrency; i++ {
 		reqDataCopy := RequestData{
 			Pager: &Pager{
@@ -351,10 +427,24 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 			Input:     reqData.Input,
 			InputJSON: reqData.InputJSON,
 		}
+		// skip the pages collected already, returns false if the last page was among them
+		skipCollected := func() bool {
+			for checkpoint := collector.checkpoints.get(inputHash, reqDataCopy.Pager.Page); checkpoint != nil; checkpoint = collector.checkpoints.get(inputHash, reqDataCopy.Pager.Page) {
+				if checkpoint.Finished {
+					return false
+				}
+				reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
+				reqDataCopy.Pager.Page += concurrency
+			}
+			return true
+		}
 		var collect func() errors.Error
 		collect = func() errors.Error {
-			collector.fetchAsync(&reqDataCopy, func(count int, body []byte, res *http.Response) errors.Error {
-				if count < collector.args.PageSize {
+			if !skipCollected() {
+				return nil
+			}
+			collector.fetchAsync(&reqDataCopy, func(page *collectedPage) errors.Error {
+				if page.checkpoint.Finished {
 					return nil
 				}
 				apiClient.NextTick(func() errors.Error {
@@ -370,6 +460,137 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 	}
 }
 
+func (collector *ApiCollector) inputHash(reqData *RequestData) string {
+	return checkpointInputHash(collector.args.Input != nil, reqData.InputJSON)
+}
+
+// handleNotModified keeps the raw data collected previously for the unchanged resource, and continues with the
+// following pages as if they were downloaded again
+func (collector *ApiCollector) handleNotModified(
+	reqData *RequestData,
+	res *http.Response,
+	requestHash string,
+	handler func(*collectedPage) errors.Error,
+) errors.Error {
+	db := collector.args.Ctx.GetDal()
+	count, err := db.Count(dal.From(collector.table), dal.Where("params = ? AND url = ?", collector.params, res.Request.URL.String()))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error counting raw rows of %s", res.Request.URL.String()))
+	}
+	page := &collectedPage{
+		count: int(count),
+		res:   res,
+		checkpoint: &models.CollectorCheckpoint{
+			InputHash: collector.inputHash(reqData),
+			Page:      reqData.Pager.Page,
+			Count:     int(count),
+			Finished:  handler == nil || count == 0 || int(count) < collector.args.PageSize,
+		},
+	}
+	err = collector.savePage(db, nil, page.checkpoint, collector.httpValidators.fromResponse(requestHash, res))
+	if err != nil {
+		return err
+	}
+	collector.args.Ctx.IncProgress(1)
+	if handler != nil && count > 0 {
+		return handler(page)
+	}
+	return nil
+}
+
+// savePage inserts the raw rows of the page, along with the checkpoint and the http validator if they were enabled
+// in a transaction. Raw rows collected previously by the same request are replaced when conditional requests enabled
+func (collector *ApiCollector) savePage(
+	db dal.Dal,
+	rows []*RawData,
+	checkpoint *models.CollectorCheckpoint,
+	validator *models.CollectorHttpValidator,
+) (err errors.Error) {
+	if collector.checkpoints == nil && validator == nil {
+		if len(rows) == 0 {
+			return nil
+		}
+		return db.Create(rows, dal.From(collector.table))
+	}
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	if validator != nil && len(rows) > 0 {
+		err = tx.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ? AND url = ?", collector.params, validator.Url))
+		if err != nil {
+			return err
+		}
+	}
+	if len(rows) > 0 {
+		err = tx.Create(rows, dal.From(collector.table))
+		if err != nil {
+			return err
+		}
+	}
+	if collector.checkpoints != nil {
+		checkpoint.RawDataTable = collector.checkpoints.table
+		checkpoint.RawDataParams = collector.checkpoints.params
+		checkpoint.QueryHash = collector.checkpoints.queryHash
+		err = tx.CreateOrUpdate(checkpoint)
+		if err != nil {
+			return err
+		}
+	}
+	if validator != nil {
+		err = tx.CreateOrUpdate(validator)
+		if err != nil {
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	if collector.checkpoints != nil {
+		collector.checkpoints.record(checkpoint)
+	}
+	if validator != nil {
+		collector.httpValidators.record(validator)
+	}
+	return nil
+}
+
+// preparePagination extracts the information for fetching the following pages from the response
+func (collector *ApiCollector) preparePagination(reqData *RequestData, page *collectedPage) errors.Error {
+	switch {
+	case collector.args.GetNextPageCustomData != nil:
+		if page.checkpoint.Finished {
+			return nil
+		}
+		customData, err := collector.args.GetNextPageCustomData(reqData, page.res)
+		if err != nil {
+			if errors.Is(err, ErrFinishCollect) {
+				page.checkpoint.Finished = true
+				return nil
+			}
+			return errors.Default.Wrap(err, "failed to get the custom data of the next page")
+		}
+		page.nextCustomData = customData
+		if customData != nil {
+			customDataJson, e := json.Marshal(customData)
+			if e != nil {
+				return errors.Default.Wrap(e, "failed to serialize the custom data of the next page")
+			}
+			page.checkpoint.NextCustomData = customDataJson
+		}
+	case collector.args.GetTotalPages != nil && reqData.Pager.Page == 1:
+		totalPages, err := collector.args.GetTotalPages(page.res, collector.args)
+		if err != nil {
+			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
+		}
+		page.checkpoint.TotalPages = totalPages
+	}
+	return nil
+}
+
 func (collector *ApiCollector) generateUrl(pager *Pager, input interface{}) (string, errors.Error) {
 	params := collector.args.Params
 	if collector.args.Options != nil {
@@ -397,7 +618,7 @@ func (collector *ApiCollector) SetAfterResponse(f plugin.ApiClientAfterResponse)
 	collector.args.ApiClient.SetAfterFunction(f)
 }
 
-func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int, []byte, *http.Response) errors.Error) {
+func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(*collectedPage) errors.Error) {
 	if reqData.Pager == nil {
 		reqData.Pager = &Pager{
 			Page: 1,
@@ -431,11 +652,18 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	requestHash := httpRequestHash(apiUrl, apiQuery)
+	if collector.conditional {
+		apiHeader = collector.httpValidators.conditionalHeader(requestHash, apiHeader)
+	}
 	logger := collector.args.Ctx.GetLogger()
 	logger.Debug("fetchAsync <<< enqueueing for %s %v", apiUrl, apiQuery)
 	responseHandler := func(res *http.Response) errors.Error {
 		defer logger.Debug("fetchAsync >>> done for %s %v %v", apiUrl, apiQuery, collector.args.RequestBody)
 		logger := collector.args.Ctx.GetLogger()
+		if res.StatusCode == http.StatusNotModified && collector.httpValidators != nil {
+			return collector.handleNotModified(reqData, res, requestHash, handler)
+		}
 		// read body to buffer
 		body, err := io.ReadAll(res.Body)
 		if err != nil {
@@ -453,12 +681,25 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 				return errors.Default.Wrap(err, fmt.Sprintf("error parsing response from %s", apiUrl))
 			}
 		}
-		// save to db
 		count := len(items)
-		if count == 0 {
-			collector.args.Ctx.IncProgress(1)
-			return nil
+		page := &collectedPage{
+			count: count,
+			res:   res,
+			checkpoint: &models.CollectorCheckpoint{
+				InputHash: collector.inputHash(reqData),
+				Page:      reqData.Pager.Page,
+				Count:     count,
+				Finished:  handler == nil || count == 0 || count < collector.args.PageSize,
+			},
+		}
+		// figure out how to fetch the following pages before saving, so they could be saved along with the page
+		if handler != nil && count > 0 {
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+			if paginationErr := collector.preparePagination(reqData, page); paginationErr != nil {
+				return paginationErr
+			}
 		}
+		// save to db
 		db := collector.args.Ctx.GetDal()
 		urlString := res.Request.URL.String()
 		rows := make([]*RawData, count)
@@ -470,17 +711,17 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 				Input:  reqData.InputJSON,
 			}
 		}
-		err = db.Create(rows, dal.From(collector.table))
+		err = collector.savePage(db, rows, page.checkpoint, collector.httpValidators.fromResponse(requestHash, res))
 		if err != nil {
 			return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
 		}
 		logger.Debug("fetchAsync === total %d rows were saved into database", count)
 		// increase progress only when it was not nested
 		collector.args.Ctx.IncProgress(1)
-		if handler != nil {
+		if handler != nil && count > 0 {
 			// trigger next fetch, but return if ErrFinishCollect got from ResponseParser
 			res.Body = io.NopCloser(bytes.NewBuffer(body))
-			return handler(count, body, res)
+			return handler(page)
 		}
 		return nil
 	}
@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// checkpoints left by a collection failed long ago are discarded, the data might have changed a lot since then
+const collectorCheckpointExpiry = 72 * time.Hour
+
+// collectorCheckpoints keeps track of the pages collected by the ApiCollector, a nil *collectorCheckpoints means
+// checkpoints are disabled
+type collectorCheckpoints struct {
+	mu        sync.Mutex
+	db        dal.Dal
+	table     string
+	params    string
+	queryHash string
+	pages     map[string]map[int]*models.CollectorCheckpoint
+}
+
+// loadCollectorCheckpoints loads the checkpoints of the collection identified by the queryHash, checkpoints left by
+// other collections, i.e. an incremental collection since another time, are deleted. A full sync always starts over
+func loadCollectorCheckpoints(db dal.Dal, table string, params string, queryHash string, fullSync bool) (*collectorCheckpoints, errors.Error) {
+	c := &collectorCheckpoints{
+		db:        db,
+		table:     table,
+		params:    params,
+		queryHash: queryHash,
+		pages:     make(map[string]map[int]*models.CollectorCheckpoint),
+	}
+	scope := dal.Where("raw_data_table = ? AND raw_data_params = ?", table, params)
+	if fullSync {
+		err := db.Delete(&models.CollectorCheckpoint{}, scope)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to delete collector checkpoints for full sync")
+		}
+		return c, nil
+	}
+	err := db.Delete(
+		&models.CollectorCheckpoint{},
+		scope,
+		dal.Where("updated_at < ? OR query_hash != ?", time.Now().Add(-collectorCheckpointExpiry), queryHash),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to delete stale collector checkpoints")
+	}
+	var checkpoints []*models.CollectorCheckpoint
+	err = db.All(&checkpoints, scope, dal.Where("query_hash = ?", queryHash))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load collector checkpoints")
+	}
+	for _, checkpoint := range checkpoints {
+		c.put(checkpoint)
+	}
+	return c, nil
+}
+
+func (c *collectorCheckpoints) put(checkpoint *models.CollectorCheckpoint) {
+	pages, ok := c.pages[checkpoint.InputHash]
+	if !ok {
+		pages = make(map[int]*models.CollectorCheckpoint)
+		c.pages[checkpoint.InputHash] = pages
+	}
+	pages[checkpoint.Page] = checkpoint
+}
+
+// resuming returns true if there were pages collected by a failed collection
+func (c *collectorCheckpoints) resuming() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pages) > 0
+}
+
+// get returns the checkpoint of the page of the input, nil if the page was not collected
+func (c *collectorCheckpoints) get(inputHash string, page int) *models.CollectorCheckpoint {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pages[inputHash][page]
+}
+
+// last returns the checkpoint of the last page collected for the input, nil if none was collected
+func (c *collectorCheckpoints) last(inputHash string) *models.CollectorCheckpoint {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var last *models.CollectorCheckpoint
+	for _, checkpoint := range c.pages[inputHash] {
+		if last == nil || checkpoint.Page > last.Page {
+			last = checkpoint
+		}
+	}
+	return last
+}
+
+// record keeps the checkpoint saved along with the raw rows of the page
+func (c *collectorCheckpoints) record(checkpoint *models.CollectorCheckpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(checkpoint)
+}
+
+// startedAt returns when the failed collection being resumed started, zero if not resuming
+func (c *collectorCheckpoints) startedAt() time.Time {
+	var startedAt time.Time
+	if c == nil {
+		return startedAt
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pages := range c.pages {
+		for _, checkpoint := range pages {
+			if startedAt.IsZero() || checkpoint.CreatedAt.Before(startedAt) {
+				startedAt = checkpoint.CreatedAt
+			}
+		}
+	}
+	return startedAt
+}
+
+// clear removes all checkpoints once the collection finished successfully
+func (c *collectorCheckpoints) clear() errors.Error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pages = make(map[string]map[int]*models.CollectorCheckpoint)
+	return c.db.Delete(
+		&models.CollectorCheckpoint{},
+		dal.Where("raw_data_table = ? AND raw_data_params = ?", c.table, c.params),
+	)
+}
+
+// checkpointInputHash identifies the input of the collector
+func checkpointInputHash(hasInput bool, inputJson []byte) string {
+	if !hasInput {
+		return ""
+	}
+	hash := sha256.Sum256(inputJson)
+	return hex.EncodeToString(hash[:])
+}
+
+// checkpointQueryHash identifies the collection, the query of an incremental collection depends on the time since
+// which the data were changed, pages collected by a full collection don't fit an incremental one either
+func checkpointQueryHash(urlTemplate string, incremental bool, since *time.Time) string {
+	sinceText := ""
+	if since != nil {
+		sinceText = since.UTC().Format(time.RFC3339Nano)
+	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s\n%v\n%s", urlTemplate, incremental, sinceText)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorCheckpoints(t *testing.T) {
+	// disabled
+	var disabled *collectorCheckpoints
+	assert.False(t, disabled.resuming())
+	assert.Nil(t, disabled.get("", 1))
+	assert.Nil(t, disabled.last(""))
+	assert.Nil(t, disabled.clear())
+
+	c := &collectorCheckpoints{pages: make(map[string]map[int]*models.CollectorCheckpoint)}
+	assert.False(t, c.resuming())
+	input1 := checkpointInputHash(true, []byte(`{"IssueId":1}`))
+	input2 := checkpointInputHash(true, []byte(`{"IssueId":2}`))
+	assert.NotEqual(t, input1, input2)
+	assert.Len(t, input1, 64)
+	assert.Equal(t, "", checkpointInputHash(false, []byte(`null`)))
+
+	c.put(&models.CollectorCheckpoint{InputHash: input1, Page: 1, Count: 100})
+	c.put(&models.CollectorCheckpoint{InputHash: input1, Page: 3, Count: 100})
+	c.put(&models.CollectorCheckpoint{InputHash: input1, Page: 2, Count: 100})
+	c.put(&models.CollectorCheckpoint{InputHash: input2, Page: 1, Count: 10, Finished: true})
+	assert.True(t, c.resuming())
+	assert.Equal(t, 3, c.last(input1).Page)
+	assert.True(t, c.last(input2).Finished)
+	assert.NotNil(t, c.get(input1, 2))
+	assert.Nil(t, c.get(input1, 4))
+	assert.Nil(t, c.last("unknown"))
+}
+
+func TestCheckpointQueryHash(t *testing.T) {
+	since := time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC)
+	later := since.Add(time.Hour)
+	full := checkpointQueryHash("issues", false, nil)
+	incremental := checkpointQueryHash("issues", true, &since)
+	assert.Len(t, full, 64)
+	assert.Equal(t, incremental, checkpointQueryHash("issues", true, &since))
+	assert.NotEqual(t, full, incremental)
+	assert.NotEqual(t, incremental, checkpointQueryHash("issues", true, &later))
+	assert.NotEqual(t, full, checkpointQueryHash("changelogs", false, nil))
+}
@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// collectorHttpValidators keeps the validators of the responses of the ApiCollector for sending conditional
+// requests, a nil *collectorHttpValidators means conditional requests are disabled
+type collectorHttpValidators struct {
+	mu         sync.Mutex
+	table      string
+	params     string
+	validators map[string]*models.CollectorHttpValidator
+}
+
+func loadCollectorHttpValidators(db dal.Dal, table string, params string) (*collectorHttpValidators, errors.Error) {
+	var validators []*models.CollectorHttpValidator
+	err := db.All(&validators, dal.Where("raw_data_table = ? AND raw_data_params = ?", table, params))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load collector http validators")
+	}
+	v := &collectorHttpValidators{
+		table:      table,
+		params:     params,
+		validators: make(map[string]*models.CollectorHttpValidator, len(validators)),
+	}
+	for _, validator := range validators {
+		v.validators[validator.RequestHash] = validator
+	}
+	return v, nil
+}
+
+// conditionalHeader returns the header with the conditions added if the request was sent before
+func (v *collectorHttpValidators) conditionalHeader(requestHash string, header http.Header) http.Header {
+	if v == nil {
+		return header
+	}
+	v.mu.Lock()
+	validator := v.validators[requestHash]
+	v.mu.Unlock()
+	if validator == nil || (validator.ETag == "" && validator.LastModified == "") {
+		return header
+	}
+	if header == nil {
+		header = http.Header{}
+	} else {
+		header = header.Clone()
+	}
+	if validator.ETag != "" {
+		header.Set("If-None-Match", validator.ETag)
+	}
+	if validator.LastModified != "" {
+		header.Set("If-Modified-Since", validator.LastModified)
+	}
+	return header
+}
+
+// fromResponse returns the validator of the response to be saved, the validators of a `304 Not Modified` response
+// are kept if the server didn't send them again
+func (v *collectorHttpValidators) fromResponse(requestHash string, res *http.Response) *models.CollectorHttpValidator {
+	if v == nil {
+		return nil
+	}
+	validator := &models.CollectorHttpValidator{
+		RawDataTable:  v.table,
+		RawDataParams: v.params,
+		RequestHash:   requestHash,
+		Url:           res.Request.URL.String(),
+		ETag:          res.Header.Get("ETag"),
+		LastModified:  res.Header.Get("Last-Modified"),
+		SeenAt:        time.Now(),
+	}
+	if res.StatusCode == http.StatusNotModified {
+		v.mu.Lock()
+		previous := v.validators[requestHash]
+		v.mu.Unlock()
+		if previous != nil {
+			if validator.ETag == "" {
+				validator.ETag = previous.ETag
+			}
+			if validator.LastModified == "" {
+				validator.LastModified = previous.LastModified
+			}
+		}
+	}
+	return validator
+}
+
+// record keeps the validator saved along with the raw rows
+func (v *collectorHttpValidators) record(validator *models.CollectorHttpValidator) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.validators[validator.RequestHash] = validator
+}
+
+// removeStale deletes the raw data and validators of the requests not sent since the collection started, which
+// were collected by previous collections and no longer exist, i.e. the pull request was deleted
+func (v *collectorHttpValidators) removeStale(db dal.Dal, rawTable string, startedAt time.Time) errors.Error {
+	if v == nil {
+		return nil
+	}
+	err := db.Delete(
+		&RawData{},
+		dal.From(rawTable),
+		dal.Where(
+			`params = ? AND url NOT IN (
+				SELECT url FROM _devlake_collector_http_validators
+				WHERE raw_data_table = ? AND raw_data_params = ? AND seen_at >= ?
+			)`,
+			v.params, v.table, v.params, startedAt,
+		),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete stale raw data")
+	}
+	return db.Delete(
+		&models.CollectorHttpValidator{},
+		dal.Where("raw_data_table = ? AND raw_data_params = ? AND seen_at < ?", v.table, v.params, startedAt),
+	)
+}
+
+// httpRequestHash identifies the request by its path and query
+func httpRequestHash(apiUrl string, query url.Values) string {
+	hash := sha256.Sum256([]byte(apiUrl + "?" + query.Encode()))
+	return hex.EncodeToString(hash[:])
+}
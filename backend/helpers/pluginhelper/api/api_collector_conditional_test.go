@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorHttpValidatorsConditionalHeader(t *testing.T) {
+	var disabled *collectorHttpValidators
+	header := http.Header{"Accept": []string{"application/json"}}
+	assert.Equal(t, header, disabled.conditionalHeader("hash", header))
+
+	v := &collectorHttpValidators{validators: map[string]*models.CollectorHttpValidator{
+		"hash": {ETag: `W/"abc"`, LastModified: "Mon, 12 Feb 2024 00:00:00 GMT"},
+	}}
+	assert.Equal(t, header, v.conditionalHeader("other", header))
+
+	conditional := v.conditionalHeader("hash", header)
+	assert.Equal(t, `W/"abc"`, conditional.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 12 Feb 2024 00:00:00 GMT", conditional.Get("If-Modified-Since"))
+	assert.Equal(t, "application/json", conditional.Get("Accept"))
+	// the header passed in should be left untouched
+	assert.Empty(t, header.Get("If-None-Match"))
+}
+
+func TestCollectorHttpValidatorsFromResponse(t *testing.T) {
+	v := &collectorHttpValidators{
+		table:  "_raw_test",
+		params: `{"Name":"test"}`,
+		validators: map[string]*models.CollectorHttpValidator{
+			"hash": {ETag: `"abc"`, LastModified: "Mon, 12 Feb 2024 00:00:00 GMT"},
+		},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/items?page=1", nil)
+
+	notModified := v.fromResponse("hash", &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Request: req})
+	assert.Equal(t, `"abc"`, notModified.ETag)
+	assert.Equal(t, "Mon, 12 Feb 2024 00:00:00 GMT", notModified.LastModified)
+	assert.Equal(t, "https://example.com/items?page=1", notModified.Url)
+	assert.Equal(t, "_raw_test", notModified.RawDataTable)
+
+	ok := v.fromResponse("hash", &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"def"`}}, Request: req})
+	assert.Equal(t, `"def"`, ok.ETag)
+	assert.Empty(t, ok.LastModified)
+}
+
+func TestHttpRequestHash(t *testing.T) {
+	hash := httpRequestHash("repos/a/b/pulls/1/commits", url.Values{"page": []string{"1"}, "per_page": []string{"100"}})
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, httpRequestHash("repos/a/b/pulls/1/commits", url.Values{"per_page": []string{"100"}, "page": []string{"1"}}))
+	assert.NotEqual(t, hash, httpRequestHash("repos/a/b/pulls/1/commits", url.Values{"page": []string{"2"}, "per_page": []string{"100"}}))
+}
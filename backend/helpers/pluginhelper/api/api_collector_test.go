@@ -23,8 +23,10 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/unithelper"
 	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
@@ -45,8 +47,17 @@ func (t TestOpts) GetParams() any {
 func TestFetchPageUndetermined(t *testing.T) {
 	mockDal := new(mockdal.Dal)
 	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
-	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
-	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	// expired checkpoints and the raw data of the previous collection are deleted
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	mockDal.On("All", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	// each page is saved along with its checkpoint
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything).Return(nil).Twice()
+	mockTx.On("Commit").Return(nil).Twice()
+	mockDal.On("Begin").Return(mockTx).Twice()
+	// checkpoints are cleared once the collection finished
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
 
 	mockCtx := unithelper.DummySubTaskContext(mockDal)
 
@@ -106,4 +117,101 @@ func TestFetchPageUndetermined(t *testing.T) {
 	assert.Nil(t, collector.Execute())
 
 	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestFetchPageUndeterminedResume(t *testing.T) {
+	// the previous attempt failed after collecting the first page of the input
+	inputHash := checkpointInputHash(true, []byte("null"))
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	// only the expired checkpoints are deleted, the raw data of the first page must be kept
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		checkpoints := args.Get(0).(*[]*models.CollectorCheckpoint)
+		*checkpoints = []*models.CollectorCheckpoint{
+			{InputHash: inputHash, Page: 1, Count: 3, CreatedAt: time.Now()},
+		}
+	}).Return(nil).Once()
+	// only the rows of the second page are inserted
+	mockTx := new(mockdal.Transaction)
+	mockTx.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		rows := args.Get(0).([]*RawData)
+		assert.Len(t, rows, 2)
+	}).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		checkpoint := args.Get(0).(*models.CollectorCheckpoint)
+		assert.Equal(t, 2, checkpoint.Page)
+		assert.True(t, checkpoint.Finished)
+		assert.Equal(t, checkpointQueryHash("page/{{ .Pager.Page }}", false, nil), checkpoint.QueryHash)
+	}).Return(nil).Once()
+	mockTx.On("Commit").Return(nil).Once()
+	mockDal.On("Begin").Return(mockTx).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+	mockCtx := unithelper.DummySubTaskContext(mockDal)
+
+	mockInput := new(mockapi.Iterator)
+	mockInput.On("HasNext").Return(true).Once()
+	mockInput.On("HasNext").Return(false).Twice()
+	mockInput.On("Fetch").Return(nil, nil).Once()
+	mockInput.On("Close").Return(nil)
+
+	// the collection should continue with the second page
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", "page/2", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		res := &http.Response{
+			Request: &http.Request{
+				URL: &url.URL{},
+			},
+			Body: io.NopCloser(bytes.NewBufferString("[4,5]")),
+		}
+		handler := args.Get(3).(plugin.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	}).Once()
+	mockApi.On("NextTick", mock.Anything).Run(func(args mock.Arguments) {
+		handler := args.Get(0).(func() errors.Error)
+		assert.Nil(t, handler())
+	}).Once()
+	mockApi.On("HasError").Return(false)
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("GetAfterFunction", mock.Anything).Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+	mockApi.On("SetSyncPolicy", mock.Anything).Return()
+	mockApi.On("Release").Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:     mockCtx,
+			Table:   "whatever rawtable",
+			Options: &TestOpts{},
+		},
+		ApiClient:      mockApi,
+		Input:          mockInput,
+		UrlTemplate:    "page/{{ .Pager.Page }}",
+		Concurrency:    1,
+		PageSize:       3,
+		ResponseParser: GetRawMessageArrayFromResponse,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+
+	mockDal.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockApi.AssertExpectations(t)
+}
+
+func TestLoadCollectorCheckpointsFullSync(t *testing.T) {
+	// a full sync deletes all the checkpoints of the scope and never resumes
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+	checkpoints, err := loadCollectorCheckpoints(mockDal, "whatever rawtable", "{}", "hash", true)
+	assert.Nil(t, err)
+	assert.False(t, checkpoints.resuming())
+	assert.Equal(t, "hash", checkpoints.queryHash)
+
+	mockDal.AssertExpectations(t)
+	mockDal.AssertNotCalled(t, "All", mock.Anything, mock.Anything, mock.Anything)
 }
@@ -151,6 +151,7 @@ func NewStatefulApiCollector(args RawDataSubTaskArgs) (*ApiCollectorStateManager
 func (m *ApiCollectorStateManager) InitCollector(args ApiCollectorArgs) errors.Error {
 	args.RawDataSubTaskArgs = m.RawDataSubTaskArgs
 	args.Incremental = args.Incremental || m.IsIncremental
+	args.since = m.Since
 	apiCollector, err := NewApiCollector(args)
 	if err != nil {
 		return err
@@ -31,6 +31,9 @@ type ApiRateLimitCalculator struct {
 	Method                 string
 	ApiPath                string
 	DynamicRateLimit       func(res *http.Response) (int, time.Duration, errors.Error)
+	// RateLimitHeaderParser extracts rate limit status from every response to adapt the pace of requests,
+	// ParseStandardRateLimitHeaders is used if not specified
+	RateLimitHeaderParser RateLimitHeaderParser
 }
 
 // Calculate FIXME ...
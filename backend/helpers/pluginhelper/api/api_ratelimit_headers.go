@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitStatus is the rate limit information carried by a response
+type RateLimitStatus struct {
+	// Limit is the quota of current window, -1 if unknown
+	Limit int
+	// Remaining is the number of requests left in current window, -1 if unknown
+	Remaining int
+	// ResetAt is when the quota would be replenished, nil if unknown
+	ResetAt *time.Time
+	// RetryAfter asks the client to stop sending requests for the duration, 0 if not required
+	RetryAfter time.Duration
+}
+
+// RateLimitHeaderParser extracts the rate limit status from the response, returns nil if no information found
+type RateLimitHeaderParser func(res *http.Response) *RateLimitStatus
+
+// timestamps larger than this are treated as unix epoch, or they would be treated as delta seconds
+const minEpochSeconds = 1000000000
+
+// ParseStandardRateLimitHeaders recognizes the commonly used `X-RateLimit-Limit/Remaining/Reset` headers (GitHub
+// and many others), the `RateLimit-Limit/Remaining/Reset` headers (GitLab and the IETF draft) and `Retry-After`
+func ParseStandardRateLimitHeaders(res *http.Response) *RateLimitStatus {
+	if res == nil {
+		return nil
+	}
+	status := &RateLimitStatus{Limit: -1, Remaining: -1}
+	found := false
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if status.Limit < 0 {
+			if limit, ok := parseHeaderInt(res.Header, prefix+"Limit"); ok {
+				status.Limit = limit
+				found = true
+			}
+		}
+		if status.Remaining < 0 {
+			if remaining, ok := parseHeaderInt(res.Header, prefix+"Remaining"); ok {
+				status.Remaining = remaining
+				found = true
+			}
+		}
+		if status.ResetAt == nil {
+			if reset, ok := parseHeaderInt(res.Header, prefix+"Reset"); ok {
+				resetAt := resetToTime(reset, responseTime(res))
+				status.ResetAt = &resetAt
+				found = true
+			}
+		}
+	}
+	if retryAfter := strings.TrimSpace(res.Header.Get("Retry-After")); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			status.RetryAfter = time.Duration(seconds) * time.Second
+			found = true
+		} else if t, err := http.ParseTime(retryAfter); err == nil {
+			status.RetryAfter = t.Sub(responseTime(res))
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return status
+}
+
+func parseHeaderInt(header http.Header, name string) (int, bool) {
+	value := strings.TrimSpace(header.Get(name))
+	if value == "" {
+		return 0, false
+	}
+	// some servers send float numbers, i.e. `1700000000.123`
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// resetToTime converts the reset header, which might be a unix epoch or seconds until reset, to time
+func resetToTime(reset int, now time.Time) time.Time {
+	if reset >= minEpochSeconds {
+		return time.Unix(int64(reset), 0)
+	}
+	return now.Add(time.Duration(reset) * time.Second)
+}
+
+// responseTime returns the time the response was generated, the local time is used if `Date` header is missing
+func responseTime(res *http.Response) time.Time {
+	if date, err := http.ParseTime(res.Header.Get("Date")); err == nil {
+		return date
+	}
+	return time.Now()
+}
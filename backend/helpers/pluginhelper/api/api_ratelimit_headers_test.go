@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStandardRateLimitHeaders(t *testing.T) {
+	date := time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+	newResponse := func(headers map[string]string) *http.Response {
+		res := &http.Response{Header: http.Header{}}
+		res.Header.Set("Date", date.Format(http.TimeFormat))
+		for k, v := range headers {
+			res.Header.Set(k, v)
+		}
+		return res
+	}
+
+	// github style, reset in unix epoch
+	status := ParseStandardRateLimitHeaders(newResponse(map[string]string{
+		"X-RateLimit-Limit":     "5000",
+		"X-RateLimit-Remaining": "4000",
+		"X-RateLimit-Reset":     "1706774400",
+	}))
+	assert.NotNil(t, status)
+	assert.Equal(t, 5000, status.Limit)
+	assert.Equal(t, 4000, status.Remaining)
+	assert.Equal(t, time.Unix(1706774400, 0).Unix(), status.ResetAt.Unix())
+	assert.Equal(t, time.Duration(0), status.RetryAfter)
+
+	// ietf draft style, reset in seconds
+	status = ParseStandardRateLimitHeaders(newResponse(map[string]string{
+		"RateLimit-Limit":     "600",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "30",
+	}))
+	assert.NotNil(t, status)
+	assert.Equal(t, 600, status.Limit)
+	assert.Equal(t, 0, status.Remaining)
+	assert.Equal(t, date.Add(30*time.Second), *status.ResetAt)
+
+	// retry-after in seconds and http date
+	status = ParseStandardRateLimitHeaders(newResponse(map[string]string{"Retry-After": "120"}))
+	assert.NotNil(t, status)
+	assert.Equal(t, -1, status.Limit)
+	assert.Equal(t, -1, status.Remaining)
+	assert.Nil(t, status.ResetAt)
+	assert.Equal(t, 2*time.Minute, status.RetryAfter)
+	status = ParseStandardRateLimitHeaders(newResponse(map[string]string{
+		"Retry-After": date.Add(time.Minute).Format(http.TimeFormat),
+	}))
+	assert.Equal(t, time.Minute, status.RetryAfter)
+
+	// nothing related
+	assert.Nil(t, ParseStandardRateLimitHeaders(newResponse(nil)))
+	assert.Nil(t, ParseStandardRateLimitHeaders(newResponse(map[string]string{"X-RateLimit-Remaining": "n/a"})))
+	assert.Nil(t, ParseStandardRateLimitHeaders(nil))
+}
@@ -0,0 +1,265 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	corecontext "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+)
+
+// ConnectionRateLimiter is a token bucket shared by all api clients of the same connection in the process,
+// so concurrent tasks of a connection would not exceed its quota altogether. Optionally, it could be
+// coordinated through the database when multiple devlake processes are collecting data at the same time
+type ConnectionRateLimiter struct {
+	mu          sync.Mutex
+	key         string
+	requests    int
+	duration    time.Duration
+	tokens      float64
+	capacity    float64
+	perToken    time.Duration
+	minPerToken time.Duration
+	lastRefill  time.Time
+	pausedUntil time.Time
+	coordinator *dbRateLimitCoordinator
+}
+
+var (
+	connectionRateLimiters     = make(map[string]*ConnectionRateLimiter)
+	connectionRateLimitersLock sync.Mutex
+)
+
+// GetConnectionRateLimiter returns the limiter shared by the connection identified by `key`, the budget of
+// an existing limiter would be updated to the latest `requests` per `duration`
+func GetConnectionRateLimiter(key string, requests int, duration time.Duration) (*ConnectionRateLimiter, errors.Error) {
+	if requests <= 0 || duration <= 0 {
+		return nil, errors.Default.New("requests and duration must be greater than 0")
+	}
+	connectionRateLimitersLock.Lock()
+	defer connectionRateLimitersLock.Unlock()
+	limiter, ok := connectionRateLimiters[key]
+	if !ok {
+		limiter = &ConnectionRateLimiter{key: key}
+		connectionRateLimiters[key] = limiter
+	}
+	limiter.SetRate(requests, duration)
+	return limiter, nil
+}
+
+// SetRate updates the budget of the limiter, it allows bursting up to 1 second worth of requests
+func (l *ConnectionRateLimiter) SetRate(requests int, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.requests = requests
+	l.duration = duration
+	l.minPerToken = duration / time.Duration(requests)
+	if l.minPerToken <= 0 {
+		l.minPerToken = time.Nanosecond
+	}
+	l.setPerToken(l.minPerToken)
+	if l.lastRefill.IsZero() {
+		l.tokens = l.capacity
+		l.lastRefill = time.Now()
+	}
+}
+
+func (l *ConnectionRateLimiter) setPerToken(perToken time.Duration) {
+	l.perToken = perToken
+	l.capacity = math.Max(1, float64(time.Second)/float64(l.perToken))
+	l.tokens = math.Min(l.tokens, l.capacity)
+}
+
+// Throttle spreads the `remaining` requests evenly until `resetAt`, the pace would never be faster
+// than the budget set by SetRate
+func (l *ConnectionRateLimiter) Throttle(remaining int, resetAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	perToken := l.minPerToken
+	if remaining > 0 {
+		if spread := time.Until(resetAt) / time.Duration(remaining); spread > perToken {
+			perToken = spread
+		}
+	}
+	l.setPerToken(perToken)
+}
+
+// PauseUntil stops all requests of the connection until the specified time
+func (l *ConnectionRateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// GetInterval returns current interval between requests
+func (l *ConnectionRateLimiter) GetInterval() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.perToken
+}
+
+// GetRate returns the budget of the limiter
+func (l *ConnectionRateLimiter) GetRate() (int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests, l.duration
+}
+
+func (l *ConnectionRateLimiter) refill(now time.Time) {
+	if l.lastRefill.IsZero() || l.perToken <= 0 {
+		return
+	}
+	elapsed := now.Sub(l.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+	l.tokens = math.Min(l.capacity, l.tokens+float64(elapsed)/float64(l.perToken))
+	l.lastRefill = now
+}
+
+// Wait blocks until a request is allowed to be sent or the context is done
+func (l *ConnectionRateLimiter) Wait(ctx context.Context) errors.Error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if now.Before(l.pausedUntil) {
+			wait := l.pausedUntil.Sub(now)
+			l.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return errors.Convert(ctx.Err())
+			case <-time.After(wait):
+			}
+			continue
+		}
+		l.refill(now)
+		if l.tokens >= 1 {
+			l.tokens--
+			coordinator := l.coordinator
+			requests, duration := l.requests, l.duration
+			l.mu.Unlock()
+			if coordinator != nil {
+				return coordinator.acquire(ctx, requests, duration)
+			}
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) * float64(l.perToken))
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// EnableDbCoordination makes the limiter draw requests from the quota recorded in the database as well,
+// which is shared by all devlake processes using the same database
+func (l *ConnectionRateLimiter) EnableDbCoordination(basicRes corecontext.BasicRes) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.coordinator == nil {
+		l.coordinator = &dbRateLimitCoordinator{basicRes: basicRes, key: l.key}
+	}
+}
+
+// dbRateLimitCoordinator leases requests from a fixed window counter stored in the database in small
+// batches, to avoid hitting the database for every single request
+type dbRateLimitCoordinator struct {
+	mu       sync.Mutex
+	basicRes corecontext.BasicRes
+	key      string
+	leased   int
+}
+
+func (c *dbRateLimitCoordinator) acquire(ctx context.Context, requests int, window time.Duration) errors.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.leased <= 0 {
+		granted, resetAt, err := c.lease(requests, window, leaseSize(requests, window))
+		if err != nil {
+			return err
+		}
+		if granted > 0 {
+			c.leased = granted
+			break
+		}
+		// quota of current window was exhausted by all processes, wait for the next window
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		case <-time.After(time.Until(resetAt)):
+		}
+	}
+	c.leased--
+	return nil
+}
+
+func (c *dbRateLimitCoordinator) lease(quota int, window time.Duration, size int) (granted int, resetAt time.Time, err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(c.basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	err = txHelper.LockTablesTimeout(10*time.Second, dal.LockTables{{Table: &models.ApiRateLimit{}, Exclusive: true}})
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	state := &models.ApiRateLimit{}
+	err = tx.First(state, dal.Where("limiter_key = ?", c.key))
+	if err != nil {
+		if !tx.IsErrorNotFound(err) {
+			return
+		}
+		err = nil
+		state = &models.ApiRateLimit{LimiterKey: c.key, WindowStart: now}
+	}
+	if now.Sub(state.WindowStart) >= window {
+		state.WindowStart = now
+		state.Used = 0
+	}
+	granted = quota - state.Used
+	if granted > size {
+		granted = size
+	}
+	if granted < 0 {
+		granted = 0
+	}
+	state.Used += granted
+	err = tx.CreateOrUpdate(state)
+	return granted, state.WindowStart.Add(window), err
+}
+
+// leaseSize returns number of requests to lease at once, roughly 10 seconds worth of requests
+func leaseSize(requests int, window time.Duration) int {
+	size := int(float64(requests) * float64(10*time.Second) / float64(window))
+	if size < 1 {
+		return 1
+	}
+	return size
+}
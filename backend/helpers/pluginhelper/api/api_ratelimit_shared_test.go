@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionRateLimiterShared(t *testing.T) {
+	l1, err := GetConnectionRateLimiter("TestConnectionRateLimiterShared:1", 3600, time.Hour)
+	assert.Nil(t, err)
+	l2, err := GetConnectionRateLimiter("TestConnectionRateLimiterShared:1", 7200, time.Hour)
+	assert.Nil(t, err)
+	l3, err := GetConnectionRateLimiter("TestConnectionRateLimiterShared:2", 3600, time.Hour)
+	assert.Nil(t, err)
+	assert.Same(t, l1, l2)
+	assert.NotSame(t, l1, l3)
+	// the budget follows the latest calculation
+	requests, duration := l1.GetRate()
+	assert.Equal(t, 7200, requests)
+	assert.Equal(t, time.Hour, duration)
+
+	_, err = GetConnectionRateLimiter("TestConnectionRateLimiterShared:3", 0, time.Hour)
+	assert.NotNil(t, err)
+}
+
+func TestConnectionRateLimiterWait(t *testing.T) {
+	// 20 requests per second, burst of 20
+	limiter, err := GetConnectionRateLimiter("TestConnectionRateLimiterWait:1", 20, time.Second)
+	assert.Nil(t, err)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		assert.Nil(t, limiter.Wait(ctx))
+	}
+	// the first 20 requests are served by the burst, the other 10 requests take 0.5 seconds
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 400*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+
+	// waiting should be interrupted by the context
+	limiter, err = GetConnectionRateLimiter("TestConnectionRateLimiterWait:2", 1, time.Hour)
+	assert.Nil(t, err)
+	assert.Nil(t, limiter.Wait(ctx))
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, limiter.Wait(ctx))
+}
+
+func TestLeaseSize(t *testing.T) {
+	assert.Equal(t, 50, leaseSize(18000, time.Hour))
+	assert.Equal(t, 1, leaseSize(100, time.Hour))
+}
+
+func TestConnectionRateLimiterThrottle(t *testing.T) {
+	limiter, err := GetConnectionRateLimiter("TestConnectionRateLimiterThrottle:1", 3600, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, limiter.GetInterval())
+	// 10 requests left for the next 100 seconds
+	limiter.Throttle(10, time.Now().Add(100*time.Second))
+	assert.InDelta(t, float64(10*time.Second), float64(limiter.GetInterval()), float64(time.Second))
+	// never faster than the budget
+	limiter.Throttle(1000, time.Now().Add(100*time.Second))
+	assert.Equal(t, time.Second, limiter.GetInterval())
+}
+
+func TestConnectionRateLimiterPauseUntil(t *testing.T) {
+	limiter, err := GetConnectionRateLimiter("TestConnectionRateLimiterPauseUntil:1", 100, time.Second)
+	assert.Nil(t, err)
+	limiter.PauseUntil(time.Now().Add(200 * time.Millisecond))
+	start := time.Now()
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
@@ -45,6 +45,7 @@ type GraphqlAsyncClient struct {
 	rateRemaining    int
 	getRateRemaining func(context.Context, *graphql.Client, log.Logger) (rateRemaining int, resetAt *time.Time, err errors.Error)
 	getRateCost      func(q interface{}) int
+	rateLimiter      *ConnectionRateLimiter
 }
 
 // CreateAsyncGraphqlClient creates a new GraphqlAsyncClient
@@ -139,6 +140,11 @@ func (apiClient *GraphqlAsyncClient) SetGetRateCost(getRateCost func(q interface
 	apiClient.getRateCost = getRateCost
 }
 
+// SetConnectionRateLimiter makes the client draw from the rate limiter shared by all api clients of the same connection
+func (apiClient *GraphqlAsyncClient) SetConnectionRateLimiter(rateLimiter *ConnectionRateLimiter) {
+	apiClient.rateLimiter = rateLimiter
+}
+
 // Query send a graphql request when get lock
 // []graphql.DataError are the errors returned in response body
 // errors.Error is other error
@@ -163,6 +169,11 @@ func (apiClient *GraphqlAsyncClient) Query(q interface{}, variables map[string]i
 		case <-apiClient.ctx.Done():
 			return nil, nil
 		default:
+			if apiClient.rateLimiter != nil {
+				if err := apiClient.rateLimiter.Wait(apiClient.ctx); err != nil {
+					return nil, err
+				}
+			}
 			var dataErrors []graphql.DataError
 			dataErrors, err := apiClient.client.Query(apiClient.ctx, q, variables)
 			if err == context.Canceled {
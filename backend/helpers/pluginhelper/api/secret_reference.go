@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// secret fields of connections could reference secrets stored outside the database in the form of
+// `${env:NAME}` for environment variables or `${file:/path/to/secret}` for mounted files
+var secretReferencePattern = regexp.MustCompile(`^\$\{(env|file):([^}]+)\}$`)
+
+const (
+	// only environment variables with the prefix could be referenced, to prevent other settings like
+	// ENCRYPTION_SECRET from being leaked to an arbitrary endpoint
+	secretReferenceEnvPrefixKey     = "SECRET_REFERENCE_ENV_PREFIX"
+	defaultSecretReferenceEnvPrefix = "DEVLAKE_SECRET_"
+	// only files inside the directory could be referenced, file references are disabled if not set
+	secretReferenceDirKey = "SECRET_REFERENCE_DIR"
+)
+
+// IsSecretReference returns true if the value references a secret stored outside the database
+func IsSecretReference(value string) bool {
+	return secretReferencePattern.MatchString(strings.TrimSpace(value))
+}
+
+// ResolveSecretReference returns the secret referenced by the value, or the value itself if it is not a reference
+func ResolveSecretReference(cfg config.ConfigReader, value string) (string, errors.Error) {
+	matches := secretReferencePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return value, nil
+	}
+	kind, name := matches[1], strings.TrimSpace(matches[2])
+	switch kind {
+	case "env":
+		prefix := defaultSecretReferenceEnvPrefix
+		if cfg.IsSet(secretReferenceEnvPrefixKey) {
+			prefix = cfg.GetString(secretReferenceEnvPrefixKey)
+		}
+		if !strings.HasPrefix(name, prefix) {
+			return "", errors.BadInput.New(fmt.Sprintf("environment variable %s is not allowed to be referenced, the name must start with %s", name, prefix))
+		}
+		secret := cfg.GetString(name)
+		if secret == "" {
+			return "", errors.BadInput.New(fmt.Sprintf("environment variable %s referenced by the connection is not set", name))
+		}
+		return secret, nil
+	default:
+		dir := cfg.GetString(secretReferenceDirKey)
+		if dir == "" {
+			return "", errors.BadInput.New(fmt.Sprintf("file references are disabled, please set %s to the directory of the secret files", secretReferenceDirKey))
+		}
+		path, err := filepath.Abs(filepath.Join(dir, name))
+		if err != nil {
+			return "", errors.Convert(err)
+		}
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return "", errors.Convert(err)
+		}
+		if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return "", errors.BadInput.New(fmt.Sprintf("secret file %s is outside of %s", name, dir))
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.BadInput.Wrap(err, fmt.Sprintf("failed to read secret file %s", name))
+		}
+		// files created by editors or `echo` usually end with a line break
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+}
+
+// ResolveSecretReferences returns a copy of the connection with the secret references in the encrypted fields
+// replaced by the secrets, the connection itself is left untouched, so the references are never persisted with the
+// resolved secrets even if the connection is shared or saved later
+func ResolveSecretReferences(cfg config.ConfigReader, connection interface{}) (interface{}, errors.Error) {
+	v := reflect.ValueOf(connection)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return connection, nil
+	}
+	resolved := reflect.New(v.Elem().Type())
+	resolved.Elem().Set(v.Elem())
+	if err := resolveSecretReferences(cfg, resolved.Elem()); err != nil {
+		return nil, err
+	}
+	return resolved.Interface(), nil
+}
+
+// resolveSecretReferences resolves the secrets of the copied struct in place, the structs embedded by pointers are
+// copied as well since they are still shared with the original connection
+func resolveSecretReferences(cfg config.ConfigReader, v reflect.Value) errors.Error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fieldValue := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch {
+		case field.Anonymous && fieldValue.Kind() == reflect.Struct:
+			if err := resolveSecretReferences(cfg, fieldValue); err != nil {
+				return err
+			}
+		case field.Anonymous && fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Struct:
+			embedded := reflect.New(fieldValue.Elem().Type())
+			embedded.Elem().Set(fieldValue.Elem())
+			if err := resolveSecretReferences(cfg, embedded.Elem()); err != nil {
+				return err
+			}
+			fieldValue.Set(embedded)
+		case fieldValue.Kind() == reflect.String && strings.Contains(field.Tag.Get("gorm"), "serializer:encdec"):
+			secret, err := ResolveSecretReference(cfg, fieldValue.String())
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to resolve the secret reference of %s", field.Name))
+			}
+			fieldValue.SetString(secret)
+		}
+	}
+	return nil
+}
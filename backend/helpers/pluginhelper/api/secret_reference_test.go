@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSecretReferences(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "jira_token"), []byte("file-secret\n"), 0600))
+	cfg := viper.New()
+	cfg.Set("DEVLAKE_SECRET_JIRA_PASSWORD", "env-secret")
+	cfg.Set("ENCRYPTION_SECRET", "top-secret")
+
+	type connection struct {
+		BasicAuth
+		AccessToken
+		Endpoint string
+	}
+	conn := &connection{
+		BasicAuth:   BasicAuth{Username: "admin", Password: "${env:DEVLAKE_SECRET_JIRA_PASSWORD}"},
+		AccessToken: AccessToken{Token: "${file:jira_token}"},
+		Endpoint:    "${env:DEVLAKE_SECRET_JIRA_PASSWORD}",
+	}
+	// file references are disabled by default
+	_, err := ResolveSecretReferences(cfg, conn)
+	assert.NotNil(t, err)
+
+	cfg.Set("SECRET_REFERENCE_DIR", dir)
+	resolved, err := ResolveSecretReferences(cfg, conn)
+	assert.Nil(t, err)
+	assert.Equal(t, "env-secret", resolved.(*connection).Password)
+	assert.Equal(t, "file-secret", resolved.(*connection).Token)
+	// only encrypted fields are resolved
+	assert.Equal(t, "${env:DEVLAKE_SECRET_JIRA_PASSWORD}", resolved.(*connection).Endpoint)
+	// the connection itself keeps the references
+	assert.Equal(t, "${env:DEVLAKE_SECRET_JIRA_PASSWORD}", conn.Password)
+	assert.Equal(t, "${file:jira_token}", conn.Token)
+
+	// plain secrets are kept as they are
+	secret, err := ResolveSecretReference(cfg, "plain-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "plain-token", secret)
+
+	// not allowed
+	_, err = ResolveSecretReference(cfg, "${env:ENCRYPTION_SECRET}")
+	assert.NotNil(t, err)
+	_, err = ResolveSecretReference(cfg, "${file:../etc/passwd}")
+	assert.NotNil(t, err)
+	_, err = ResolveSecretReference(cfg, "${env:DEVLAKE_SECRET_MISSING}")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 some source systems still sign their payloads with sha1
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// VerifyHmacSignature verifies the `signature` of the `payload` signed by `secret`, the signature should be
+// in the form of `<algorithm>=<hex digest>` like `sha256=abc...`, which is adopted by GitHub, Bitbucket and Jira
+func VerifyHmacSignature(secret string, payload []byte, signature string) errors.Error {
+	if secret == "" {
+		return errors.Unauthorized.New("secret is not configured")
+	}
+	if signature == "" {
+		return errors.Unauthorized.New("signature is missing")
+	}
+	algorithm, digest, found := strings.Cut(signature, "=")
+	if !found {
+		return errors.Unauthorized.New("signature is malformed")
+	}
+	var newHash func() hash.Hash
+	switch strings.ToLower(algorithm) {
+	case "sha256":
+		newHash = sha256.New
+	case "sha1":
+		newHash = sha1.New
+	default:
+		return errors.Unauthorized.New("unsupported signature algorithm " + algorithm)
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return errors.Unauthorized.New("signature is malformed")
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.Unauthorized.New("signature mismatched")
+	}
+	return nil
+}
+
+// VerifySecretToken compares the `token` sent along with the payload against the `secret` in constant time,
+// which is adopted by GitLab
+func VerifySecretToken(secret string, token string) errors.Error {
+	if secret == "" {
+		return errors.Unauthorized.New("secret is not configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return errors.Unauthorized.New("token mismatched")
+	}
+	return nil
+}
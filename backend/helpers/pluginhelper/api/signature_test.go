@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHmacSignature(t *testing.T) {
+	payload := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte("my-secret"))
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Nil(t, VerifyHmacSignature("my-secret", payload, signature))
+	assert.NotNil(t, VerifyHmacSignature("other-secret", payload, signature))
+	assert.NotNil(t, VerifyHmacSignature("my-secret", []byte(`{"action":"closed"}`), signature))
+	assert.NotNil(t, VerifyHmacSignature("my-secret", payload, ""))
+	assert.NotNil(t, VerifyHmacSignature("my-secret", payload, "md5=abc"))
+	assert.NotNil(t, VerifyHmacSignature("my-secret", payload, "sha256=not-hex"))
+	assert.NotNil(t, VerifyHmacSignature("", payload, signature))
+}
+
+func TestVerifySecretToken(t *testing.T) {
+	assert.Nil(t, VerifySecretToken("my-secret", "my-secret"))
+	assert.NotNil(t, VerifySecretToken("my-secret", "my-secre"))
+	assert.NotNil(t, VerifySecretToken("", ""))
+}
@@ -123,14 +123,19 @@ func makeDataSourcePipelinePlanV200(
 				return nil, err
 			}
 			cloneUrl.User = url.UserPassword(connection.Username, connection.Password)
+			gitextractorOp := map[string]interface{}{
+				"url":    cloneUrl.String(),
+				"name":   repo.BitbucketId,
+				"repoId": didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(connection.ID, repo.BitbucketId),
+				"proxy":  connection.Proxy,
+			}
+			if scopeConfig.CoverageReportPath != "" {
+				gitextractorOp["coverageReportPath"] = scopeConfig.CoverageReportPath
+				gitextractorOp["coverageReportFormat"] = scopeConfig.CoverageReportFormat
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":    cloneUrl.String(),
-					"name":   repo.BitbucketId,
-					"repoId": didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(connection.ID, repo.BitbucketId),
-					"proxy":  connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOp,
 			})
 
 		}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// ReceiveSourceEvent verifies the `X-Hub-Signature` of the Bitbucket webhook and maps it to the affected repository
+func ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	err := api.VerifyHmacSignature(secret, body, header.Get("X-Hub-Signature"))
+	if err != nil {
+		return nil, err
+	}
+	// event keys look like `repo:push`, `pullrequest:fulfilled` or `issue:created`
+	eventKey := header.Get("X-Event-Key")
+	if eventKey != "repo:push" &&
+		!strings.HasPrefix(eventKey, "repo:commit_status_") &&
+		!strings.HasPrefix(eventKey, "pullrequest:") &&
+		!strings.HasPrefix(eventKey, "issue:") {
+		return nil, nil
+	}
+	payload := &struct {
+		Repository *struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode bitbucket event")
+	}
+	if payload.Repository == nil || payload.Repository.FullName == "" {
+		return nil, nil
+	}
+	return []string{payload.Repository.FullName}, nil
+}
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -42,6 +43,7 @@ var _ interface {
 	plugin.PluginMigration
 	plugin.CloseablePluginTask
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourceEventReceiver
 	plugin.PluginSource
 } = (*Bitbucket)(nil)
 
@@ -241,6 +243,10 @@ func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 	}
 }
 
+func (p Bitbucket) ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	return api.ReceiveSourceEvent(connectionId, secret, header, body)
+}
+
 func (p Bitbucket) Close(taskCtx plugin.TaskContext) errors.Error {
 	data, ok := taskCtx.GetData().(*tasks.BitbucketTaskData)
 	if !ok {
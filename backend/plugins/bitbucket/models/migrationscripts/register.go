@@ -40,5 +40,6 @@ func All() []plugin.MigrationScript {
 		new(addRawParamTableForScope),
 		new(addBuildNumberToPipelines),
 		new(reCreatBitBucketPipelineSteps),
+		new(addCoverageReportPath),
 	}
 }
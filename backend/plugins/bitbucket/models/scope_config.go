@@ -23,10 +23,12 @@ import (
 )
 
 type BitbucketScopeConfig struct {
-	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
-	DeploymentPattern  string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
-	ProductionPattern  string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
-	Refdiff            datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	common.ScopeConfig   `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	DeploymentPattern    string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
+	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
+	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	CoverageReportPath   string            `mapstructure:"coverageReportPath,omitempty" json:"coverageReportPath" gorm:"type:varchar(255)"`
+	CoverageReportFormat string            `mapstructure:"coverageReportFormat,omitempty" json:"coverageReportFormat" gorm:"type:varchar(255)"`
 
 	// a string array, split by `,`.
 	IssueStatusTodo       string `mapstructure:"issueStatusTodo,omitempty" json:"issueStatusTodo" gorm:"type:varchar(255)"`
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/circleci/impl"
+	"github.com/apache/incubator-devlake/plugins/circleci/models"
+	"github.com/apache/incubator-devlake/plugins/circleci/tasks"
+)
+
+func TestCircleciTest(t *testing.T) {
+	var circleci impl.Circleci
+
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "circleci", circleci)
+	taskData := &tasks.CircleciTaskData{
+		Options: &tasks.CircleciOptions{
+			ConnectionId: 1,
+			ProjectSlug:  "github/coldgust/coldgust.github.io",
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+		Project: &models.CircleciProject{
+			Id: "abcd",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_circleci_api_tests.csv",
+		"_raw_circleci_api_tests")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_circleci_jobs.csv", &models.CircleciJob{})
+	dataflowTester.FlushTabler(&models.CircleciTest{})
+
+	// verify extraction
+	dataflowTester.Subtask(tasks.ExtractTestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.CircleciTest{},
+		e2ehelper.TableOptions{
+			CSVRelPath:   "./snapshot_tables/_tool_circleci_tests.csv",
+			IgnoreTypes:  []interface{}{common.NoPKModel{}},
+			IgnoreFields: []string{},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdTestSuite{})
+	dataflowTester.FlushTabler(&devops.CicdTestCase{})
+	dataflowTester.FlushTabler(&devops.CicdTestCaseResult{})
+	dataflowTester.Subtask(tasks.ConvertTestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestSuite{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_suites.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestCase{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_cases.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestCaseResult{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_case_results.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+}
@@ -64,6 +64,7 @@ func (p Circleci) GetTablesInfo() []dal.Tabler {
 		&models.CircleciPipeline{},
 		&models.CircleciWorkflow{},
 		&models.CircleciJob{},
+		&models.CircleciTest{},
 		&models.CircleciScopeConfig{},
 	}
 }
@@ -81,7 +82,10 @@ func (p Circleci) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractWorkflowsMeta,
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
+		tasks.CollectTestsMeta,
+		tasks.ExtractTestsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.ConvertTestsMeta,
 		tasks.ConvertWorkflowsMeta,
 	}
 }
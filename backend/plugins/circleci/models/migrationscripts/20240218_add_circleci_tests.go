@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type circleciTest20240218 struct {
+	ConnectionId uint64 `gorm:"primaryKey;type:BIGINT"`
+	JobId        string `gorm:"primaryKey;type:varchar(100)"`
+	Id           string `gorm:"primaryKey;type:varchar(32)"`
+	ProjectSlug  string `gorm:"type:varchar(255)"`
+	WorkflowId   string `gorm:"type:varchar(100)"`
+	Name         string
+	Classname    string `gorm:"type:varchar(500)"`
+	File         string `gorm:"type:varchar(500)"`
+	Result       string `gorm:"type:varchar(100)"`
+	Message      string
+	Source       string `gorm:"type:varchar(255)"`
+	RunTime      float64
+
+	archived.NoPKModel
+}
+
+func (circleciTest20240218) TableName() string {
+	return "_tool_circleci_tests"
+}
+
+type addCircleciTests20240218 struct{}
+
+func (*addCircleciTests20240218) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &circleciTest20240218{})
+}
+
+func (*addCircleciTests20240218) Version() uint64 {
+	return 20240218000001
+}
+
+func (*addCircleciTests20240218) Name() string {
+	return "add _tool_circleci_tests"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// CircleciTest is the test result of a job, circleci doesn't identify the tests, so the Id is the md5 of the
+// classname, name and file
+type CircleciTest struct {
+	ConnectionId uint64  `gorm:"primaryKey;type:BIGINT"`
+	JobId        string  `gorm:"primaryKey;type:varchar(100)"`
+	Id           string  `gorm:"primaryKey;type:varchar(32)"`
+	ProjectSlug  string  `gorm:"type:varchar(255)"`
+	WorkflowId   string  `gorm:"type:varchar(100)"`
+	Name         string  `json:"name"`
+	Classname    string  `gorm:"type:varchar(500)" json:"classname"`
+	File         string  `gorm:"type:varchar(500)" json:"file"`
+	Result       string  `gorm:"type:varchar(100)" json:"result"`
+	Message      string  `json:"message"`
+	Source       string  `gorm:"type:varchar(255)" json:"source"`
+	RunTime      float64 `json:"run_time"`
+
+	common.NoPKModel `swaggerignore:"true" json:"-" mapstructure:"-"`
+}
+
+func (CircleciTest) TableName() string {
+	return "_tool_circleci_tests"
+}
@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/circleci/models"
+)
+
+var ConvertTestsMeta = plugin.SubTaskMeta{
+	Name:             "convertTests",
+	EntryPoint:       ConvertTests,
+	EnabledByDefault: true,
+	Description:      "convert circleci tests into test suites, test cases and test case results",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type circleciTestWithJob struct {
+	models.CircleciTest
+	JobName      string
+	JobStartedAt *common.Iso8601Time
+}
+
+func ConvertTests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_TABLE)
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.Select("t.*, j.name AS job_name, j.started_at AS job_started_at"),
+		dal.From("_tool_circleci_tests t"),
+		dal.Join(`LEFT JOIN _tool_circleci_jobs j ON (
+			j.connection_id = t.connection_id AND j.workflow_id = t.workflow_id AND j.id = t.job_id
+		)`),
+		dal.Where("t.connection_id = ? AND t.project_slug = ?", data.Options.ConnectionId, data.Options.ProjectSlug),
+		dal.Orderby("t.workflow_id, t.job_id"),
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	cicdScopeId := getProjectIdGen().Generate(data.Options.ConnectionId, data.Project.Id)
+	// circleci has no test suite, the tests run by a job are treated as a suite, rows are sorted by job, so the suite
+	// is accumulated and saved along with each of its tests, the batch keeps the latest one
+	var suite *devops.CicdTestSuite
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(circleciTestWithJob{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			test := inputRow.(*circleciTestWithJob)
+			taskId := getJobIdGen().Generate(data.Options.ConnectionId, test.WorkflowId, test.JobId)
+			if suite == nil || suite.Id != taskId {
+				suite = &devops.CicdTestSuite{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: taskId,
+					},
+					CicdScopeId: cicdScopeId,
+					PipelineId:  getPipelineIdGen().Generate(data.Options.ConnectionId, test.WorkflowId),
+					TaskId:      taskId,
+					Name:        test.JobName,
+					StartedDate: test.JobStartedAt.ToNullableTime(),
+				}
+			}
+			// reference: https://circleci.com/docs/api/v2/index.html#operation/getTests
+			result := devops.TEST_PASSED
+			switch test.Result {
+			case "failure":
+				result = devops.TEST_FAILED
+				suite.Failures++
+			case "error":
+				result = devops.TEST_ERROR
+				suite.Errors++
+			case "skipped":
+				result = devops.TEST_SKIPPED
+				suite.Skipped++
+			}
+			suite.Tests++
+			suite.DurationSec += test.RunTime
+			testCase := &devops.CicdTestCase{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: devops.CicdTestCaseId(cicdScopeId, test.JobName, test.Classname, test.Name),
+				},
+				CicdScopeId: cicdScopeId,
+				SuiteName:   test.JobName,
+				ClassName:   test.Classname,
+				Name:        test.Name,
+				File:        test.File,
+			}
+			testCaseResult := &devops.CicdTestCaseResult{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: taskId + ":" + test.Id,
+				},
+				TestCaseId:     testCase.Id,
+				TestSuiteId:    suite.Id,
+				CicdScopeId:    cicdScopeId,
+				PipelineId:     suite.PipelineId,
+				TaskId:         taskId,
+				Result:         result,
+				OriginalResult: test.Result,
+				DurationSec:    test.RunTime,
+				Message:        test.Message,
+				ExecutedDate:   suite.StartedDate,
+			}
+			return []interface{}{
+				testCase,
+				testCaseResult,
+				suite,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
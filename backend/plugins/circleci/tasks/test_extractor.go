@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/circleci/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractTests
+
+var ExtractTestsMeta = plugin.SubTaskMeta{
+	Name:             "extractTests",
+	EntryPoint:       ExtractTests,
+	EnabledByDefault: true,
+	Description:      "Extract raw test data into tool layer table _tool_circleci_tests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractTests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &models.CircleciJob{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			toolL := models.CircleciTest{}
+			err = errors.Convert(json.Unmarshal(row.Data, &toolL))
+			if err != nil {
+				return nil, err
+			}
+			toolL.ConnectionId = data.Options.ConnectionId
+			toolL.ProjectSlug = data.Options.ProjectSlug
+			toolL.WorkflowId = input.WorkflowId
+			toolL.JobId = input.Id
+			toolL.Id = fmt.Sprintf("%x", md5.Sum([]byte(toolL.Classname+"\n"+toolL.Name+"\n"+toolL.File)))
+			return []interface{}{
+				&toolL,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -43,6 +43,7 @@ func TestConnectIncidentToDeploymentDataFlow(t *testing.T) {
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issue_changelogs.csv", &ticket.IssueChangelogs{})
 
 	// verify converter
 	dataflowTester.FlushTabler(&crossdomain.ProjectIssueMetric{})
@@ -35,7 +35,7 @@ var ConnectIncidentToDeploymentMeta = plugin.SubTaskMeta{
 	Name:             "ConnectIncidentToDeployment",
 	EntryPoint:       ConnectIncidentToDeployment,
 	EnabledByDefault: true,
-	Description:      "Connect incident issue to deployment",
+	Description:      "Connect incident issue to deployment and calculate the time to acknowledge/restore",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 }
 
@@ -47,6 +47,10 @@ type simpleCicdDeploymentCommit struct {
 func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*DoraTaskData)
+	statusChangelogs, err := loadIncidentStatusChangelogs(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
 	// select all issues belongs to the board
 	clauses := []dal.Clause{
 		dal.From(`issues i`),
@@ -81,6 +85,8 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 				},
 				ProjectName: data.Options.ProjectName,
 			}
+			projectIssueMetric.TimeToAcknowledgeMinutes, projectIssueMetric.TimeToRestoreMinutes = getIncidentResponseTime(issue, statusChangelogs[issue.Id])
+			hasResponseTime := projectIssueMetric.TimeToAcknowledgeMinutes != nil || projectIssueMetric.TimeToRestoreMinutes != nil
 
 			cicdDeploymentCommit := &devops.CicdDeploymentCommit{}
 			cicdDeploymentCommitClauses := []dal.Clause{
@@ -103,6 +109,9 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 			err = db.All(scdc, cicdDeploymentCommitClauses...)
 			if err != nil {
 				if db.IsErrorNotFound(err) {
+					if hasResponseTime {
+						return []interface{}{projectIssueMetric}, nil
+					}
 					return nil, nil
 				} else {
 					return nil, err
@@ -110,6 +119,8 @@ func ConnectIncidentToDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 			if scdc.Id != "" {
 				projectIssueMetric.DeploymentId = scdc.Id
+			}
+			if scdc.Id != "" || hasResponseTime {
 				return []interface{}{projectIssueMetric}, nil
 			}
 			return nil, nil
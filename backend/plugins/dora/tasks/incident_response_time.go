@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// loadIncidentStatusChangelogs returns the status changelogs of the incidents of the project in one query, grouped
+// by the issue id and in the order of their creation
+func loadIncidentStatusChangelogs(db dal.Dal, projectName string) (map[string][]ticket.IssueChangelogs, errors.Error) {
+	var changelogs []ticket.IssueChangelogs
+	err := db.All(
+		&changelogs,
+		dal.Where(
+			`field_name = ? AND issue_id IN (
+				SELECT i.id FROM issues i
+				LEFT JOIN board_issues bi ON bi.issue_id = i.id
+				LEFT JOIN project_mapping pm ON pm.row_id = bi.board_id
+				WHERE i.type = ? AND pm.project_name = ? AND pm.table = ?
+			)`,
+			"status", "INCIDENT", projectName, "boards",
+		),
+		dal.Orderby("issue_id ASC, created_date ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]ticket.IssueChangelogs)
+	for _, changelog := range changelogs {
+		grouped[changelog.IssueId] = append(grouped[changelog.IssueId], changelog)
+	}
+	return grouped, nil
+}
+
+// getIncidentResponseTime returns minutes from the creation of the incident to the first time it left TODO
+// (acknowledged) and to its final resolution, according to the status changelogs in the order of their creation.
+// The resolution is nil if the incident was reopened and never resolved again
+func getIncidentResponseTime(issue *ticket.Issue, changelogs []ticket.IssueChangelogs) (timeToAcknowledge *uint, timeToRestore *uint) {
+	if issue.CreatedDate == nil {
+		return nil, nil
+	}
+	var acknowledgedAt, restoredAt *time.Time
+	for i := range changelogs {
+		changelog := &changelogs[i]
+		if changelog.FromValue == changelog.ToValue {
+			continue
+		}
+		if acknowledgedAt == nil && changelog.ToValue != ticket.TODO {
+			acknowledgedAt = &changelog.CreatedDate
+		}
+		if changelog.ToValue == ticket.DONE {
+			restoredAt = &changelog.CreatedDate
+		} else {
+			restoredAt = nil
+		}
+	}
+	return minutesSince(issue.CreatedDate, acknowledgedAt), minutesSince(issue.CreatedDate, restoredAt)
+}
+
+func minutesSince(start *time.Time, end *time.Time) *uint {
+	if start == nil || end == nil || end.Before(*start) {
+		return nil
+	}
+	minutes := uint(end.Sub(*start).Minutes())
+	return &minutes
+}
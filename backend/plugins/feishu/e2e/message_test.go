@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/feishu/impl"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+	"github.com/apache/incubator-devlake/plugins/feishu/tasks"
+)
+
+func TestMessageDataFlow(t *testing.T) {
+	var plugin impl.Feishu
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "feishu", plugin)
+
+	taskData := &tasks.FeishuTaskData{
+		Options: &tasks.FeishuOptions{
+			ConnectionId: 1,
+			ProjectName:  "devlake",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_feishu_message.csv", "_raw_feishu_message")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.FeishuMessage{})
+	dataflowTester.Subtask(tasks.ExtractMessageMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.FeishuMessage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_feishu_messages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion, messages mention an issue, a deployment and a pull request, the deployment of another
+	// project with the same id on the CI/CD tool shouldn't be linked
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/board_issues.csv", &ticket.BoardIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/cicd_deployments.csv", &devops.CICDDeployment{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.FlushTabler(&communication.ChatMessage{})
+	dataflowTester.FlushTabler(&crossdomain.ChatMessageReference{})
+	dataflowTester.Subtask(tasks.ConvertMessageMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(communication.ChatMessage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/chat_messages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(crossdomain.ChatMessageReference{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/chat_message_references.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify threads and participants
+	dataflowTester.FlushTabler(&communication.ChatThread{})
+	dataflowTester.Subtask(tasks.ConvertThreadMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(communication.ChatThread{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/chat_threads.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&communication.ChatChannelParticipant{})
+	dataflowTester.Subtask(tasks.ConvertChatParticipantMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(communication.ChatChannelParticipant{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/chat_channel_participants.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
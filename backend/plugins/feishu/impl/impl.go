@@ -87,6 +87,11 @@ func (p Feishu) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectMessageMeta,
 		tasks.ExtractMessageMeta,
 
+		tasks.ConvertChatMeta,
+		tasks.ConvertMessageMeta,
+		tasks.ConvertThreadMeta,
+		tasks.ConvertChatParticipantMeta,
+
 		tasks.CollectMeetingTopUserItemMeta,
 		tasks.ExtractMeetingTopUserItemMeta,
 	}
@@ -59,4 +59,5 @@ var ExtractChatItemMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractChatItem,
 	EnabledByDefault: true,
 	Description:      "Extract raw chats data into tool layer table feishu_meeting_top_user_item",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
 }
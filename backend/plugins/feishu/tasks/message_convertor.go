@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/chathelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMessage
+
+func ConvertMessage(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*FeishuTaskData)
+	db := taskCtx.GetDal()
+
+	// feishu only tells the replies which thread they belong to, count them to find out the root messages
+	var rootMessages []struct {
+		RootId     string
+		ReplyCount int
+	}
+	err := db.All(
+		&rootMessages,
+		dal.Select("root_id, COUNT(*) AS reply_count"),
+		dal.From(&models.FeishuMessage{}),
+		dal.Where("connection_id = ? AND root_id != ''", data.Options.ConnectionId),
+		dal.Groupby("root_id"),
+	)
+	if err != nil {
+		return err
+	}
+	replyCounts := make(map[string]int, len(rootMessages))
+	for _, root := range rootMessages {
+		replyCounts[root.RootId] = root.ReplyCount
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.FeishuMessage{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	resolver := chathelper.NewReferenceResolver(db, data.Options.ProjectName)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: FeishuApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.FeishuMessage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.FeishuMessage)
+			chatMessage := &communication.ChatMessage{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: messageIdGen.Generate(message.ConnectionId, message.MessageId),
+				},
+				ChannelId:   chatIdGen.Generate(message.ConnectionId, message.ChatId),
+				AuthorId:    message.SenderId,
+				Content:     getMessageText(message),
+				Type:        message.MsgType,
+				ReplyCount:  replyCounts[message.MessageId],
+				IsDeleted:   message.Deleted,
+				CreatedDate: message.CreateTime,
+			}
+			if message.RootId != "" {
+				chatMessage.ThreadId = messageIdGen.Generate(message.ConnectionId, message.RootId)
+			} else if chatMessage.ReplyCount > 0 {
+				chatMessage.ThreadId = chatMessage.Id
+			}
+			if message.Updated {
+				updatedDate := message.UpdateTime
+				chatMessage.UpdatedDate = &updatedDate
+			}
+			results := []interface{}{chatMessage}
+			references, err := resolver.Resolve(chatMessage.Id, chatMessage.Content)
+			if err != nil {
+				return nil, err
+			}
+			for _, reference := range references {
+				results = append(results, reference)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertMessageMeta = plugin.SubTaskMeta{
+	Name:             "convertMessage",
+	EntryPoint:       ConvertMessage,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_feishu_messages into domain layer table chat_messages and link them to the issues, pull requests and deployments they mention",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION, plugin.DOMAIN_TYPE_CROSS},
+}
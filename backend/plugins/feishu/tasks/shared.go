@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+)
+
+var chatIdGen = didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+var messageIdGen = didgen.NewDomainIdGenerator(&models.FeishuMessage{})
+
+// getMessageText returns the text of a text message, content of other types of messages is returned as is
+func getMessageText(message *models.FeishuMessage) string {
+	if message.MsgType != "text" {
+		return message.Content
+	}
+	content := &struct {
+		Text string `json:"text"`
+	}{}
+	if json.Unmarshal([]byte(message.Content), content) != nil {
+		return message.Content
+	}
+	return content.Text
+}
@@ -27,6 +27,7 @@ type FeishuApiParams struct {
 
 type FeishuOptions struct {
 	ConnectionId            uint64  `json:"connectionId"`
+	ProjectName             string  `json:"projectName"`
 	NumOfDaysToCollect      float64 `json:"numOfDaysToCollect"`
 	helper.CollectorOptions `mapstructure:",squash"`
 }
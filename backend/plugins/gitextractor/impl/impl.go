@@ -53,6 +53,7 @@ func (p GitExtractor) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectGitBranchMeta,
 		tasks.CollectGitTagMeta,
 		tasks.CollectGitDiffLineMeta,
+		tasks.CollectCoverageReportMeta,
 	}
 }
 
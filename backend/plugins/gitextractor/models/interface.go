@@ -20,6 +20,7 @@ package models
 import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 )
 
 type Store interface {
@@ -31,5 +32,7 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	CqProjects(project *codequality.CqProject) errors.Error
+	CqFileCoverages(coverage *codequality.CqFileCoverage) errors.Error
 	Close() errors.Error
 }
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/coveragehelper"
+	git "github.com/libgit2/git2go/v33"
+)
+
+// CollectCoverageReport reads the coverage report committed at the path of the HEAD commit, the coverage of the files
+// would be saved to the cq_project of the repo
+func (r *GitRepo) CollectCoverageReport(subtaskCtx plugin.SubTaskContext, name, reportPath, format, repoUrl string) errors.Error {
+	head, err := r.repo.Head()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return errors.Convert(err)
+	}
+	commitSha := commit.Id().String()
+	tree, err := commit.Tree()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	entry, err := tree.EntryByPath(reportPath)
+	if err != nil {
+		r.logger.Warn(err, "coverage report %s not found in commit %s", reportPath, commitSha)
+		return nil
+	}
+	content, err := r.blobContents(entry)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	if format == "" {
+		format = coveragehelper.DetectFormat(content)
+	}
+	options := &coveragehelper.ParseOptions{}
+	if format == coveragehelper.FORMAT_GOCOVER {
+		options, err = r.goModule(tree, path.Dir(reportPath), repoUrl)
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+	files, parseErr := coveragehelper.Parse(format, content, options)
+	if parseErr != nil {
+		return errors.BadInput.Wrap(parseErr, fmt.Sprintf("failed to parse the coverage report %s", reportPath))
+	}
+
+	project := &codequality.CqProject{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: r.id,
+		},
+		Name:      name,
+		CommitSha: commitSha,
+	}
+	if err := r.store.CqProjects(project); err != nil {
+		return err
+	}
+	var reportedDate = commit.Committer().When
+	coverages := coveragehelper.ToCqFileCoverages(r.id, commitSha, format, &reportedDate, files)
+	subtaskCtx.SetProgress(0, len(coverages))
+	for _, coverage := range coverages {
+		if err := r.store.CqFileCoverages(coverage); err != nil {
+			return err
+		}
+		subtaskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+func (r *GitRepo) blobContents(entry *git.TreeEntry) ([]byte, error) {
+	blob, err := r.repo.LookupBlob(entry.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Free()
+	return blob.Contents(), nil
+}
+
+// goModule finds the go.mod nearest to the report, from its directory up to the root of the repository, the module
+// path is guessed by the url of the repo if there is none
+func (r *GitRepo) goModule(tree *git.Tree, dir, repoUrl string) (*coveragehelper.ParseOptions, error) {
+	for {
+		entry, err := tree.EntryByPath(path.Join(dir, "go.mod"))
+		if err == nil {
+			goMod, err := r.blobContents(entry)
+			if err != nil {
+				return nil, err
+			}
+			if dir == "." {
+				dir = ""
+			}
+			return &coveragehelper.ParseOptions{GoModulePath: coveragehelper.GoModulePath(goMod), GoModuleDir: dir}, nil
+		}
+		if dir == "." || dir == "/" || dir == "" {
+			break
+		}
+		dir = path.Dir(dir)
+	}
+	return &coveragehelper.ParseOptions{GoModulePath: coveragehelper.GoModulePathFromUrl(repoUrl)}, nil
+}
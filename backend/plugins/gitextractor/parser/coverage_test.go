@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	git "github.com/libgit2/git2go/v33"
+	"github.com/stretchr/testify/assert"
+)
+
+type coverageStore struct {
+	projects  []*codequality.CqProject
+	coverages []*codequality.CqFileCoverage
+}
+
+func (s *coverageStore) RepoCommits(*code.RepoCommit) errors.Error                   { return nil }
+func (s *coverageStore) Commits(*code.Commit) errors.Error                           { return nil }
+func (s *coverageStore) Refs(*code.Ref) errors.Error                                 { return nil }
+func (s *coverageStore) CommitFiles(*code.CommitFile) errors.Error                   { return nil }
+func (s *coverageStore) CommitParents([]*code.CommitParent) errors.Error             { return nil }
+func (s *coverageStore) CommitFileComponents(*code.CommitFileComponent) errors.Error { return nil }
+func (s *coverageStore) CommitLineChange(*code.CommitLineChange) errors.Error        { return nil }
+func (s *coverageStore) RepoSnapshot(*code.RepoSnapshot) errors.Error                { return nil }
+func (s *coverageStore) Close() errors.Error                                         { return nil }
+
+func (s *coverageStore) CqProjects(project *codequality.CqProject) errors.Error {
+	s.projects = append(s.projects, project)
+	return nil
+}
+
+func (s *coverageStore) CqFileCoverages(coverage *codequality.CqFileCoverage) errors.Error {
+	s.coverages = append(s.coverages, coverage)
+	return nil
+}
+
+type coverageSubTaskContext struct {
+	testSubTaskContext
+}
+
+func (coverageSubTaskContext) IncProgress(quantity int) {}
+
+func commitFile(t *testing.T, dir, path, content string) (*git.Repository, *git.Oid) {
+	return commitFiles(t, dir, map[string]string{path: content})
+}
+
+func commitFiles(t *testing.T, dir string, files map[string]string) (*git.Repository, *git.Oid) {
+	repo, err := git.InitRepository(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := repo.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := index.AddByPath(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := index.Write(); err != nil {
+		t.Fatal(err)
+	}
+	treeId, err := index.WriteTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := repo.LookupTree(treeId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := &git.Signature{Name: "devlake", Email: "devlake@example.com", When: time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)}
+	commitId, err := repo.CreateCommit("HEAD", sig, sig, "add coverage", tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo, commitId
+}
+
+func TestCollectCoverageReport(t *testing.T) {
+	dir := t.TempDir()
+	repo, commitId := commitFile(t, dir, "coverage/lcov.info", `TN:
+SF:src/app.js
+DA:1,1
+DA:2,0
+end_of_record
+SF:src/util.js
+DA:1,1
+end_of_record
+`)
+	defer repo.Free()
+	store := &coverageStore{}
+	gitRepo := &GitRepo{store: store, id: "github:GithubRepo:1:1", repo: repo}
+
+	err := gitRepo.CollectCoverageReport(coverageSubTaskContext{}, "apache/incubator-devlake", "coverage/lcov.info", "", "")
+	assert.Nil(t, err)
+	commitSha := commitId.String()
+	if assert.Len(t, store.projects, 1) {
+		assert.Equal(t, "github:GithubRepo:1:1", store.projects[0].Id)
+		assert.Equal(t, "apache/incubator-devlake", store.projects[0].Name)
+		assert.Equal(t, commitSha, store.projects[0].CommitSha)
+	}
+	if assert.Len(t, store.coverages, 2) {
+		assert.Equal(t, "src/app.js", store.coverages[0].FilePath)
+		assert.Equal(t, commitSha, store.coverages[0].CommitSha)
+		assert.Equal(t, "lcov", store.coverages[0].ReportFormat)
+		assert.Equal(t, 2, store.coverages[0].LinesToCover)
+		assert.Equal(t, 1, store.coverages[0].CoveredLines)
+		assert.Equal(t, float64(50), store.coverages[0].Coverage)
+		assert.Equal(t, "src/util.js", store.coverages[1].FilePath)
+		assert.Equal(t, float64(100), store.coverages[1].Coverage)
+	}
+}
+
+func TestCollectGoCoverageReport(t *testing.T) {
+	dir := t.TempDir()
+	repo, _ := commitFiles(t, dir, map[string]string{
+		"backend/go.mod": "module github.com/apache/incubator-devlake\n\ngo 1.20\n",
+		"backend/coverage.out": `mode: set
+github.com/apache/incubator-devlake/core/main.go:5.13,7.2 1 1
+`,
+		"tools/coverage.out": `mode: set
+github.com/apache/incubator-devlake/tools/main.go:5.13,7.2 1 1
+`,
+	})
+	defer repo.Free()
+
+	// the module path is stripped, and the directory of the go.mod is prefixed
+	store := &coverageStore{}
+	gitRepo := &GitRepo{store: store, id: "github:GithubRepo:1:1", repo: repo}
+	err := gitRepo.CollectCoverageReport(coverageSubTaskContext{}, "apache/incubator-devlake", "backend/coverage.out", "", "https://github.com/apache/incubator-devlake.git")
+	assert.Nil(t, err)
+	if assert.Len(t, store.coverages, 1) {
+		assert.Equal(t, "backend/core/main.go", store.coverages[0].FilePath)
+	}
+
+	// the module path is guessed by the url of the repo without a go.mod
+	store = &coverageStore{}
+	gitRepo = &GitRepo{store: store, id: "github:GithubRepo:1:1", repo: repo}
+	err = gitRepo.CollectCoverageReport(coverageSubTaskContext{}, "apache/incubator-devlake", "tools/coverage.out", "", "https://github.com/apache/incubator-devlake.git")
+	assert.Nil(t, err)
+	if assert.Len(t, store.coverages, 1) {
+		assert.Equal(t, "tools/main.go", store.coverages[0].FilePath)
+	}
+}
+
+func TestCollectCoverageReportInvalid(t *testing.T) {
+	dir := t.TempDir()
+	repo, _ := commitFile(t, dir, "coverage.out", "DA:1,1\n")
+	defer repo.Free()
+	store := &coverageStore{}
+	gitRepo := &GitRepo{store: store, id: "github:GithubRepo:1:1", repo: repo}
+
+	err := gitRepo.CollectCoverageReport(coverageSubTaskContext{}, "apache/incubator-devlake", "coverage.out", "lcov", "")
+	assert.NotNil(t, err)
+	assert.Empty(t, store.projects)
+	assert.Empty(t, store.coverages)
+}
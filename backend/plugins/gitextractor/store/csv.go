@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -86,6 +87,8 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	cqProjectWriter           *csvWriter
+	cqFileCoverageWriter      *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +132,14 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.cqProjectWriter, err = newCsvWriter(filepath.Join(dir, "cq_projects.csv"), codequality.CqProject{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	s.cqFileCoverageWriter, err = newCsvWriter(filepath.Join(dir, "cq_file_coverages.csv"), codequality.CqFileCoverage{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -160,6 +171,14 @@ func (c *CsvStore) RepoSnapshot(ss *code.RepoSnapshot) errors.Error {
 	return c.snapshotWriter.Write(ss)
 }
 
+func (c *CsvStore) CqProjects(project *codequality.CqProject) errors.Error {
+	return c.cqProjectWriter.Write(project)
+}
+
+func (c *CsvStore) CqFileCoverages(coverage *codequality.CqFileCoverage) errors.Error {
+	return c.cqFileCoverageWriter.Write(coverage)
+}
+
 func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	var err error
 	for _, p := range pp {
@@ -190,5 +209,11 @@ func (c *CsvStore) Close() errors.Error {
 	if c.snapshotWriter != nil {
 		c.snapshotWriter.Close()
 	}
+	if c.cqProjectWriter != nil {
+		c.cqProjectWriter.Close()
+	}
+	if c.cqFileCoverageWriter != nil {
+		c.cqFileCoverageWriter.Close()
+	}
 	return nil
 }
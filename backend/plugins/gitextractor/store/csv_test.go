@@ -18,10 +18,15 @@ limitations under the License.
 package store
 
 import (
+	"encoding/csv"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -78,3 +83,56 @@ func Test_csvWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func readCsv(t *testing.T, path string) [][]string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(content), "\xEF\xBB\xBF"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestCsvStore_Coverages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitextractor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := NewCsvStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, s.CqProjects(&codequality.CqProject{
+		DomainEntity: domainlayer.DomainEntity{Id: "github:GithubRepo:1:1"},
+		Name:         "apache/incubator-devlake",
+		CommitSha:    "8d5b6e1f",
+	}))
+	assert.Nil(t, s.CqFileCoverages(&codequality.CqFileCoverage{
+		DomainEntity:   domainlayer.DomainEntity{Id: "github:GithubRepo:1:1:8d5b6e1f:abc"},
+		ProjectKey:     "github:GithubRepo:1:1",
+		CommitSha:      "8d5b6e1f",
+		FilePath:       "src/app.js",
+		LinesToCover:   4,
+		CoveredLines:   3,
+		UncoveredLines: 1,
+		Coverage:       75,
+		ReportFormat:   "lcov",
+	}))
+	assert.Nil(t, s.Close())
+
+	projects := readCsv(t, filepath.Join(dir, "cq_projects.csv"))
+	assert.Equal(t, [][]string{
+		{"Name", "Qualifier", "Visibility", "LastAnalysisDate", "CommitSha"},
+		{"apache/incubator-devlake", "", "", "<nil>", "8d5b6e1f"},
+	}, projects)
+
+	coverages := readCsv(t, filepath.Join(dir, "cq_file_coverages.csv"))
+	assert.Equal(t, [][]string{
+		{"ProjectKey", "CommitSha", "FilePath", "LinesToCover", "CoveredLines", "UncoveredLines", "Coverage", "BranchesToCover", "CoveredBranches", "ReportFormat", "ReportedDate"},
+		{"github:GithubRepo:1:1", "8d5b6e1f", "src/app.js", "4", "3", "1", "75", "0", "0", "lcov", "<nil>"},
+	}, coverages)
+}
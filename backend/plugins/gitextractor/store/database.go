@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"reflect"
@@ -31,15 +32,19 @@ import (
 const BathSize = 100
 
 type Database struct {
-	driver *helper.BatchSaveDivider
-	table  string
-	params string
+	basicRes        context.BasicRes
+	driver          *helper.BatchSaveDivider
+	coverageDrivers map[string]*helper.BatchSaveDivider
+	table           string
+	params          string
 }
 
 func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
 	database := &Database{
-		table:  "gitextractor",
-		params: repoId,
+		basicRes:        basicRes,
+		coverageDrivers: make(map[string]*helper.BatchSaveDivider),
+		table:           "gitextractor",
+		params:          repoId,
 	}
 	database.driver = helper.NewBatchSaveDivider(
 		basicRes,
@@ -151,6 +156,39 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	return nil
 }
 
+func (d *Database) CqProjects(project *codequality.CqProject) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(project))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&project.RawDataOrigin)
+	return batch.Add(project)
+}
+
+// CqFileCoverages saves the coverage with a driver of the commit, so coverages of the previous commits are kept and
+// only the ones of the same commit get replaced
+func (d *Database) CqFileCoverages(coverage *codequality.CqFileCoverage) errors.Error {
+	params := d.params + ":" + coverage.CommitSha
+	driver, ok := d.coverageDrivers[params]
+	if !ok {
+		driver = helper.NewBatchSaveDivider(d.basicRes, BathSize, d.table, params)
+		d.coverageDrivers[params] = driver
+	}
+	batch, err := driver.ForType(reflect.TypeOf(coverage))
+	if err != nil {
+		return err
+	}
+	coverage.RawDataTable = d.table
+	coverage.RawDataParams = params
+	return batch.Add(coverage)
+}
+
 func (d *Database) Close() errors.Error {
+	for _, driver := range d.coverageDrivers {
+		err := driver.Close()
+		if err != nil {
+			return err
+		}
+	}
 	return d.driver.Close()
 }
@@ -39,6 +39,10 @@ type GitExtractorOptions struct {
 	PrivateKey string `json:"privateKey"`
 	Passphrase string `json:"passphrase"`
 	Proxy      string `json:"proxy"`
+	// path of the coverage report committed in the repo, e.g. coverage/lcov.info
+	CoverageReportPath string `json:"coverageReportPath"`
+	// lcov, cobertura or gocover, detected by the content if omitted
+	CoverageReportFormat string `json:"coverageReportFormat"`
 }
 
 func (o GitExtractorOptions) Valid() errors.Error {
@@ -103,6 +107,16 @@ func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return repo.CollectDiffLine(subTaskCtx)
 }
 
+func CollectCoverageReport(subTaskCtx plugin.SubTaskContext) errors.Error {
+	op := subTaskCtx.GetData().(*GitExtractorTaskData).Options
+	if op.CoverageReportPath == "" {
+		subTaskCtx.GetLogger().Info("coverageReportPath is not set, skip collecting coverage report")
+		return nil
+	}
+	repo := getGitRepo(subTaskCtx)
+	return repo.CollectCoverageReport(subTaskCtx, op.Name, op.CoverageReportPath, op.CoverageReportFormat, op.Url)
+}
+
 func getGitRepo(subTaskCtx plugin.SubTaskContext) *parser.GitRepo {
 	taskData, ok := subTaskCtx.GetData().(*GitExtractorTaskData)
 	if !ok {
@@ -149,3 +163,12 @@ var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
 }
+
+var CollectCoverageReportMeta = plugin.SubTaskMeta{
+	Name:             "collectCoverageReport",
+	EntryPoint:       CollectCoverageReport,
+	EnabledByDefault: true,
+	Description:      "collect the coverage report committed in the repo into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
@@ -124,14 +124,19 @@ func makeDataSourcePipelinePlanV200(
 			}
 			token := strings.Split(connection.Token, ",")[0]
 			cloneUrl.User = url.UserPassword("git", token)
+			gitextractorOp := map[string]interface{}{
+				"url":    cloneUrl.String(),
+				"name":   githubRepo.FullName,
+				"repoId": didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
+				"proxy":  connection.Proxy,
+			}
+			if scopeConfig.CoverageReportPath != "" {
+				gitextractorOp["coverageReportPath"] = scopeConfig.CoverageReportPath
+				gitextractorOp["coverageReportFormat"] = scopeConfig.CoverageReportFormat
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":    cloneUrl.String(),
-					"name":   githubRepo.FullName,
-					"repoId": didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
-					"proxy":  connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOp,
 			})
 
 		}
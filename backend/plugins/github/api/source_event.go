@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// events that might change the data collected from a repository
+var githubSourceEvents = map[string]bool{
+	"push":                        true,
+	"create":                      true,
+	"delete":                      true,
+	"pull_request":                true,
+	"pull_request_review":         true,
+	"pull_request_review_comment": true,
+	"issues":                      true,
+	"issue_comment":               true,
+	"milestone":                   true,
+	"deployment":                  true,
+	"deployment_status":           true,
+	"workflow_run":                true,
+	"workflow_job":                true,
+}
+
+// ReceiveSourceEvent verifies the `X-Hub-Signature-256` of the GitHub webhook and maps it to the affected repository
+func ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	err := api.VerifyHmacSignature(secret, body, header.Get("X-Hub-Signature-256"))
+	if err != nil {
+		return nil, err
+	}
+	if !githubSourceEvents[header.Get("X-GitHub-Event")] {
+		return nil, nil
+	}
+	payload := &struct {
+		Repository *struct {
+			Id int `json:"id"`
+		} `json:"repository"`
+	}{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode github event")
+	}
+	if payload.Repository == nil || payload.Repository.Id == 0 {
+		return nil, nil
+	}
+	return []string{strconv.Itoa(payload.Repository.Id)}, nil
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveSourceEvent(t *testing.T) {
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	body := []byte(`{"ref":"refs/heads/main","repository":{"id":123,"full_name":"apache/incubator-devlake"}}`)
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "push")
+	header.Set("X-Hub-Signature-256", sign(body))
+
+	scopeIds, err := ReceiveSourceEvent(1, "secret", header, body)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"123"}, scopeIds)
+
+	// wrong secret
+	_, err = ReceiveSourceEvent(1, "wrong", header, body)
+	assert.NotNil(t, err)
+
+	// events irrelevant to the data collected should be ignored
+	header.Set("X-GitHub-Event", "ping")
+	scopeIds, err = ReceiveSourceEvent(1, "secret", header, body)
+	assert.Nil(t, err)
+	assert.Empty(t, scopeIds)
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCoverageReportPath)(nil)
+
+type scopeConfig20240305 struct {
+	CoverageReportPath   string `gorm:"type:varchar(255)"`
+	CoverageReportFormat string `gorm:"type:varchar(255)"`
+}
+
+func (scopeConfig20240305) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type addCoverageReportPath struct{}
+
+func (script *addCoverageReportPath) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&scopeConfig20240305{},
+	)
+}
+
+func (*addCoverageReportPath) Version() uint64 {
+	return 20240305000001
+}
+
+func (script *addCoverageReportPath) Name() string {
+	return "add coverage_report_path and coverage_report_format to _tool_github_scope_configs"
+}
@@ -47,5 +47,6 @@ func All() []plugin.MigrationScript {
 		new(modifyGithubMilestone),
 		new(addEnvNamePattern),
 		new(modifyIssueTypeLength),
+		new(addCoverageReportPath),
 	}
 }
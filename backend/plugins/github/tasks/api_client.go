@@ -72,6 +72,25 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 			// TODO: consider different token has different rate-limit
 			return rateLimit * connection.GetTokensCount(), 1 * time.Hour, nil
 		},
+		RateLimitHeaderParser: func(res *http.Response) *api.RateLimitStatus {
+			status := api.ParseStandardRateLimitHeaders(res)
+			tokensCount := connection.GetTokensCount()
+			if status == nil || tokensCount <= 1 {
+				return status
+			}
+			// tokens are used in turn, the headers reflect the quota of the token used by the request only,
+			// so we presume all tokens are in the same situation like what DynamicRateLimit does
+			if status.Limit > 0 {
+				status.Limit *= tokensCount
+			}
+			if status.Remaining > 0 {
+				status.Remaining *= tokensCount
+			} else if status.Remaining == 0 {
+				// other tokens might still have quota left
+				status.Remaining = -1
+			}
+			return status
+		},
 	}
 	asyncApiClient, err := api.CreateAsyncApiClient(
 		taskCtx,
@@ -96,9 +96,10 @@ func CollectApiPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
-		ApiClient: data.ApiClient,
-		PageSize:  100,
-		Input:     iterator,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Input:              iterator,
+		ConditionalRequest: true,
 
 		UrlTemplate: "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}/commits",
 
@@ -90,9 +90,10 @@ func CollectApiPullRequestReviews(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
-		ApiClient: data.ApiClient,
-		PageSize:  100,
-		Input:     iterator,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Input:              iterator,
+		ConditionalRequest: true,
 
 		UrlTemplate: "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}/reviews",
 
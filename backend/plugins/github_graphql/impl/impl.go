@@ -226,6 +226,8 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 		v := reflect.ValueOf(q)
 		return int(v.Elem().FieldByName(`RateLimit`).FieldByName(`Cost`).Int())
 	})
+	// share the rate limit with the rest api client of the same connection
+	graphqlClient.SetConnectionRateLimiter(apiClient.GetConnectionRateLimiter())
 
 	regexEnricher := helper.NewRegexEnricher()
 	if err = regexEnricher.TryAdd(devops.DEPLOYMENT, op.ScopeConfig.DeploymentPattern); err != nil {
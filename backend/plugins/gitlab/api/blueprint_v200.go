@@ -141,14 +141,19 @@ func makePipelinePlanV200(
 				return nil, err
 			}
 			cloneUrl.User = url.UserPassword("git", connection.Token)
+			gitextractorOp := map[string]interface{}{
+				"url":    cloneUrl.String(),
+				"name":   gitlabProject.Name,
+				"repoId": didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
+				"proxy":  connection.Proxy,
+			}
+			if scopeConfig.CoverageReportPath != "" {
+				gitextractorOp["coverageReportPath"] = scopeConfig.CoverageReportPath
+				gitextractorOp["coverageReportFormat"] = scopeConfig.CoverageReportFormat
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":    cloneUrl.String(),
-					"name":   gitlabProject.Name,
-					"repoId": didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
-					"proxy":  connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOp,
 			})
 		}
 
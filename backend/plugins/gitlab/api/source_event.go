@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// events that might change the data collected from a project
+var gitlabSourceEvents = map[string]bool{
+	"Push Hook":          true,
+	"Tag Push Hook":      true,
+	"Merge Request Hook": true,
+	"Issue Hook":         true,
+	"Note Hook":          true,
+	"Pipeline Hook":      true,
+	"Job Hook":           true,
+	"Deployment Hook":    true,
+	"Release Hook":       true,
+}
+
+// ReceiveSourceEvent verifies the `X-Gitlab-Token` of the GitLab webhook and maps it to the affected project
+func ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	err := api.VerifySecretToken(secret, header.Get("X-Gitlab-Token"))
+	if err != nil {
+		return nil, err
+	}
+	if !gitlabSourceEvents[header.Get("X-Gitlab-Event")] {
+		return nil, nil
+	}
+	payload := &struct {
+		ProjectId int `json:"project_id"`
+		Project   *struct {
+			Id int `json:"id"`
+		} `json:"project"`
+	}{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode gitlab event")
+	}
+	projectId := payload.ProjectId
+	if payload.Project != nil && payload.Project.Id != 0 {
+		projectId = payload.Project.Id
+	}
+	if projectId == 0 {
+		return nil, nil
+	}
+	return []string{strconv.Itoa(projectId)}, nil
+}
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/subtaskmeta/sorter"
 
@@ -43,6 +44,7 @@ var _ interface {
 	plugin.PluginMigration
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourceEventReceiver
 	plugin.CloseablePluginTask
 } = (*Gitlab)(nil)
 
@@ -274,6 +276,10 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	}
 }
 
+func (p Gitlab) ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	return api.ReceiveSourceEvent(connectionId, secret, header, body)
+}
+
 func (p Gitlab) Close(taskCtx plugin.TaskContext) errors.Error {
 	data, ok := taskCtx.GetData().(*tasks.GitlabTaskData)
 	if !ok {
@@ -37,6 +37,8 @@ type GitlabScopeConfig struct {
 	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern       string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	CoverageReportPath   string            `mapstructure:"coverageReportPath,omitempty" json:"coverageReportPath" gorm:"type:varchar(255)"`
+	CoverageReportFormat string            `mapstructure:"coverageReportFormat,omitempty" json:"coverageReportFormat" gorm:"type:varchar(255)"`
 }
 
 func (t GitlabScopeConfig) TableName() string {
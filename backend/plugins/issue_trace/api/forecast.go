@@ -0,0 +1,313 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/utils"
+)
+
+const (
+	defaultHistoryWeeks = 12
+	defaultSimulations  = 10000
+	// simulations taking longer are capped and flagged, it happens when most of the sampled weeks delivered nothing
+	maxForecastWeeks = 520
+)
+
+var forecastPercentiles = []int{50, 85, 95}
+
+type ForecastParams struct {
+	ProjectName  string `mapstructure:"projectName"`
+	BoardId      string `mapstructure:"boardId"`
+	SprintId     string `mapstructure:"sprintId"`
+	EpicKey      string `mapstructure:"epicKey"`
+	HistoryWeeks int    `mapstructure:"historyWeeks"`
+	Simulations  int    `mapstructure:"simulations"`
+}
+
+type ForecastPercentile struct {
+	Percentile int       `json:"percentile"`
+	Weeks      int       `json:"weeks"`
+	Date       time.Time `json:"date"`
+	// Capped is true when the percentile falls on the simulations stopped at the maximum weeks, the items would not
+	// be done by the date
+	Capped bool `json:"capped"`
+}
+
+type ForecastResult struct {
+	RemainingItems    int                  `json:"remainingItems"`
+	HistoryWeeks      int                  `json:"historyWeeks"`
+	WeeklyThroughput  []int                `json:"weeklyThroughput"`
+	Simulations       int                  `json:"simulations"`
+	CappedSimulations int                  `json:"cappedSimulations"`
+	Forecasts         []ForecastPercentile `json:"forecasts"`
+}
+
+// GetForecast forecasts when the remaining items of an epic, a sprint or a board will be done
+// @Summary forecast the completion date of an epic, a sprint or a board
+// @Description Runs Monte Carlo simulations which sample the weekly throughput of the past weeks until the
+// @Description remaining items are done, and returns the 50/85/95 percentiles of the completion dates.
+// @Description The throughput is measured on the board given by boardId, or on the boards of the epic or sprint
+// @Description Simulations not done within 520 weeks are capped, the percentiles falling on them are flagged
+// @Tags plugins/issue_trace
+// @Param projectName query string false "the project the epic is looked up in when boardId is not given"
+// @Param boardId query string false "domain board id, the scope when neither epicKey nor sprintId is given"
+// @Param sprintId query string false "domain sprint id"
+// @Param epicKey query string false "issue key of the epic"
+// @Param historyWeeks query int false "weeks of throughput history to sample, 12 by default"
+// @Param simulations query int false "number of simulations, 10000 by default"
+// @Success 200  {object} ForecastResult
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/forecast [GET]
+func GetForecast(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	params := &ForecastParams{
+		HistoryWeeks: defaultHistoryWeeks,
+		Simulations:  defaultSimulations,
+	}
+	err := utils.DecodeMapStruct(input.Query, params, false)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to decode forecast params from query string")
+	}
+	if params.EpicKey != "" && params.SprintId != "" {
+		return nil, errors.BadInput.New("only one of epicKey and sprintId is allowed")
+	}
+	if params.EpicKey == "" && params.SprintId == "" && params.BoardId == "" {
+		return nil, errors.BadInput.New("one of epicKey, sprintId and boardId is required")
+	}
+	// issue keys are unique within a jira instance only
+	if params.EpicKey != "" && params.BoardId == "" && params.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName or boardId is required to look up the epic")
+	}
+	if params.HistoryWeeks < 1 || params.HistoryWeeks > 104 {
+		return nil, errors.BadInput.New("historyWeeks must be between 1 and 104")
+	}
+	if params.Simulations < 100 || params.Simulations > 100000 {
+		return nil, errors.BadInput.New("simulations must be between 100 and 100000")
+	}
+
+	db := basicRes.GetDal()
+	remaining, boardIds, err := getForecastScope(db, params)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	throughput, err := getWeeklyThroughput(db, boardIds, params.HistoryWeeks, now)
+	if err != nil {
+		return nil, err
+	}
+	weeks, capped, err := simulateWeeksToComplete(remaining, throughput, params.Simulations, rand.New(rand.NewSource(now.UnixNano())))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: &ForecastResult{
+			RemainingItems:    remaining,
+			HistoryWeeks:      params.HistoryWeeks,
+			WeeklyThroughput:  throughput,
+			Simulations:       params.Simulations,
+			CappedSimulations: capped,
+			Forecasts:         forecast(weeks, capped, now),
+		},
+		Status: http.StatusOK,
+	}, nil
+}
+
+// getForecastScope counts the items which are not done yet and finds the boards to measure the throughput on
+func getForecastScope(db dal.Dal, params *ForecastParams) (int, []string, errors.Error) {
+	var boardIds []string
+	if params.BoardId != "" {
+		boardIds = []string{params.BoardId}
+	}
+	var clauses []dal.Clause
+	switch {
+	case params.EpicKey != "":
+		// the epic is looked up on the board or the boards of the project
+		epicClauses := []dal.Clause{
+			dal.Select("i.*"),
+			dal.From("issues i"),
+			dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+			dal.Where("i.issue_key = ?", params.EpicKey),
+		}
+		if params.BoardId != "" {
+			epicClauses = append(epicClauses, dal.Where("bi.board_id = ?", params.BoardId))
+		} else {
+			epicClauses = append(epicClauses,
+				dal.Join("JOIN project_mapping pm ON pm.row_id = bi.board_id"),
+				dal.Where("pm.project_name = ? AND pm.table = ?", params.ProjectName, "boards"),
+			)
+		}
+		epic := &ticket.Issue{}
+		err := db.First(epic, epicClauses...)
+		if err != nil {
+			if db.IsErrorNotFound(err) {
+				return 0, nil, errors.NotFound.New("epic not found")
+			}
+			return 0, nil, err
+		}
+		if boardIds == nil {
+			err = db.Pluck(
+				"bi.board_id",
+				&boardIds,
+				dal.From("board_issues bi"),
+				dal.Join("JOIN project_mapping pm ON pm.row_id = bi.board_id"),
+				dal.Where("bi.issue_id = ? AND pm.project_name = ? AND pm.table = ?", epic.Id, params.ProjectName, "boards"),
+			)
+			if err != nil {
+				return 0, nil, err
+			}
+		}
+		// epic keys of other jira instances are alike, only the children on the boards are counted
+		clauses = []dal.Clause{
+			dal.From("issues i"),
+			dal.Where(
+				"(i.parent_issue_id = ? OR (i.epic_key = ? AND i.id IN (SELECT issue_id FROM board_issues WHERE board_id IN ?))) AND i.id != ?",
+				epic.Id, params.EpicKey, boardIds, epic.Id,
+			),
+		}
+	case params.SprintId != "":
+		clauses = []dal.Clause{
+			dal.From("issues i"),
+			dal.Join("left join sprint_issues si on si.issue_id = i.id"),
+			dal.Where("si.sprint_id = ?", params.SprintId),
+		}
+		if boardIds == nil {
+			err := db.Pluck("board_id", &boardIds, dal.From(&ticket.BoardSprint{}), dal.Where("sprint_id = ?", params.SprintId))
+			if err != nil {
+				return 0, nil, err
+			}
+		}
+	default:
+		clauses = []dal.Clause{
+			dal.From("issues i"),
+			dal.Join("left join board_issues bi on bi.issue_id = i.id"),
+			dal.Where("bi.board_id = ?", params.BoardId),
+		}
+	}
+	if len(boardIds) == 0 {
+		return 0, nil, errors.BadInput.New("no board to measure the throughput on, please specify boardId")
+	}
+	remaining, err := db.Count(append(clauses, dal.Where("i.status != ?", ticket.DONE))...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return int(remaining), boardIds, nil
+}
+
+// getWeeklyThroughput counts the items done on the boards in each of the past weeks, from the oldest to the latest
+func getWeeklyThroughput(db dal.Dal, boardIds []string, historyWeeks int, now time.Time) ([]int, errors.Error) {
+	since := now.AddDate(0, 0, -7*historyWeeks)
+	var resolvedIssues []struct {
+		Id             string
+		ResolutionDate time.Time
+	}
+	err := db.All(
+		&resolvedIssues,
+		dal.Select("i.id, i.resolution_date"),
+		dal.From("issues i"),
+		dal.Join("left join board_issues bi on bi.issue_id = i.id"),
+		dal.Where(
+			"bi.board_id IN ? AND i.status = ? AND i.resolution_date >= ? AND i.resolution_date < ?",
+			boardIds, ticket.DONE, since, now,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// an issue may be on more than one of the boards
+	seen := make(map[string]bool, len(resolvedIssues))
+	resolutionDates := make([]time.Time, 0, len(resolvedIssues))
+	for _, issue := range resolvedIssues {
+		if !seen[issue.Id] {
+			seen[issue.Id] = true
+			resolutionDates = append(resolutionDates, issue.ResolutionDate)
+		}
+	}
+	return bucketByWeek(resolutionDates, historyWeeks, now), nil
+}
+
+func bucketByWeek(dates []time.Time, historyWeeks int, now time.Time) []int {
+	throughput := make([]int, historyWeeks)
+	for _, date := range dates {
+		weeksAgo := int(now.Sub(date) / (7 * 24 * time.Hour))
+		if weeksAgo >= 0 && weeksAgo < historyWeeks {
+			throughput[historyWeeks-1-weeksAgo]++
+		}
+	}
+	return throughput
+}
+
+// simulateWeeksToComplete returns the sorted numbers of weeks each simulation took to finish the remaining items
+// by drawing the throughput of a random past week for every week ahead, and the number of simulations which were
+// stopped at maxForecastWeeks before the items were done, they are the last ones of the results
+func simulateWeeksToComplete(remaining int, throughput []int, simulations int, rnd *rand.Rand) ([]int, int, errors.Error) {
+	results := make([]int, simulations)
+	if remaining == 0 {
+		return results, 0, nil
+	}
+	total := 0
+	for _, t := range throughput {
+		total += t
+	}
+	if total == 0 {
+		return nil, 0, errors.BadInput.New("nothing was done in the history window, please extend historyWeeks")
+	}
+	capped := 0
+	for i := range results {
+		left, weeks := remaining, 0
+		for left > 0 && weeks < maxForecastWeeks {
+			left -= throughput[rnd.Intn(len(throughput))]
+			weeks++
+		}
+		if left > 0 {
+			capped++
+		}
+		results[i] = weeks
+	}
+	sort.Ints(results)
+	return results, capped, nil
+}
+
+// forecast picks the percentiles of the sorted weeks, the last `capped` weeks are the capped simulations
+func forecast(sortedWeeks []int, capped int, now time.Time) []ForecastPercentile {
+	forecasts := make([]ForecastPercentile, 0, len(forecastPercentiles))
+	for _, p := range forecastPercentiles {
+		index := int(math.Ceil(float64(p)/100*float64(len(sortedWeeks)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		weeks := sortedWeeks[index]
+		forecasts = append(forecasts, ForecastPercentile{
+			Percentile: p,
+			Weeks:      weeks,
+			Date:       now.AddDate(0, 0, 7*weeks),
+			Capped:     index >= len(sortedWeeks)-capped,
+		})
+	}
+	return forecasts
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketByWeek(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	dates := []time.Time{
+		now.Add(-time.Hour),
+		now.AddDate(0, 0, -6),
+		now.AddDate(0, 0, -7),
+		now.AddDate(0, 0, -20),
+		// out of the history window
+		now.AddDate(0, 0, -21),
+	}
+	assert.Equal(t, []int{1, 1, 2}, bucketByWeek(dates, 3, now))
+}
+
+func TestSimulateWeeksToComplete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	weeks, capped, err := simulateWeeksToComplete(5, []int{2, 2, 2}, 100, rnd)
+	assert.Nil(t, err)
+	for _, w := range weeks {
+		assert.Equal(t, 3, w)
+	}
+
+	weeks, capped, err = simulateWeeksToComplete(10, []int{1, 5}, 1000, rnd)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, weeks[0])
+	assert.Equal(t, 10, weeks[len(weeks)-1])
+	assert.IsNonDecreasing(t, weeks)
+	assert.Equal(t, 0, capped)
+
+	weeks, capped, err = simulateWeeksToComplete(0, []int{0, 0}, 100, rnd)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, weeks[99])
+
+	_, _, err = simulateWeeksToComplete(3, []int{0, 0}, 100, rnd)
+	assert.NotNil(t, err)
+
+	// hardly anything was done, most simulations are capped
+	throughput := make([]int, 100)
+	throughput[0] = 1
+	weeks, capped, err = simulateWeeksToComplete(20, throughput, 100, rnd)
+	assert.Nil(t, err)
+	assert.Greater(t, capped, 50)
+	assert.Equal(t, maxForecastWeeks, weeks[len(weeks)-1])
+}
+
+func TestForecast(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	sortedWeeks := make([]int, 100)
+	for i := range sortedWeeks {
+		sortedWeeks[i] = i / 10
+	}
+	forecasts := forecast(sortedWeeks, 0, now)
+	if assert.Len(t, forecasts, 3) {
+		assert.Equal(t, ForecastPercentile{Percentile: 50, Weeks: 4, Date: now.AddDate(0, 0, 28)}, forecasts[0])
+		assert.Equal(t, ForecastPercentile{Percentile: 85, Weeks: 8, Date: now.AddDate(0, 0, 56)}, forecasts[1])
+		assert.Equal(t, ForecastPercentile{Percentile: 95, Weeks: 9, Date: now.AddDate(0, 0, 63)}, forecasts[2])
+	}
+
+	// the last 10 simulations were capped
+	forecasts = forecast(sortedWeeks, 10, now)
+	assert.False(t, forecasts[0].Capped)
+	assert.False(t, forecasts[1].Capped)
+	assert.True(t, forecasts[2].Capped)
+}
@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+type IssueHierarchyNode struct {
+	Id        string                 `json:"id"`
+	IssueKey  string                 `json:"issueKey"`
+	Title     string                 `json:"title"`
+	Type      string                 `json:"type"`
+	Status    string                 `json:"status"`
+	Hierarchy *ticket.IssueHierarchy `json:"hierarchy"`
+	Children  []*IssueHierarchyNode  `json:"children"`
+}
+
+type IssueHierarchyTree struct {
+	Ancestors []*IssueHierarchyNode `json:"ancestors"`
+	Tree      *IssueHierarchyNode   `json:"tree"`
+}
+
+// GetIssueHierarchy returns the ancestors of an issue and the tree of its descendants
+// @Summary get the hierarchy of an issue
+// @Description the ancestors of the issue from the root down, and the issue with its descendants along with the rolled-up story points, estimates, time spent and progress
+// @Tags plugins/issue_trace
+// @Param issueId path string true "domain issue id"
+// @Success 200  {object} IssueHierarchyTree
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/issue-hierarchy/{issueId} [GET]
+func GetIssueHierarchy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	issueId := input.Params["issueId"]
+	db := basicRes.GetDal()
+	hierarchy := &ticket.IssueHierarchy{}
+	err := db.First(hierarchy, dal.Where("issue_id = ?", issueId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("no hierarchy for the issue, please run the issue_trace plugin first")
+		}
+		return nil, err
+	}
+	tree := &IssueHierarchyTree{}
+	tree.Tree, err = newIssueHierarchyNode(db, hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	err = loadDescendants(db, tree.Tree)
+	if err != nil {
+		return nil, err
+	}
+	tree.Ancestors, err = loadAncestors(db, hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: tree, Status: http.StatusOK}, nil
+}
+
+func newIssueHierarchyNode(db dal.Dal, hierarchy *ticket.IssueHierarchy) (*IssueHierarchyNode, errors.Error) {
+	node := &IssueHierarchyNode{
+		Id:        hierarchy.IssueId,
+		Hierarchy: hierarchy,
+	}
+	issue := &ticket.Issue{}
+	err := db.First(issue, dal.Where("id = ?", hierarchy.IssueId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return node, nil
+		}
+		return nil, err
+	}
+	node.IssueKey = issue.IssueKey
+	node.Title = issue.Title
+	node.Type = issue.Type
+	node.Status = issue.Status
+	return node, nil
+}
+
+// loadDescendants fills the children of the node level by level
+func loadDescendants(db dal.Dal, root *IssueHierarchyNode) errors.Error {
+	visited := map[string]bool{root.Id: true}
+	level := []*IssueHierarchyNode{root}
+	for len(level) > 0 {
+		var next []*IssueHierarchyNode
+		for _, node := range level {
+			var children []*ticket.IssueHierarchy
+			err := db.All(&children, dal.Where("parent_issue_id = ?", node.Id), dal.Orderby("issue_id"))
+			if err != nil {
+				return err
+			}
+			node.Children = make([]*IssueHierarchyNode, 0, len(children))
+			for _, child := range children {
+				if visited[child.IssueId] {
+					continue
+				}
+				visited[child.IssueId] = true
+				childNode, err := newIssueHierarchyNode(db, child)
+				if err != nil {
+					return err
+				}
+				node.Children = append(node.Children, childNode)
+			}
+			next = append(next, node.Children...)
+		}
+		level = next
+	}
+	return nil
+}
+
+// loadAncestors walks up the parents of the issue and returns them starting from the root
+func loadAncestors(db dal.Dal, hierarchy *ticket.IssueHierarchy) ([]*IssueHierarchyNode, errors.Error) {
+	visited := map[string]bool{hierarchy.IssueId: true}
+	var ancestors []*IssueHierarchyNode
+	for parentId := hierarchy.ParentIssueId; parentId != "" && !visited[parentId]; {
+		visited[parentId] = true
+		parent := &ticket.IssueHierarchy{}
+		err := db.First(parent, dal.Where("issue_id = ?", parentId))
+		if err != nil {
+			if db.IsErrorNotFound(err) {
+				break
+			}
+			return nil, err
+		}
+		node, err := newIssueHierarchyNode(db, parent)
+		if err != nil {
+			return nil, err
+		}
+		ancestors = append([]*IssueHierarchyNode{node}, ancestors...)
+		parentId = parent.ParentIssueId
+	}
+	return ancestors, nil
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+type SprintAnalyticsSummary struct {
+	SprintName string `json:"sprintName"`
+	ticket.SprintAnalytics
+}
+
+type SprintAnalyticsDetail struct {
+	SprintName string                         `json:"sprintName"`
+	Analytics  *ticket.SprintAnalytics        `json:"analytics"`
+	Issues     []*ticket.SprintIssueAnalytics `json:"issues"`
+	Burndown   []*ticket.SprintBurndown       `json:"burndown"`
+}
+
+// ListSprintAnalytics lists the analytics of the sprints in a project
+// @Summary list the analytics of the sprints in a project
+// @Description committed, added, removed, completed and carried-over scope of the sprints ordered by start date
+// @Tags plugins/issue_trace
+// @Param projectName query string true "project name"
+// @Success 200  {object} []SprintAnalyticsSummary
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/sprint-analytics [GET]
+func ListSprintAnalytics(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	projectName := input.Query.Get("projectName")
+	if projectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	var summaries []*SprintAnalyticsSummary
+	err := basicRes.GetDal().All(
+		&summaries,
+		dal.Select("s.name AS sprint_name, sa.*"),
+		dal.From("sprint_analytics sa"),
+		dal.Join("left join sprints s on s.id = sa.sprint_id"),
+		dal.Join("left join board_sprints bs on bs.sprint_id = sa.sprint_id"),
+		dal.Join("left join project_mapping pm on pm.row_id = bs.board_id"),
+		dal.Where("pm.project_name = ? and pm.table = ?", projectName, "boards"),
+		dal.Orderby("sa.started_date ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: summaries, Status: http.StatusOK}, nil
+}
+
+// GetSprintAnalytics returns the analytics, the issues and the daily burndown of a sprint
+// @Summary get the analytics of a sprint
+// @Description the scope changes of the sprint along with the part every issue took in it and the daily burndown
+// @Tags plugins/issue_trace
+// @Param sprintId path string true "domain sprint id"
+// @Success 200  {object} SprintAnalyticsDetail
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/sprint-analytics/{sprintId} [GET]
+func GetSprintAnalytics(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	sprintId := input.Params["sprintId"]
+	db := basicRes.GetDal()
+	analytics := &ticket.SprintAnalytics{}
+	err := db.First(analytics, dal.Where("sprint_id = ?", sprintId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("no analytics for the sprint, please run the issue_trace plugin first")
+		}
+		return nil, err
+	}
+	detail := &SprintAnalyticsDetail{
+		Analytics: analytics,
+	}
+	sprint := &ticket.Sprint{}
+	err = db.First(sprint, dal.Where("id = ?", sprintId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, err
+	}
+	detail.SprintName = sprint.Name
+	err = db.All(&detail.Issues, dal.Where("sprint_id = ?", sprintId), dal.Orderby("issue_id"))
+	if err != nil {
+		return nil, err
+	}
+	err = db.All(&detail.Burndown, dal.Where("sprint_id = ?", sprintId), dal.Orderby("date"))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: detail, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/impl"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+func TestSprintAnalyticsDataFlow(t *testing.T) {
+	var plugin impl.IssueTrace
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "issue_trace", plugin)
+
+	taskData := &tasks.IssueTraceTaskData{
+		Options: &tasks.IssueTraceOptions{
+			ProjectName: "project1",
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/project_mapping.csv", &crossdomain.ProjectMapping{})
+	dataflowTester.ImportCsvIntoTabler("./sprint_analytics/board_sprints.csv", &ticket.BoardSprint{})
+	dataflowTester.ImportCsvIntoTabler("./sprint_analytics/sprints.csv", &ticket.Sprint{})
+	dataflowTester.ImportCsvIntoTabler("./sprint_analytics/sprint_issues.csv", &ticket.SprintIssue{})
+	dataflowTester.ImportCsvIntoTabler("./sprint_analytics/issues.csv", &ticket.Issue{})
+	dataflowTester.ImportCsvIntoTabler("./sprint_analytics/issue_changelogs.csv", &ticket.IssueChangelogs{})
+
+	// verify sprint analytics
+	dataflowTester.FlushTabler(&ticket.SprintAnalytics{})
+	dataflowTester.FlushTabler(&ticket.SprintIssueAnalytics{})
+	dataflowTester.FlushTabler(&ticket.SprintBurndown{})
+	dataflowTester.Subtask(tasks.CalculateSprintAnalyticsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintAnalytics{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./sprint_analytics/sprint_analytics.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintIssueAnalytics{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./sprint_analytics/sprint_issue_analytics.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintBurndown{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./sprint_analytics/sprint_burndowns.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.MetricPluginBlueprintV200
+} = (*IssueTrace)(nil)
+
+type IssueTrace struct{}
+
+func (p IssueTrace) Description() string {
+	return "Trace the status and sprint history of issues to calculate flow metrics, sprint analytics and issue hierarchy roll-ups"
+}
+
+func (p IssueTrace) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p IssueTrace) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{
+		{
+			"model": "issue_changelogs",
+			"requiredFields": map[string]string{
+				"column":        "field_name",
+				"execptedValue": "status",
+			},
+		},
+	}, nil
+}
+
+func (p IssueTrace) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p IssueTrace) Name() string {
+	return "issue_trace"
+}
+
+func (p IssueTrace) IsProjectMetric() bool {
+	return true
+}
+
+func (p IssueTrace) RunAfter() ([]string, errors.Error) {
+	return []string{}, nil
+}
+
+func (p IssueTrace) Settings() interface{} {
+	return nil
+}
+
+func (p IssueTrace) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.GenerateIssueStatusIntervalsMeta,
+		tasks.CalculateIssueFlowMetricsMeta,
+		tasks.CalculateSprintAnalyticsMeta,
+		tasks.EnrichIssueHierarchiesMeta,
+	}
+}
+
+func (p IssueTrace) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.IssueTraceTaskData{
+		Options: op,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p IssueTrace) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/issue_trace"
+}
+
+func (p IssueTrace) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"sprint-analytics": {
+			"GET": api.ListSprintAnalytics,
+		},
+		"sprint-analytics/:sprintId": {
+			"GET": api.GetSprintAnalytics,
+		},
+		"forecast": {
+			"GET": api.GetForecast,
+		},
+		"issue-hierarchy/:issueId": {
+			"GET": api.GetIssueHierarchy,
+		},
+	}
+}
+
+func (p IssueTrace) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "issue_trace",
+				Options: map[string]interface{}{
+					"projectName": projectName,
+				},
+			},
+		},
+	}
+	return plan, nil
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = CalculateIssueFlowMetrics
+
+var CalculateIssueFlowMetricsMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueFlowMetrics",
+	EntryPoint:       CalculateIssueFlowMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate cycle time, wait time, flow efficiency and reopen count of issues from their status intervals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// CalculateIssueFlowMetrics summarizes the status intervals generated by GenerateIssueStatusIntervals
+func CalculateIssueFlowMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+	cursor, err := db.Cursor(append(projectIssuesClauses(data.Options.ProjectName), dal.Orderby("i.id ASC"))...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	issueIntervals, err := newIssueRowsReader(
+		db,
+		func(interval *ticket.IssueStatusInterval) string { return interval.IssueId },
+		dal.From(&ticket.IssueStatusInterval{}),
+		dal.Where("issue_id IN ("+projectIssueIdsQuery+")", data.Options.ProjectName, "boards"),
+		dal.Orderby("issue_id ASC, start_date ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer issueIntervals.close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: IssueTraceApiParams{
+				ProjectName: data.Options.ProjectName,
+			},
+			Table: "issue_status_intervals",
+		},
+		InputRowType: reflect.TypeOf(ticket.Issue{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			issue := inputRow.(*ticket.Issue)
+			intervals, err := issueIntervals.read(issue.Id)
+			if err != nil {
+				return nil, err
+			}
+			if len(intervals) == 0 {
+				return nil, nil
+			}
+			return []interface{}{calculateFlowMetric(issue.Id, intervals)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// buildIssueHierarchies links every issue to its parent issue, or to its epic when it has no parent, and rolls
+// up the estimates, the time spent and the progress of each subtree. The epic key is resolved among the issues
+// collected by the same connection. Parents which are not among the given issues are recorded but end the
+// tree, and parent links which would close a cycle are dropped. Time spent is taken from the worklogs when
+// the issue has any, and from the issue itself otherwise
+func buildIssueHierarchies(issues []*ticket.Issue, worklogMinutes map[string]int64) map[string]*ticket.IssueHierarchy {
+	issuesById := make(map[string]*ticket.Issue, len(issues))
+	for _, issue := range issues {
+		issuesById[issue.Id] = issue
+	}
+	ids := make([]string, 0, len(issuesById))
+	for id := range issuesById {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	issueIdsByKey := make(map[string]string)
+	for _, id := range ids {
+		issue := issuesById[id]
+		if issue.IssueKey == "" {
+			continue
+		}
+		key := idPrefix(id) + "#" + issue.IssueKey
+		if _, ok := issueIdsByKey[key]; !ok {
+			issueIdsByKey[key] = id
+		}
+	}
+
+	hierarchies := make(map[string]*ticket.IssueHierarchy, len(ids))
+	parents := make(map[string]string)
+	for _, id := range ids {
+		issue := issuesById[id]
+		hierarchy := &ticket.IssueHierarchy{IssueId: id}
+		if issue.ParentIssueId != "" && issue.ParentIssueId != id {
+			hierarchy.ParentIssueId = issue.ParentIssueId
+		} else if issue.EpicKey != "" {
+			epicId := issueIdsByKey[idPrefix(id)+"#"+issue.EpicKey]
+			if epicId != id {
+				hierarchy.ParentIssueId = epicId
+			}
+		}
+		if _, ok := issuesById[hierarchy.ParentIssueId]; ok {
+			parents[id] = hierarchy.ParentIssueId
+		}
+		hierarchies[id] = hierarchy
+	}
+	for _, id := range ids {
+		visited := map[string]bool{id: true}
+		for parentId := parents[id]; parentId != ""; parentId = parents[parentId] {
+			if parentId == id {
+				delete(parents, id)
+				hierarchies[id].ParentIssueId = ""
+				break
+			}
+			if visited[parentId] {
+				break
+			}
+			visited[parentId] = true
+		}
+	}
+
+	children := make(map[string][]string)
+	for _, id := range ids {
+		if parentId, ok := parents[id]; ok {
+			children[parentId] = append(children[parentId], id)
+		}
+	}
+	var rollUp func(id, rootId string, depth int) *ticket.IssueHierarchy
+	rollUp = func(id, rootId string, depth int) *ticket.IssueHierarchy {
+		issue := issuesById[id]
+		hierarchy := hierarchies[id]
+		hierarchy.RootIssueId = rootId
+		hierarchy.Depth = depth
+		hierarchy.ChildCount = len(children[id])
+		hierarchy.TotalIssues = 1
+		if issue.StoryPoint != nil {
+			hierarchy.TotalStoryPoints = *issue.StoryPoint
+		}
+		if issue.Status == ticket.DONE {
+			hierarchy.DoneIssues = 1
+			hierarchy.DoneStoryPoints = hierarchy.TotalStoryPoints
+		}
+		if issue.OriginalEstimateMinutes != nil {
+			hierarchy.TotalOriginalEstimateMinutes = *issue.OriginalEstimateMinutes
+		}
+		if minutes := worklogMinutes[id]; minutes > 0 {
+			hierarchy.TotalTimeSpentMinutes = minutes
+		} else if issue.TimeSpentMinutes != nil {
+			hierarchy.TotalTimeSpentMinutes = *issue.TimeSpentMinutes
+		}
+		if issue.TimeRemainingMinutes != nil {
+			hierarchy.TotalTimeRemainingMinutes = *issue.TimeRemainingMinutes
+		}
+		for _, childId := range children[id] {
+			child := rollUp(childId, rootId, depth+1)
+			hierarchy.TotalIssues += child.TotalIssues
+			hierarchy.DoneIssues += child.DoneIssues
+			hierarchy.TotalStoryPoints += child.TotalStoryPoints
+			hierarchy.DoneStoryPoints += child.DoneStoryPoints
+			hierarchy.TotalOriginalEstimateMinutes += child.TotalOriginalEstimateMinutes
+			hierarchy.TotalTimeSpentMinutes += child.TotalTimeSpentMinutes
+			hierarchy.TotalTimeRemainingMinutes += child.TotalTimeRemainingMinutes
+		}
+		hierarchy.PercentComplete = percentComplete(hierarchy)
+		return hierarchy
+	}
+	for _, id := range ids {
+		if _, ok := parents[id]; !ok {
+			rollUp(id, id, 0)
+		}
+	}
+	return hierarchies
+}
+
+// percentComplete weighs the issues by their story points when the subtree is estimated and counts them otherwise
+func percentComplete(hierarchy *ticket.IssueHierarchy) float64 {
+	var percent float64
+	if hierarchy.TotalStoryPoints > 0 {
+		percent = hierarchy.DoneStoryPoints / hierarchy.TotalStoryPoints * 100
+	} else {
+		percent = float64(hierarchy.DoneIssues) / float64(hierarchy.TotalIssues) * 100
+	}
+	return math.Round(percent*100) / 100
+}
+
+// idPrefix strips the tool id from a domain issue id, issues sharing the prefix come from the same connection
+func idPrefix(id string) string {
+	return id[:strings.LastIndex(id, ":")+1]
+}
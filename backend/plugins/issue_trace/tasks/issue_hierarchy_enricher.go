@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = EnrichIssueHierarchies
+
+var EnrichIssueHierarchiesMeta = plugin.SubTaskMeta{
+	Name:             "enrichIssueHierarchies",
+	EntryPoint:       EnrichIssueHierarchies,
+	EnabledByDefault: true,
+	Description:      "Build the hierarchies of issues from their parents and epics and roll up story points, estimates, worklogs and progress",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type issueWorklogSum struct {
+	IssueId          string
+	TimeSpentMinutes int64
+}
+
+// EnrichIssueHierarchies materializes the hierarchies of all issues in the project at once, since the roll-up
+// of an issue depends on all of its descendants
+func EnrichIssueHierarchies(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+
+	var issues []*ticket.Issue
+	err := db.All(&issues, projectIssuesClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	var worklogSums []issueWorklogSum
+	err = db.All(
+		&worklogSums,
+		dal.Select("w.issue_id, SUM(w.time_spent_minutes) AS time_spent_minutes"),
+		dal.From("issue_worklogs w"),
+		dal.Where(
+			`w.issue_id IN (
+				SELECT bi.issue_id FROM board_issues bi
+				LEFT JOIN project_mapping pm ON pm.row_id = bi.board_id
+				WHERE pm.project_name = ? AND pm.table = ?
+			)`,
+			data.Options.ProjectName, "boards",
+		),
+		dal.Groupby("w.issue_id"),
+	)
+	if err != nil {
+		return err
+	}
+	worklogMinutes := make(map[string]int64, len(worklogSums))
+	for _, sum := range worklogSums {
+		worklogMinutes[sum.IssueId] = sum.TimeSpentMinutes
+	}
+	hierarchies := buildIssueHierarchies(issues, worklogMinutes)
+
+	cursor, err := db.Cursor(projectIssuesClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: IssueTraceApiParams{
+				ProjectName: data.Options.ProjectName,
+			},
+			Table: "issues",
+		},
+		InputRowType: reflect.TypeOf(ticket.Issue{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			issue := inputRow.(*ticket.Issue)
+			return []interface{}{hierarchies[issue.Id]}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
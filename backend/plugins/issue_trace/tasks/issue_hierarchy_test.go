@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIssueHierarchies(t *testing.T) {
+	points := func(value float64) *float64 {
+		return &value
+	}
+	minutes := func(value int64) *int64 {
+		return &value
+	}
+	issues := []*ticket.Issue{
+		{DomainEntity: domainlayer.DomainEntity{Id: "jira:JiraIssue:1:1"}, IssueKey: "P-1", Status: ticket.IN_PROGRESS},
+		{DomainEntity: domainlayer.DomainEntity{Id: "jira:JiraIssue:1:2"}, IssueKey: "P-2", EpicKey: "P-1", Status: ticket.DONE, StoryPoint: points(3), TimeSpentMinutes: minutes(30)},
+		{DomainEntity: domainlayer.DomainEntity{Id: "jira:JiraIssue:1:3"}, IssueKey: "P-3", EpicKey: "P-1", Status: ticket.TODO, StoryPoint: points(1), OriginalEstimateMinutes: minutes(60)},
+		{DomainEntity: domainlayer.DomainEntity{Id: "jira:JiraIssue:1:4"}, IssueKey: "P-4", ParentIssueId: "jira:JiraIssue:1:3", Status: ticket.DONE, TimeSpentMinutes: minutes(10)},
+		// the epic key must not be resolved across connections
+		{DomainEntity: domainlayer.DomainEntity{Id: "jira:JiraIssue:2:1"}, IssueKey: "Q-1", EpicKey: "P-1", Status: ticket.TODO},
+		// a cycle must be broken instead of recursing forever
+		{DomainEntity: domainlayer.DomainEntity{Id: "webhook:1:A"}, IssueKey: "A", ParentIssueId: "webhook:1:B", Status: ticket.DONE},
+		{DomainEntity: domainlayer.DomainEntity{Id: "webhook:1:B"}, IssueKey: "B", ParentIssueId: "webhook:1:A", Status: ticket.TODO},
+		// the parent is unknown so the issue becomes a root
+		{DomainEntity: domainlayer.DomainEntity{Id: "webhook:1:C"}, IssueKey: "C", ParentIssueId: "webhook:1:Z", Status: ticket.TODO},
+	}
+	hierarchies := buildIssueHierarchies(issues, map[string]int64{"jira:JiraIssue:1:4": 45})
+	assert.Len(t, hierarchies, len(issues))
+
+	epic := hierarchies["jira:JiraIssue:1:1"]
+	assert.Equal(t, "", epic.ParentIssueId)
+	assert.Equal(t, "jira:JiraIssue:1:1", epic.RootIssueId)
+	assert.Equal(t, 0, epic.Depth)
+	assert.Equal(t, 2, epic.ChildCount)
+	assert.Equal(t, 4, epic.TotalIssues)
+	assert.Equal(t, 2, epic.DoneIssues)
+	assert.Equal(t, float64(4), epic.TotalStoryPoints)
+	assert.Equal(t, float64(3), epic.DoneStoryPoints)
+	assert.Equal(t, int64(60), epic.TotalOriginalEstimateMinutes)
+	assert.Equal(t, int64(75), epic.TotalTimeSpentMinutes)
+	assert.Equal(t, 75.0, epic.PercentComplete)
+
+	story := hierarchies["jira:JiraIssue:1:3"]
+	assert.Equal(t, "jira:JiraIssue:1:1", story.ParentIssueId)
+	assert.Equal(t, 1, story.Depth)
+	assert.Equal(t, 2, story.TotalIssues)
+	assert.Equal(t, int64(45), story.TotalTimeSpentMinutes)
+	assert.Equal(t, 0.0, story.PercentComplete)
+
+	subTask := hierarchies["jira:JiraIssue:1:4"]
+	assert.Equal(t, "jira:JiraIssue:1:1", subTask.RootIssueId)
+	assert.Equal(t, 2, subTask.Depth)
+	assert.Equal(t, 100.0, subTask.PercentComplete)
+
+	assert.Equal(t, "", hierarchies["jira:JiraIssue:2:1"].ParentIssueId)
+
+	a, b := hierarchies["webhook:1:A"], hierarchies["webhook:1:B"]
+	assert.Equal(t, "", a.ParentIssueId)
+	assert.Equal(t, 0, a.Depth)
+	assert.Equal(t, "webhook:1:A", b.ParentIssueId)
+	assert.Equal(t, "webhook:1:A", b.RootIssueId)
+	assert.Equal(t, 50.0, a.PercentComplete)
+
+	orphan := hierarchies["webhook:1:C"]
+	assert.Equal(t, "webhook:1:Z", orphan.ParentIssueId)
+	assert.Equal(t, "webhook:1:C", orphan.RootIssueId)
+	assert.Equal(t, 0, orphan.Depth)
+}
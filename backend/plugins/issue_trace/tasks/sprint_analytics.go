@@ -0,0 +1,308 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// membershipPeriod is a period of time an issue belonged to a sprint, an open period has no end
+type membershipPeriod struct {
+	from time.Time
+	to   *time.Time
+}
+
+// sprintIssueHistory is how an issue entered and left a sprint, along with the other sprints it was planned in
+type sprintIssueHistory struct {
+	issue     *ticket.Issue
+	periods   []membershipPeriod
+	sprintIds map[string]bool
+}
+
+// buildSprintIssueHistory replays the Sprint changelogs of the issue, which must be sorted by created_date. The
+// sprint fields of changelogs hold comma separated domain sprint ids. An issue with no changelog about the sprint
+// is considered a member since its creation when the sprint is among the given sprintIds from sprint_issues
+func buildSprintIssueHistory(issue *ticket.Issue, sprintId string, sprintIds []string, changelogs []ticket.IssueChangelogs) *sprintIssueHistory {
+	history := &sprintIssueHistory{
+		issue:     issue,
+		sprintIds: make(map[string]bool),
+	}
+	for _, id := range sprintIds {
+		history.sprintIds[id] = true
+	}
+	var since time.Time
+	if issue.CreatedDate != nil {
+		since = *issue.CreatedDate
+	}
+	member := history.sprintIds[sprintId]
+	mentioned := false
+	for i := range changelogs {
+		changelog := &changelogs[i]
+		for _, id := range splitSprintIds(changelog.OriginalFromValue + "," + changelog.OriginalToValue) {
+			history.sprintIds[id] = true
+			mentioned = mentioned || id == sprintId
+		}
+	}
+	if mentioned {
+		member = containsSprint(changelogs[0].OriginalFromValue, sprintId)
+		for i := range changelogs {
+			changelog := &changelogs[i]
+			in := containsSprint(changelog.OriginalToValue, sprintId)
+			if in && !member {
+				since = changelog.CreatedDate
+			} else if !in && member {
+				to := changelog.CreatedDate
+				history.periods = append(history.periods, membershipPeriod{from: since, to: &to})
+			}
+			member = in
+		}
+	}
+	if member {
+		history.periods = append(history.periods, membershipPeriod{from: since})
+	}
+	delete(history.sprintIds, sprintId)
+	return history
+}
+
+// memberAt tells if the issue belonged to the sprint at the given time
+func (h *sprintIssueHistory) memberAt(t time.Time) bool {
+	for _, p := range h.periods {
+		if !p.from.After(t) && (p.to == nil || p.to.After(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// addedDuring returns the first time the issue joined the sprint within (start, end)
+func (h *sprintIssueHistory) addedDuring(start, end time.Time) *time.Time {
+	for i := range h.periods {
+		p := &h.periods[i]
+		if p.from.After(start) && p.from.Before(end) {
+			return &p.from
+		}
+	}
+	return nil
+}
+
+// removedDuring returns the last time the issue left the sprint within (start, end]
+func (h *sprintIssueHistory) removedDuring(start, end time.Time) *time.Time {
+	var removed *time.Time
+	for i := range h.periods {
+		p := &h.periods[i]
+		if p.to != nil && p.to.After(start) && !p.to.After(end) {
+			removed = p.to
+		}
+	}
+	return removed
+}
+
+func (h *sprintIssueHistory) resolvedAt(t time.Time) bool {
+	return h.issue.ResolutionDate != nil && !h.issue.ResolutionDate.After(t)
+}
+
+func (h *sprintIssueHistory) storyPoint() float64 {
+	if h.issue.StoryPoint == nil {
+		return 0
+	}
+	return *h.issue.StoryPoint
+}
+
+// analyzeSprint computes the scope changes of the sprint between its start and end, the carry-over target of
+// an unfinished issue is the earliest sprint started after this one the issue was planned in
+func analyzeSprint(sprint *ticket.Sprint, end time.Time, histories []*sprintIssueHistory, sprints map[string]*ticket.Sprint) (*ticket.SprintAnalytics, []*ticket.SprintIssueAnalytics, []*ticket.SprintBurndown) {
+	start := *sprint.StartedDate
+	analytics := &ticket.SprintAnalytics{
+		SprintId:    sprint.Id,
+		StartedDate: sprint.StartedDate,
+		EndedDate:   &end,
+	}
+	var issues []*ticket.SprintIssueAnalytics
+	for _, h := range histories {
+		issueAnalytics := &ticket.SprintIssueAnalytics{
+			SprintId:    sprint.Id,
+			IssueId:     h.issue.Id,
+			StoryPoint:  h.storyPoint(),
+			IsCommitted: h.memberAt(start),
+		}
+		if !issueAnalytics.IsCommitted {
+			issueAnalytics.AddedDate = h.addedDuring(start, end)
+			issueAnalytics.IsAdded = issueAnalytics.AddedDate != nil
+		}
+		if !issueAnalytics.IsCommitted && !issueAnalytics.IsAdded {
+			// planned in the sprint but never during it
+			continue
+		}
+		if h.memberAt(end) {
+			issueAnalytics.IsCompleted = h.resolvedAt(end)
+			issueAnalytics.IsCarriedOver = !issueAnalytics.IsCompleted
+		} else {
+			issueAnalytics.RemovedDate = h.removedDuring(start, end)
+			issueAnalytics.IsRemoved = true
+		}
+		if issueAnalytics.IsCarriedOver {
+			issueAnalytics.CarriedOverSprintId = nextSprintId(sprint, h.sprintIds, sprints)
+		}
+		issues = append(issues, issueAnalytics)
+
+		storyPoint := issueAnalytics.StoryPoint
+		if issueAnalytics.IsCommitted {
+			analytics.CommittedIssues++
+			analytics.CommittedStoryPoints += storyPoint
+		}
+		if issueAnalytics.IsAdded {
+			analytics.AddedIssues++
+			analytics.AddedStoryPoints += storyPoint
+		}
+		if issueAnalytics.IsRemoved {
+			analytics.RemovedIssues++
+			analytics.RemovedStoryPoints += storyPoint
+		}
+		if issueAnalytics.IsCompleted {
+			analytics.CompletedIssues++
+			analytics.CompletedStoryPoints += storyPoint
+		}
+		if issueAnalytics.IsCarriedOver {
+			analytics.CarriedOverIssues++
+			analytics.CarriedOverStoryPoints += storyPoint
+		}
+	}
+	return analytics, issues, buildBurndown(sprint.Id, start, end, histories)
+}
+
+// buildBurndown takes a snapshot of the sprint at the end of every day from its start to its end
+func buildBurndown(sprintId string, start, end time.Time, histories []*sprintIssueHistory) []*ticket.SprintBurndown {
+	var burndowns []*ticket.SprintBurndown
+	for day := start.UTC().Truncate(24 * time.Hour); day.Before(end); day = day.Add(24 * time.Hour) {
+		t := day.Add(24 * time.Hour)
+		if t.After(end) {
+			t = end
+		}
+		burndown := &ticket.SprintBurndown{
+			SprintId: sprintId,
+			Date:     day,
+		}
+		for _, h := range histories {
+			if !h.memberAt(t) {
+				continue
+			}
+			storyPoint := h.storyPoint()
+			burndown.ScopeIssues++
+			burndown.ScopeStoryPoints += storyPoint
+			if h.resolvedAt(t) {
+				burndown.CompletedStoryPoints += storyPoint
+			} else {
+				burndown.RemainingIssues++
+				burndown.RemainingStoryPoints += storyPoint
+			}
+		}
+		burndowns = append(burndowns, burndown)
+	}
+	return burndowns
+}
+
+func nextSprintId(sprint *ticket.Sprint, sprintIds map[string]bool, sprints map[string]*ticket.Sprint) string {
+	var candidates []*ticket.Sprint
+	for id := range sprintIds {
+		if s, ok := sprints[id]; ok && s.StartedDate != nil && s.StartedDate.After(*sprint.StartedDate) {
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].StartedDate.Before(*candidates[j].StartedDate)
+	})
+	return candidates[0].Id
+}
+
+// projectSprintIssues groups the project issues by the sprints they are in now or were moved in or out of
+type projectSprintIssues struct {
+	issues     map[string]*ticket.Issue
+	changelogs map[string][]ticket.IssueChangelogs
+	sprintIds  map[string][]string
+	bySprint   map[string][]string
+}
+
+// newProjectSprintIssues indexes the issues sorted by id, changelogs must be sorted by created_date
+func newProjectSprintIssues(issues []*ticket.Issue, changelogs []ticket.IssueChangelogs, sprintIssues []ticket.SprintIssue) *projectSprintIssues {
+	p := &projectSprintIssues{
+		issues:     make(map[string]*ticket.Issue, len(issues)),
+		changelogs: make(map[string][]ticket.IssueChangelogs),
+		sprintIds:  make(map[string][]string),
+		bySprint:   make(map[string][]string),
+	}
+	for _, issue := range issues {
+		p.issues[issue.Id] = issue
+	}
+	for _, changelog := range changelogs {
+		p.changelogs[changelog.IssueId] = append(p.changelogs[changelog.IssueId], changelog)
+	}
+	for _, sprintIssue := range sprintIssues {
+		p.sprintIds[sprintIssue.IssueId] = append(p.sprintIds[sprintIssue.IssueId], sprintIssue.SprintId)
+	}
+	for _, issue := range issues {
+		mentioned := make(map[string]bool)
+		for _, sprintId := range p.sprintIds[issue.Id] {
+			mentioned[sprintId] = true
+		}
+		for _, changelog := range p.changelogs[issue.Id] {
+			for _, sprintId := range splitSprintIds(changelog.OriginalFromValue + "," + changelog.OriginalToValue) {
+				mentioned[sprintId] = true
+			}
+		}
+		for sprintId := range mentioned {
+			p.bySprint[sprintId] = append(p.bySprint[sprintId], issue.Id)
+		}
+	}
+	return p
+}
+
+// histories builds the histories of the issues in the sprint now or moved in or out of it, sorted by the issue id
+func (p *projectSprintIssues) histories(sprintId string) []*sprintIssueHistory {
+	issueIds := p.bySprint[sprintId]
+	histories := make([]*sprintIssueHistory, 0, len(issueIds))
+	for _, issueId := range issueIds {
+		histories = append(histories, buildSprintIssueHistory(p.issues[issueId], sprintId, p.sprintIds[issueId], p.changelogs[issueId]))
+	}
+	return histories
+}
+
+func containsSprint(sprintIds string, sprintId string) bool {
+	for _, id := range splitSprintIds(sprintIds) {
+		if id == sprintId {
+			return true
+		}
+	}
+	return false
+}
+
+func splitSprintIds(sprintIds string) []string {
+	var ids []string
+	for _, id := range strings.Split(sprintIds, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = CalculateSprintAnalytics
+
+var CalculateSprintAnalyticsMeta = plugin.SubTaskMeta{
+	Name:             "calculateSprintAnalytics",
+	EntryPoint:       CalculateSprintAnalytics,
+	EnabledByDefault: true,
+	Description:      "Calculate committed, added, removed, completed and carried-over scope and the burndown of sprints from sprint changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// CalculateSprintAnalytics replays the Sprint changelogs of the issues in each started sprint of the project
+func CalculateSprintAnalytics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+
+	var projectSprints []*ticket.Sprint
+	err := db.All(&projectSprints, projectSprintsClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	sprints := make(map[string]*ticket.Sprint, len(projectSprints))
+	for _, sprint := range projectSprints {
+		sprints[sprint.Id] = sprint
+	}
+
+	sprintIssues, err := loadProjectSprintIssues(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(projectSprintsClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	now := time.Now()
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: IssueTraceApiParams{
+				ProjectName: data.Options.ProjectName,
+			},
+			Table: "sprints",
+		},
+		InputRowType: reflect.TypeOf(ticket.Sprint{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			sprint := inputRow.(*ticket.Sprint)
+			if sprint.StartedDate == nil {
+				return nil, nil
+			}
+			end := now
+			if sprint.CompletedDate != nil {
+				end = *sprint.CompletedDate
+			} else if sprint.EndedDate != nil && sprint.EndedDate.Before(now) {
+				end = *sprint.EndedDate
+			}
+			analytics, issues, burndowns := analyzeSprint(sprint, end, sprintIssues.histories(sprint.Id), sprints)
+			results := make([]interface{}, 0, 1+len(issues)+len(burndowns))
+			results = append(results, analytics)
+			for _, issue := range issues {
+				results = append(results, issue)
+			}
+			for _, burndown := range burndowns {
+				results = append(results, burndown)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func projectSprintsClauses(projectName string) []dal.Clause {
+	return []dal.Clause{
+		dal.Select("s.*"),
+		dal.From(`sprints s`),
+		dal.Join(`left join board_sprints bs on bs.sprint_id = s.id`),
+		dal.Join(`left join project_mapping pm on pm.row_id = bs.board_id`),
+		dal.Where("pm.project_name = ? and pm.table = ?", projectName, "boards"),
+	}
+}
+
+// loadProjectSprintIssues loads the project issues which are in a sprint now or were moved in or out of one, along
+// with their Sprint changelogs, once for all the sprints of the project
+func loadProjectSprintIssues(db dal.Dal, projectName string) (*projectSprintIssues, errors.Error) {
+	var issues []*ticket.Issue
+	err := db.All(
+		&issues,
+		dal.Where(
+			"id IN ("+projectIssueIdsQuery+") AND (id IN (SELECT issue_id FROM sprint_issues) OR id IN (SELECT issue_id FROM issue_changelogs WHERE field_name = ?))",
+			projectName, "boards", "Sprint",
+		),
+		dal.Orderby("id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var changelogs []ticket.IssueChangelogs
+	err = db.All(
+		&changelogs,
+		dal.Where("field_name = ? AND issue_id IN ("+projectIssueIdsQuery+")", "Sprint", projectName, "boards"),
+		dal.Orderby("created_date ASC, id ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var sprintIssues []ticket.SprintIssue
+	err = db.All(&sprintIssues, dal.Where("issue_id IN ("+projectIssueIdsQuery+")", projectName, "boards"))
+	if err != nil {
+		return nil, err
+	}
+	return newProjectSprintIssues(issues, changelogs, sprintIssues), nil
+}
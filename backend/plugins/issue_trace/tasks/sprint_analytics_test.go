@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeSprint(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, d)
+	}
+	ptr := func(t time.Time) *time.Time {
+		return &t
+	}
+	sp := func(v float64) *float64 {
+		return &v
+	}
+	newIssue := func(id string, storyPoint float64, resolved *time.Time) *ticket.Issue {
+		return &ticket.Issue{
+			DomainEntity:   domainlayer.DomainEntity{Id: id},
+			CreatedDate:    ptr(day(-10)),
+			StoryPoint:     sp(storyPoint),
+			ResolutionDate: resolved,
+		}
+	}
+	s1 := &ticket.Sprint{DomainEntity: domainlayer.DomainEntity{Id: "s1"}, StartedDate: ptr(day(0)), CompletedDate: ptr(day(4))}
+	s2 := &ticket.Sprint{DomainEntity: domainlayer.DomainEntity{Id: "s2"}, StartedDate: ptr(day(4))}
+	sprints := map[string]*ticket.Sprint{"s1": s1, "s2": s2}
+	moved := func(from, to string, date time.Time) ticket.IssueChangelogs {
+		return ticket.IssueChangelogs{OriginalFromValue: from, OriginalToValue: to, CreatedDate: date}
+	}
+
+	histories := []*sprintIssueHistory{
+		// in the sprint since its creation, done during the sprint
+		buildSprintIssueHistory(newIssue("a", 3, ptr(day(2).Add(12*time.Hour))), "s1", []string{"s1"}, nil),
+		// committed and carried over to the next sprint when the sprint was completed
+		buildSprintIssueHistory(newIssue("b", 5, nil), "s1", []string{"s1", "s2"}, []ticket.IssueChangelogs{
+			moved("", "s1", day(-3)),
+			moved("s1", "s1,s2", day(4)),
+		}),
+		// added during the sprint and done
+		buildSprintIssueHistory(newIssue("c", 2, ptr(day(3))), "s1", []string{"s1"}, []ticket.IssueChangelogs{
+			moved("", "s1", day(1)),
+		}),
+		// committed and then removed
+		buildSprintIssueHistory(newIssue("d", 1, nil), "s1", nil, []ticket.IssueChangelogs{
+			moved("", "s1", day(-2)),
+			moved("s1", "", day(2)),
+		}),
+		// planned in the sprint after it was completed
+		buildSprintIssueHistory(newIssue("e", 8, nil), "s1", []string{"s1"}, []ticket.IssueChangelogs{
+			moved("", "s1", day(5)),
+		}),
+	}
+
+	analytics, issues, burndowns := analyzeSprint(s1, day(4), histories, sprints)
+	assert.Equal(t, 3, analytics.CommittedIssues)
+	assert.Equal(t, 9.0, analytics.CommittedStoryPoints)
+	assert.Equal(t, 1, analytics.AddedIssues)
+	assert.Equal(t, 2.0, analytics.AddedStoryPoints)
+	assert.Equal(t, 1, analytics.RemovedIssues)
+	assert.Equal(t, 1.0, analytics.RemovedStoryPoints)
+	assert.Equal(t, 2, analytics.CompletedIssues)
+	assert.Equal(t, 5.0, analytics.CompletedStoryPoints)
+	assert.Equal(t, 1, analytics.CarriedOverIssues)
+	assert.Equal(t, 5.0, analytics.CarriedOverStoryPoints)
+
+	if assert.Len(t, issues, 4) {
+		assert.Equal(t, "s2", issues[1].CarriedOverSprintId)
+		assert.Equal(t, day(1), *issues[2].AddedDate)
+		assert.Equal(t, day(2), *issues[3].RemovedDate)
+	}
+
+	if assert.Len(t, burndowns, 4) {
+		expected := []struct {
+			scopeIssues          int
+			scopeStoryPoints     float64
+			remainingStoryPoints float64
+		}{
+			{4, 11, 11},
+			{3, 10, 10},
+			{3, 10, 5},
+			{3, 10, 5},
+		}
+		for i, e := range expected {
+			assert.Equal(t, day(i), burndowns[i].Date)
+			assert.Equal(t, e.scopeIssues, burndowns[i].ScopeIssues)
+			assert.Equal(t, e.scopeStoryPoints, burndowns[i].ScopeStoryPoints)
+			assert.Equal(t, e.remainingStoryPoints, burndowns[i].RemainingStoryPoints)
+		}
+	}
+}
+
+func TestProjectSprintIssues(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*ticket.Issue{
+		{DomainEntity: domainlayer.DomainEntity{Id: "a"}, CreatedDate: &day},
+		{DomainEntity: domainlayer.DomainEntity{Id: "b"}, CreatedDate: &day},
+		{DomainEntity: domainlayer.DomainEntity{Id: "c"}, CreatedDate: &day},
+	}
+	changelogs := []ticket.IssueChangelogs{
+		{IssueId: "b", OriginalFromValue: "s1", OriginalToValue: "s1,s2", CreatedDate: day.AddDate(0, 0, 1)},
+		{IssueId: "c", OriginalFromValue: "", OriginalToValue: "s1", CreatedDate: day.AddDate(0, 0, 1)},
+		{IssueId: "c", OriginalFromValue: "s1", OriginalToValue: "", CreatedDate: day.AddDate(0, 0, 2)},
+		// changelogs of the issues out of the project are ignored
+		{IssueId: "x", OriginalFromValue: "", OriginalToValue: "s1", CreatedDate: day},
+	}
+	sprintIssues := []ticket.SprintIssue{
+		{SprintId: "s1", IssueId: "a"},
+		{SprintId: "s1", IssueId: "b"},
+		{SprintId: "s2", IssueId: "b"},
+	}
+	p := newProjectSprintIssues(issues, changelogs, sprintIssues)
+
+	s1 := p.histories("s1")
+	assert.Len(t, s1, 3)
+	assert.Equal(t, "a", s1[0].issue.Id)
+	assert.Equal(t, "b", s1[1].issue.Id)
+	assert.Equal(t, "c", s1[2].issue.Id)
+	// moved out of the sprint
+	assert.Len(t, s1[2].periods, 1)
+	assert.NotNil(t, s1[2].periods[0].to)
+
+	s2 := p.histories("s2")
+	assert.Len(t, s2, 1)
+	assert.Equal(t, "b", s2[0].issue.Id)
+	assert.True(t, s2[0].sprintIds["s1"])
+	assert.Empty(t, p.histories("s3"))
+}
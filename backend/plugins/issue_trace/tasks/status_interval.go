@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// buildStatusIntervals splits the lifetime of the issue into the periods it stayed in each status. Both
+// changelog lists must be sorted by created_date. The first interval starts at the creation of the issue with
+// the from value of the first status changelog, or with the current status if the status never changed. The
+// last interval is left open
+func buildStatusIntervals(issue *ticket.Issue, statusChangelogs, assigneeChangelogs []ticket.IssueChangelogs) []*ticket.IssueStatusInterval {
+	start := issue.CreatedDate
+	if start == nil && len(statusChangelogs) > 0 {
+		start = &statusChangelogs[0].CreatedDate
+	}
+	if start == nil {
+		return nil
+	}
+	assignees := newAssigneeTimeline(issue, assigneeChangelogs)
+	current := &ticket.IssueStatusInterval{
+		IssueId:        issue.Id,
+		StartDate:      *start,
+		OriginalStatus: issue.OriginalStatus,
+		Status:         issue.Status,
+	}
+	if len(statusChangelogs) > 0 {
+		current.OriginalStatus = statusChangelogs[0].OriginalFromValue
+		current.Status = statusChangelogs[0].FromValue
+	}
+	current.AssigneeId = assignees.at(current.StartDate)
+	var intervals []*ticket.IssueStatusInterval
+	for i := range statusChangelogs {
+		changelog := &statusChangelogs[i]
+		if changelog.OriginalToValue == current.OriginalStatus && changelog.ToValue == current.Status {
+			continue
+		}
+		date := changelog.CreatedDate
+		if date.Before(current.StartDate) {
+			date = current.StartDate
+		}
+		// changes happened at the same moment replace the status instead of producing empty intervals
+		if date.Equal(current.StartDate) {
+			current.OriginalStatus = changelog.OriginalToValue
+			current.Status = changelog.ToValue
+			if n := len(intervals); n > 0 && intervals[n-1].OriginalStatus == current.OriginalStatus && intervals[n-1].Status == current.Status {
+				current = intervals[n-1]
+				current.EndDate = nil
+				current.DurationMinutes = nil
+				intervals = intervals[:n-1]
+			}
+			continue
+		}
+		end := date
+		current.EndDate = &end
+		current.DurationMinutes = minutesBetween(current.StartDate, end)
+		intervals = append(intervals, current)
+		current = &ticket.IssueStatusInterval{
+			IssueId:        issue.Id,
+			StartDate:      date,
+			OriginalStatus: changelog.OriginalToValue,
+			Status:         changelog.ToValue,
+			AssigneeId:     assignees.at(date),
+		}
+	}
+	current.IsCurrentStatus = true
+	return append(intervals, current)
+}
+
+// calculateFlowMetric derives the flow metrics from the status intervals of an issue
+func calculateFlowMetric(issueId string, intervals []*ticket.IssueStatusInterval) *ticket.IssueFlowMetric {
+	metric := &ticket.IssueFlowMetric{
+		IssueId: issueId,
+	}
+	startedIndex, doneIndex := -1, -1
+	for i, interval := range intervals {
+		if i > 0 && intervals[i-1].Status == ticket.DONE && interval.Status != ticket.DONE {
+			metric.ReopenCount++
+		}
+		if startedIndex < 0 && interval.Status == ticket.IN_PROGRESS {
+			startedIndex = i
+		}
+	}
+	// the issue is done once it enters the final run of DONE intervals
+	for i := len(intervals) - 1; i >= 0 && intervals[i].Status == ticket.DONE; i-- {
+		doneIndex = i
+	}
+	if startedIndex >= 0 {
+		metric.StartedDate = &intervals[startedIndex].StartDate
+	}
+	if doneIndex >= 0 {
+		metric.DoneDate = &intervals[doneIndex].StartDate
+	}
+	if startedIndex < 0 || doneIndex < startedIndex {
+		return metric
+	}
+	var active time.Duration
+	for _, interval := range intervals[startedIndex:doneIndex] {
+		if interval.Status == ticket.IN_PROGRESS {
+			active += interval.EndDate.Sub(interval.StartDate)
+		}
+	}
+	cycle := metric.DoneDate.Sub(*metric.StartedDate)
+	metric.CycleTimeMinutes = toMinutes(cycle)
+	metric.ActiveTimeMinutes = toMinutes(active)
+	metric.WaitTimeMinutes = toMinutes(cycle - active)
+	if cycle > 0 {
+		flowEfficiency := float64(active) / float64(cycle)
+		metric.FlowEfficiency = &flowEfficiency
+	}
+	return metric
+}
+
+// assigneeTimeline answers who was assigned to the issue at a given time
+type assigneeTimeline struct {
+	initial    string
+	changelogs []ticket.IssueChangelogs
+}
+
+func newAssigneeTimeline(issue *ticket.Issue, changelogs []ticket.IssueChangelogs) *assigneeTimeline {
+	timeline := &assigneeTimeline{
+		initial:    issue.AssigneeId,
+		changelogs: changelogs,
+	}
+	if len(changelogs) > 0 {
+		timeline.initial = changelogs[0].OriginalFromValue
+	}
+	return timeline
+}
+
+func (t *assigneeTimeline) at(date time.Time) string {
+	assignee := t.initial
+	for i := range t.changelogs {
+		if t.changelogs[i].CreatedDate.After(date) {
+			break
+		}
+		assignee = t.changelogs[i].OriginalToValue
+	}
+	return assignee
+}
+
+func minutesBetween(start, end time.Time) *uint {
+	return toMinutes(end.Sub(start))
+}
+
+func toMinutes(d time.Duration) *uint {
+	if d < 0 {
+		return nil
+	}
+	minutes := uint(d.Minutes())
+	return &minutes
+}
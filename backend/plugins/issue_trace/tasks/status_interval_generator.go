@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = GenerateIssueStatusIntervals
+
+var GenerateIssueStatusIntervalsMeta = plugin.SubTaskMeta{
+	Name:             "generateIssueStatusIntervals",
+	EntryPoint:       GenerateIssueStatusIntervals,
+	EnabledByDefault: true,
+	Description:      "Generate the status intervals of issues from their status and assignee changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// GenerateIssueStatusIntervals replays the status changelogs of the issues in the project
+func GenerateIssueStatusIntervals(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+	cursor, err := db.Cursor(append(projectIssuesClauses(data.Options.ProjectName), dal.Orderby("i.id ASC"))...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	changelogs, err := newIssueRowsReader(
+		db,
+		func(changelog *ticket.IssueChangelogs) string { return changelog.IssueId },
+		dal.From(&ticket.IssueChangelogs{}),
+		dal.Where(
+			"field_name IN ? AND issue_id IN ("+projectIssueIdsQuery+")",
+			[]string{"status", "assignee"}, data.Options.ProjectName, "boards",
+		),
+		dal.Orderby("issue_id ASC, created_date ASC, id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer changelogs.close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: IssueTraceApiParams{
+				ProjectName: data.Options.ProjectName,
+			},
+			Table: "issues",
+		},
+		InputRowType: reflect.TypeOf(ticket.Issue{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			issue := inputRow.(*ticket.Issue)
+			issueChangelogs, err := changelogs.read(issue.Id)
+			if err != nil {
+				return nil, err
+			}
+			var statusChangelogs, assigneeChangelogs []ticket.IssueChangelogs
+			for _, changelog := range issueChangelogs {
+				switch changelog.FieldName {
+				case "status":
+					statusChangelogs = append(statusChangelogs, *changelog)
+				case "assignee":
+					assigneeChangelogs = append(assigneeChangelogs, *changelog)
+				}
+			}
+			intervals := buildStatusIntervals(issue, statusChangelogs, assigneeChangelogs)
+			results := make([]interface{}, 0, len(intervals))
+			for _, interval := range intervals {
+				results = append(results, interval)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+func projectIssuesClauses(projectName string) []dal.Clause {
+	return []dal.Clause{
+		dal.Select("i.*"),
+		dal.From(`issues i`),
+		dal.Join(`left join board_issues bi on bi.issue_id = i.id`),
+		dal.Join(`left join project_mapping pm on pm.row_id = bi.board_id`),
+		dal.Where("pm.project_name = ? and pm.table = ?", projectName, "boards"),
+	}
+}
+
+// projectIssueIdsQuery selects the ids of the issues returned by projectIssuesClauses, params are the project name
+// and "boards"
+const projectIssueIdsQuery = `SELECT bi.issue_id FROM board_issues bi
+	JOIN project_mapping pm ON pm.row_id = bi.board_id
+	WHERE pm.project_name = ? AND pm.table = ?`
+
+// issueRowsReader reads the rows of the project issues, e.g. changelogs, from a single cursor ordered by issue id
+// instead of querying them issue by issue, issues must be read in the same order
+type issueRowsReader[T any] struct {
+	db      dal.Dal
+	cursor  dal.Rows
+	issueId func(*T) string
+	next    *T
+	lastId  string
+	rows    []*T
+}
+
+func newIssueRowsReader[T any](db dal.Dal, issueId func(*T) string, clauses ...dal.Clause) (*issueRowsReader[T], errors.Error) {
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return &issueRowsReader[T]{db: db, cursor: cursor, issueId: issueId}, nil
+}
+
+// read returns the rows of the issue, an issue might be read repeatedly when it is on multiple boards of the project
+func (r *issueRowsReader[T]) read(issueId string) ([]*T, errors.Error) {
+	if issueId == r.lastId {
+		return r.rows, nil
+	}
+	r.lastId = issueId
+	r.rows = nil
+	for {
+		if r.next == nil {
+			if !r.cursor.Next() {
+				return r.rows, nil
+			}
+			r.next = new(T)
+			err := r.db.Fetch(r.cursor, r.next)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rowIssueId := r.issueId(r.next)
+		// rows of the issues missing from the outer cursor, i.e. orphan changelogs, are skipped
+		if rowIssueId < issueId {
+			r.next = nil
+			continue
+		}
+		// rows of the next issue
+		if rowIssueId != issueId {
+			return r.rows, nil
+		}
+		r.rows = append(r.rows, r.next)
+		r.next = nil
+	}
+}
+
+func (r *issueRowsReader[T]) close() {
+	r.cursor.Close()
+}
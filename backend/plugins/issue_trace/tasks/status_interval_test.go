@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildStatusIntervals(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return created.Add(time.Duration(hours) * time.Hour)
+	}
+	issue := &ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "issue1"},
+		CreatedDate:    &created,
+		OriginalStatus: "Closed",
+		Status:         ticket.DONE,
+		AssigneeId:     "user2",
+	}
+	statusChangelogs := []ticket.IssueChangelogs{
+		{OriginalFromValue: "Open", OriginalToValue: "Doing", FromValue: ticket.TODO, ToValue: ticket.IN_PROGRESS, CreatedDate: at(2)},
+		{OriginalFromValue: "Doing", OriginalToValue: "Blocked", FromValue: ticket.IN_PROGRESS, ToValue: ticket.TODO, CreatedDate: at(5)},
+		{OriginalFromValue: "Blocked", OriginalToValue: "Doing", FromValue: ticket.TODO, ToValue: ticket.IN_PROGRESS, CreatedDate: at(6)},
+		{OriginalFromValue: "Doing", OriginalToValue: "Closed", FromValue: ticket.IN_PROGRESS, ToValue: ticket.DONE, CreatedDate: at(8)},
+		// reopened and closed at once, which must neither produce an empty interval nor split the last one
+		{OriginalFromValue: "Closed", OriginalToValue: "Doing", FromValue: ticket.DONE, ToValue: ticket.IN_PROGRESS, CreatedDate: at(10)},
+		{OriginalFromValue: "Doing", OriginalToValue: "Closed", FromValue: ticket.IN_PROGRESS, ToValue: ticket.DONE, CreatedDate: at(10)},
+	}
+	assigneeChangelogs := []ticket.IssueChangelogs{
+		{OriginalFromValue: "user1", OriginalToValue: "user2", CreatedDate: at(5)},
+	}
+
+	intervals := buildStatusIntervals(issue, statusChangelogs, assigneeChangelogs)
+	if !assert.Len(t, intervals, 5) {
+		return
+	}
+	expected := []struct {
+		status   string
+		start    time.Time
+		assignee string
+		duration uint
+	}{
+		{"Open", at(0), "user1", 120},
+		{"Doing", at(2), "user1", 180},
+		{"Blocked", at(5), "user2", 60},
+		{"Doing", at(6), "user2", 120},
+		{"Closed", at(8), "user2", 0},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.status, intervals[i].OriginalStatus)
+		assert.Equal(t, e.start, intervals[i].StartDate)
+		assert.Equal(t, e.assignee, intervals[i].AssigneeId)
+		if i < len(expected)-1 {
+			assert.Equal(t, e.duration, *intervals[i].DurationMinutes)
+			assert.False(t, intervals[i].IsCurrentStatus)
+		}
+	}
+	assert.Nil(t, intervals[4].EndDate)
+	assert.True(t, intervals[4].IsCurrentStatus)
+
+	metric := calculateFlowMetric(issue.Id, intervals)
+	assert.Equal(t, at(2), *metric.StartedDate)
+	assert.Equal(t, at(8), *metric.DoneDate)
+	assert.Equal(t, uint(360), *metric.CycleTimeMinutes)
+	assert.Equal(t, uint(300), *metric.ActiveTimeMinutes)
+	assert.Equal(t, uint(60), *metric.WaitTimeMinutes)
+	assert.InDelta(t, 5.0/6, *metric.FlowEfficiency, 0.0001)
+	assert.Equal(t, 0, metric.ReopenCount)
+}
+
+func TestCalculateFlowMetricWithoutChangelogs(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issue := &ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "issue2"},
+		CreatedDate:    &created,
+		OriginalStatus: "Open",
+		Status:         ticket.TODO,
+	}
+	intervals := buildStatusIntervals(issue, nil, nil)
+	if !assert.Len(t, intervals, 1) {
+		return
+	}
+	assert.Equal(t, ticket.TODO, intervals[0].Status)
+	assert.True(t, intervals[0].IsCurrentStatus)
+
+	metric := calculateFlowMetric(issue.Id, intervals)
+	assert.Nil(t, metric.StartedDate)
+	assert.Nil(t, metric.CycleTimeMinutes)
+	assert.Nil(t, metric.FlowEfficiency)
+}
+
+func TestIssueRowsReader(t *testing.T) {
+	changelogs := []*ticket.IssueChangelogs{
+		{DomainEntity: domainlayer.DomainEntity{Id: "1"}, IssueId: "a", FieldName: "status"},
+		{DomainEntity: domainlayer.DomainEntity{Id: "2"}, IssueId: "a", FieldName: "assignee"},
+		// the issue is missing from the outer cursor
+		{DomainEntity: domainlayer.DomainEntity{Id: "3"}, IssueId: "b", FieldName: "status"},
+		{DomainEntity: domainlayer.DomainEntity{Id: "4"}, IssueId: "c", FieldName: "status"},
+	}
+	mockRows := new(mockdal.Rows)
+	mockRows.On("Next").Return(true).Times(len(changelogs))
+	mockRows.On("Next").Return(false)
+	mockRows.On("Close").Return(nil).Once()
+
+	fetched := 0
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Cursor", mock.Anything).Return(mockRows, nil).Once()
+	mockDal.On("Fetch", mockRows, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*ticket.IssueChangelogs) = *changelogs[fetched]
+		fetched++
+	}).Return(nil).Times(len(changelogs))
+
+	reader, err := newIssueRowsReader(
+		mockDal,
+		func(changelog *ticket.IssueChangelogs) string { return changelog.IssueId },
+		dal.From(&ticket.IssueChangelogs{}),
+	)
+	assert.Nil(t, err)
+	rows, err := reader.read("a")
+	assert.Nil(t, err)
+	assert.Equal(t, changelogs[:2], rows)
+	// the issue is on another board of the project
+	rows, err = reader.read("a")
+	assert.Nil(t, err)
+	assert.Equal(t, changelogs[:2], rows)
+	// the orphan changelog of the issue "b" is skipped
+	rows, err = reader.read("c")
+	assert.Nil(t, err)
+	assert.Equal(t, changelogs[3:], rows)
+	rows, err = reader.read("d")
+	assert.Nil(t, err)
+	assert.Empty(t, rows)
+	reader.close()
+
+	mockRows.AssertExpectations(t)
+	mockDal.AssertExpectations(t)
+}
@@ -53,7 +53,8 @@ func listJenkinsRemoteScopes(
 		parentId = &groupId
 	}
 	getJobsPageCallBack := func(job *models.Job) errors.Error {
-		if job.Jobs != nil {
+		// multibranch pipelines and organization folders are scopes, their branch jobs are discovered while collecting
+		if job.Jobs != nil && !models.IsMultibranchClass(job.Class) {
 			// this is a group
 			job.Path = groupId
 			children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.JenkinsJob]{
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsMultibranchDataFlow(t *testing.T) {
+
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `incubator-devlake`,
+			JobFullName:  `devlake-org/incubator-devlake`,
+			JobPath:      `job/devlake-org/`,
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+		Multibranch:   true,
+	}
+	// another multibranch pipeline in the same organization folder
+	otherTaskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `other-repo`,
+			JobFullName:  `devlake-org/other-repo`,
+			JobPath:      `job/devlake-org/`,
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+		Multibranch:   true,
+	}
+
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.FlushTabler(&models.JenkinsBuildCommit{})
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_multibranch_builds.csv", "_raw_jenkins_api_builds")
+
+	// verify builds are extracted into the branch jobs they were collected from
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, otherTaskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsBuild{},
+		"./snapshot_tables/_tool_jenkins_multibranch_builds.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"full_name",
+			"job_name",
+			"job_path",
+			"number",
+			"result",
+			"class",
+			"start_time",
+			"branch_name",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.JenkinsBuildCommit{},
+		"./snapshot_tables/_tool_jenkins_multibranch_build_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"build_name",
+			"commit_sha",
+			"branch",
+			"repo_url",
+		),
+	)
+
+	// verify builds of all branches are mapped to the multibranch pipeline
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertBuildReposMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertBuildsToCicdTasksMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_multibranch_pipelines.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"result",
+			"status",
+			"duration_sec",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_multibranch_pipeline_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"pipeline_id",
+			"commit_sha",
+			"repo_url",
+			"branch",
+		),
+	)
+}
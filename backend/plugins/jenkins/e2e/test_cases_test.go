@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsTestCasesDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `devlake`,
+			JobFullName:  `Test-jenkins-dir/test-jenkins-sub-dir/test-sub-sub-dir/devlake`,
+			JobPath:      `job/Test-jenkins-dir/job/test-jenkins-sub-dir/job/test-sub-sub-dir/`,
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_test_reports.csv", "_raw_jenkins_api_test_reports")
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_jenkins_builds_for_stages.csv", models.JenkinsBuild{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.JenkinsTestCase{})
+	dataflowTester.Subtask(tasks.ExtractApiTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.JenkinsTestCase{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_jenkins_test_cases.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CicdTestSuite{})
+	dataflowTester.FlushTabler(&devops.CicdTestCase{})
+	dataflowTester.FlushTabler(&devops.CicdTestCaseResult{})
+	dataflowTester.Subtask(tasks.ConvertTestCasesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestSuite{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_suites.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestCase{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_cases.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		devops.CicdTestCaseResult{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/cicd_test_case_results.csv",
+			IgnoreTypes: []interface{}{common.NoPKModel{}},
+		},
+	)
+}
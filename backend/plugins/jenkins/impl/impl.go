@@ -70,12 +70,14 @@ func (p Jenkins) ScopeConfig() dal.Tabler {
 
 func (p Jenkins) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
+		&models.JenkinsBranchJob{},
 		&models.JenkinsBuild{},
 		&models.JenkinsBuildCommit{},
 		&models.JenkinsConnection{},
 		&models.JenkinsJob{},
 		&models.JenkinsJobDag{},
 		&models.JenkinsStage{},
+		&models.JenkinsTestCase{},
 		&models.JenkinsScopeConfig{},
 	}
 }
@@ -91,13 +93,17 @@ func (p Jenkins) Name() string {
 func (p Jenkins) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConvertJobsMeta,
+		tasks.CollectBranchJobsMeta,
 		tasks.CollectApiBuildsMeta,
 		tasks.ExtractApiBuildsMeta,
 		tasks.CollectApiStagesMeta,
 		tasks.ExtractApiStagesMeta,
+		tasks.CollectApiTestReportsMeta,
+		tasks.ExtractApiTestReportsMeta,
 		tasks.EnrichApiBuildWithStagesMeta,
 		tasks.ConvertBuildsToCicdTasksMeta,
 		tasks.ConvertStagesMeta,
+		tasks.ConvertTestCasesMeta,
 		tasks.ConvertBuildReposMeta,
 	}
 }
@@ -141,11 +147,18 @@ func (p Jenkins) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]
 	if err := regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	db := taskCtx.GetDal()
+	jenkinsJob := &models.JenkinsJob{}
+	err = db.First(jenkinsJob, dal.Where(`connection_id = ? and full_name = ?`, op.ConnectionId, op.JobFullName))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, err
+	}
 	taskData := &tasks.JenkinsTaskData{
 		Options:       op,
 		ApiClient:     apiClient,
 		Connection:    connection,
 		RegexEnricher: regexEnricher,
+		Multibranch:   models.IsMultibranchClass(jenkinsJob.Class),
 	}
 
 	return taskData, nil
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	MULTIBRANCH_PROJECT_CLASS = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	ORGANIZATION_FOLDER_CLASS = "jenkins.branch.OrganizationFolder"
+)
+
+// IsMultibranchClass tells whether the job of the class discovers its branch and pull request jobs by itself,
+// such as a multibranch pipeline or a GitHub/Bitbucket organization folder
+func IsMultibranchClass(class string) bool {
+	return class == MULTIBRANCH_PROJECT_CLASS || class == ORGANIZATION_FOLDER_CLASS
+}
+
+// JenkinsBranchJob is a branch or pull request job discovered under a multibranch pipeline or an organization folder
+type JenkinsBranchJob struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	FullName      string `gorm:"primaryKey;type:varchar(255)"` // "org/repo/feature%2Flogin"
+	ScopeFullName string `gorm:"index;type:varchar(255)"`      // full name of the multibranch pipeline or the organization folder
+	Name          string `gorm:"type:varchar(255)"`            // "feature%2Flogin"
+	Path          string `gorm:"type:varchar(511)"`            // "job/org/job/repo/"
+	Class         string `gorm:"type:varchar(255)"`
+	Url           string
+	BranchName    string `gorm:"type:varchar(255)"` // "feature/login" or "PR-12"
+	IsPullRequest bool
+	// Deleted is true when the branch or pull request is no longer discovered by jenkins, the builds collected before
+	// are kept
+	Deleted bool
+	common.NoPKModel
+}
+
+func (JenkinsBranchJob) TableName() string {
+	return "_tool_jenkins_branch_jobs"
+}
@@ -41,6 +41,7 @@ type JenkinsBuild struct {
 	TriggeredBy       string    `gorm:"type:varchar(255)"`
 	Building          bool
 	HasStages         bool
+	BranchName        string `gorm:"type:varchar(255)"` // branch or pull request of the multibranch pipeline
 }
 
 func (JenkinsBuild) TableName() string {
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addTestCases)(nil)
+
+type jenkinsTestCase20240218 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildName    string `gorm:"primaryKey;type:varchar(255)"`
+	Id           string `gorm:"primaryKey;type:varchar(32)"`
+	SuiteName    string `gorm:"type:varchar(255)"`
+	ClassName    string `gorm:"type:varchar(500)"`
+	Name         string `gorm:"type:varchar(500)"`
+	Status       string `gorm:"type:varchar(100)"`
+	Duration     float64
+	ErrorDetails string
+}
+
+func (jenkinsTestCase20240218) TableName() string {
+	return "_tool_jenkins_test_cases"
+}
+
+type addTestCases struct{}
+
+func (script *addTestCases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &jenkinsTestCase20240218{})
+}
+
+func (*addTestCases) Version() uint64 {
+	return 20240218000001
+}
+
+func (*addTestCases) Name() string {
+	return "add _tool_jenkins_test_cases"
+}
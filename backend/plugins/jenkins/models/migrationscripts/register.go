@@ -35,5 +35,7 @@ func All() []plugin.MigrationScript {
 		new(addConnectionIdToTransformationRule),
 		new(renameTr2ScopeConfig),
 		new(addRawParamTableForScope),
+		new(addTestCases),
+		new(addBranchJobs),
 	}
 }
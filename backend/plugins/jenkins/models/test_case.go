@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JenkinsTestCase is a case of the test report of a build, Id is the md5 of the suite name, class name and name
+type JenkinsTestCase struct {
+	common.NoPKModel
+	ConnectionId uint64  `gorm:"primaryKey"`
+	BuildName    string  `gorm:"primaryKey;type:varchar(255)"`
+	Id           string  `gorm:"primaryKey;type:varchar(32)"`
+	SuiteName    string  `gorm:"type:varchar(255)"`
+	ClassName    string  `gorm:"type:varchar(500)"`
+	Name         string  `gorm:"type:varchar(500)"`
+	Status       string  `gorm:"type:varchar(100)"`
+	Duration     float64 // in seconds
+	ErrorDetails string
+}
+
+func (JenkinsTestCase) TableName() string {
+	return "_tool_jenkins_test_cases"
+}
+
+// TestSuite is the suite in the response of the testReport api
+type TestSuite struct {
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases"`
+}
+
+type TestCase struct {
+	ClassName    string  `json:"className"`
+	Name         string  `json:"name"`
+	Duration     float64 `json:"duration"`
+	Status       string  `json:"status"`
+	ErrorDetails string  `json:"errorDetails"`
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var CollectBranchJobsMeta = plugin.SubTaskMeta{
+	Name:             "collectBranchJobs",
+	EntryPoint:       CollectBranchJobs,
+	EnabledByDefault: true,
+	Description:      "Discover branch and pull request jobs of multibranch pipelines and organization folders",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// pull request jobs are named PR-<number> by the GitHub/Bitbucket branch sources and MR-<number> by the GitLab one
+var pullRequestJobPattern = regexp.MustCompile(`^(PR|MR)-\d+$`)
+
+func CollectBranchJobs(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	if !data.Multibranch {
+		return nil
+	}
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+
+	// an organization folder holds a multibranch pipeline per repository, so two levels are enough for both
+	query := url.Values{}
+	query.Set("tree", "jobs[name,fullName,url,color,jobs[name,fullName,url,color]]")
+	res, err := data.ApiClient.Get(fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName), query, nil)
+	if err != nil {
+		return err
+	}
+	var body struct {
+		Jobs []models.Job `json:"jobs"`
+	}
+	err = api.UnmarshalResponse(res, &body)
+	if err != nil {
+		return err
+	}
+	scopePath := fmt.Sprintf("%sjob/%s/", data.Options.JobPath, data.Options.JobName)
+	branchJobs := discoverBranchJobs(data.Options.ConnectionId, data.Options.JobFullName, scopePath, data.Options.JobFullName+"/", body.Jobs)
+	logger.Info("discovered %d branch jobs under %s", len(branchJobs), data.Options.JobFullName)
+
+	// branches and pull requests removed from the repository are no longer discovered, keep them along with their
+	// builds collected before but stop collecting them
+	var existingBranchJobs []*models.JenkinsBranchJob
+	err = db.All(&existingBranchJobs, dal.Where("connection_id = ? and scope_full_name = ?",
+		data.Options.ConnectionId, data.Options.JobFullName))
+	if err != nil {
+		return err
+	}
+	discovered := make(map[string]bool, len(branchJobs))
+	for _, branchJob := range branchJobs {
+		discovered[branchJob.FullName] = true
+	}
+	for _, branchJob := range existingBranchJobs {
+		if !discovered[branchJob.FullName] && !branchJob.Deleted {
+			branchJob.Deleted = true
+			branchJobs = append(branchJobs, branchJob)
+		}
+	}
+	for _, branchJob := range branchJobs {
+		err = db.CreateOrUpdate(branchJob)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discoverBranchJobs flattens the jobs under a multibranch pipeline or an organization folder into branch jobs
+func discoverBranchJobs(connectionId uint64, scopeFullName string, path string, fullNamePrefix string, jobs []models.Job) []*models.JenkinsBranchJob {
+	branchJobs := make([]*models.JenkinsBranchJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Jobs != nil {
+			// a multibranch pipeline of a repository in the organization folder
+			branchJobs = append(branchJobs, discoverBranchJobs(
+				connectionId,
+				scopeFullName,
+				fmt.Sprintf("%sjob/%s/", path, job.Name),
+				fmt.Sprintf("%s%s/", fullNamePrefix, job.Name),
+				*job.Jobs,
+			)...)
+			continue
+		}
+		fullName := job.FullName
+		if fullName == "" {
+			fullName = fullNamePrefix + job.Name
+		}
+		// names of branches with slashes are escaped, e.g. feature%2Flogin
+		branchName, err := url.PathUnescape(job.Name)
+		if err != nil {
+			branchName = job.Name
+		}
+		branchJobs = append(branchJobs, &models.JenkinsBranchJob{
+			ConnectionId:  connectionId,
+			FullName:      fullName,
+			ScopeFullName: scopeFullName,
+			Name:          job.Name,
+			Path:          path,
+			Class:         job.Class,
+			Url:           job.URL,
+			BranchName:    branchName,
+			IsPullRequest: pullRequestJobPattern.MatchString(job.Name),
+		})
+	}
+	return branchJobs
+}
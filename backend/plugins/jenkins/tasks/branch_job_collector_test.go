@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverBranchJobs(t *testing.T) {
+	// an organization folder with a multibranch pipeline per repository
+	jobs := []models.Job{
+		{
+			Name: "incubator-devlake",
+			Jobs: &[]models.Job{
+				{Name: "main", Class: "org.jenkinsci.plugins.workflow.job.WorkflowJob"},
+				{Name: "feature%2Flogin", FullName: "devlake-org/incubator-devlake/feature%2Flogin"},
+				{Name: "PR-12"},
+			},
+		},
+		{
+			Name: "empty-repo",
+			Jobs: &[]models.Job{},
+		},
+	}
+	branchJobs := discoverBranchJobs(1, "devlake-org", "job/devlake-org/", "devlake-org/", jobs)
+
+	assert.Len(t, branchJobs, 3)
+	assert.Equal(t, &models.JenkinsBranchJob{
+		ConnectionId:  1,
+		FullName:      "devlake-org/incubator-devlake/main",
+		ScopeFullName: "devlake-org",
+		Name:          "main",
+		Path:          "job/devlake-org/job/incubator-devlake/",
+		Class:         "org.jenkinsci.plugins.workflow.job.WorkflowJob",
+		BranchName:    "main",
+	}, branchJobs[0])
+	assert.Equal(t, "devlake-org/incubator-devlake/feature%2Flogin", branchJobs[1].FullName)
+	assert.Equal(t, "feature/login", branchJobs[1].BranchName)
+	assert.False(t, branchJobs[1].IsPullRequest)
+	assert.Equal(t, "PR-12", branchJobs[2].BranchName)
+	assert.True(t, branchJobs[2].IsPullRequest)
+}
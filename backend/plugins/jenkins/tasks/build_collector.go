@@ -24,9 +24,11 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
 )
 
 const RAW_BUILD_TABLE = "jenkins_api_builds"
@@ -51,6 +53,9 @@ type SimpleJenkinsApiBuild struct {
 
 func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JenkinsTaskData)
+	if data.Multibranch {
+		return collectMultibranchBuilds(taskCtx, data)
+	}
 	collector, err := helper.NewStatefulApiCollectorForFinalizableEntity(helper.FinalizableApiCollectorArgs{
 		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
 			Params: JenkinsApiParams{
@@ -68,9 +73,7 @@ func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				UrlTemplate: fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName),
 				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
 					query := url.Values{}
-					treeValue := fmt.Sprintf(
-						"allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}",
-						reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
+					treeValue := fmt.Sprintf(buildsTreeTemplate, reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
 					query.Set("tree", treeValue)
 					return query, nil
 				},
@@ -117,3 +120,135 @@ func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return collector.Execute()
 }
+
+// SimpleBranchJob is the input of the builds collector of a multibranch pipeline, it is saved along with the raw data
+// so the extractor knows which branch job a build belongs to
+type SimpleBranchJob struct {
+	FullName   string
+	Name       string
+	Path       string
+	BranchName string
+}
+
+const buildsTreeTemplate = "allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}"
+
+// branchJobBuilds tells where to continue the collection of a branch job from, the builds still running at the last
+// collection are collected again once they finish
+type branchJobBuilds struct {
+	JobPath        string
+	JobName        string
+	OldestBuilding *int64
+	Newest         int64
+}
+
+// since returns the start time of the oldest running build, or the newest build when none is running
+func (b *branchJobBuilds) since() *time.Time {
+	since := time.UnixMilli(b.Newest)
+	if b.OldestBuilding != nil {
+		since = time.UnixMilli(*b.OldestBuilding)
+	}
+	return &since
+}
+
+// collectMultibranchBuilds collects builds of all the branch and pull request jobs under the multibranch pipeline or
+// the organization folder, branches deleted from the repository are skipped. Each branch job is collected since its own
+// builds collected before, so branch jobs discovered later are collected entirely
+func collectMultibranchBuilds(taskCtx plugin.SubTaskContext, data *JenkinsTaskData) errors.Error {
+	db := taskCtx.GetDal()
+	collectorWithState, err := helper.NewStatefulApiCollector(helper.RawDataSubTaskArgs{
+		Params: JenkinsApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.JobFullName,
+		},
+		Ctx:   taskCtx,
+		Table: RAW_BUILD_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	var branchJobs []*SimpleBranchJob
+	err = db.All(
+		&branchJobs,
+		dal.Select("full_name, name, path, branch_name"),
+		dal.From(&models.JenkinsBranchJob{}),
+		dal.Where("connection_id = ? and scope_full_name = ? and deleted = ?",
+			data.Options.ConnectionId, data.Options.JobFullName, false),
+		dal.Orderby("full_name"),
+	)
+	if err != nil {
+		return err
+	}
+	collected := make(map[string]*branchJobBuilds)
+	if collectorWithState.IsIncremental {
+		var builds []*branchJobBuilds
+		err = db.All(
+			&builds,
+			dal.Select("b.job_path, b.job_name, MIN(CASE WHEN b.building THEN b.timestamp END) AS oldest_building, MAX(b.timestamp) AS newest"),
+			dal.From("_tool_jenkins_builds b"),
+			dal.Join("JOIN _tool_jenkins_branch_jobs j ON j.connection_id = b.connection_id AND j.path = b.job_path AND j.name = b.job_name"),
+			dal.Where("j.connection_id = ? and j.scope_full_name = ?", data.Options.ConnectionId, data.Options.JobFullName),
+			dal.Groupby("b.job_path, b.job_name"),
+		)
+		if err != nil {
+			return err
+		}
+		for _, b := range builds {
+			collected[b.JobPath+b.JobName] = b
+		}
+	}
+
+	for i, branchJob := range branchJobs {
+		var since *time.Time
+		if b, ok := collected[branchJob.Path+branchJob.Name]; ok {
+			since = b.since()
+		}
+		input := helper.NewQueueIterator()
+		input.Push(branchJob)
+		err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+			ApiClient: data.ApiClient,
+			Input:     input,
+			// all the branch jobs share the raw data, only the first collector flushes them for a full collection
+			Incremental: i > 0,
+			PageSize:    100,
+			UrlTemplate: "{{ .Input.Path }}job/{{ .Input.Name }}/api/json",
+			Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+				query := url.Values{}
+				query.Set("tree", fmt.Sprintf(buildsTreeTemplate, reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size))
+				return query, nil
+			},
+			ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+				var data struct {
+					Builds []json.RawMessage `json:"allBuilds"`
+				}
+				err := helper.UnmarshalResponse(res, &data)
+				if err != nil {
+					return nil, err
+				}
+
+				// builds are sorted from the newest, stop once reaching the ones collected before, the running builds
+				// are collected as well, they are collected again by the next collection
+				builds := make([]json.RawMessage, 0, len(data.Builds))
+				for _, build := range data.Builds {
+					b := &SimpleJenkinsApiBuild{}
+					err := json.Unmarshal(build, b)
+					if err != nil {
+						return nil, errors.Convert(err)
+					}
+					if since != nil && time.UnixMilli(b.Timestamp).Before(*since) {
+						return builds, helper.ErrFinishCollect
+					}
+					builds = append(builds, build)
+				}
+				return builds, nil
+			},
+			// the branch job might be removed since the last discovery
+			AfterResponse: ignoreHTTPStatus404,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return collectorWithState.Execute()
+}
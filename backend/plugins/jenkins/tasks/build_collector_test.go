@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchJobBuildsSince(t *testing.T) {
+	newest := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	running := newest.Add(-time.Hour)
+
+	// the newest build is where the collection continues from when no build is running
+	finished := &branchJobBuilds{JobPath: "job/org/job/repo/", JobName: "main", Newest: newest.UnixMilli()}
+	assert.True(t, newest.Equal(*finished.since()))
+
+	// the running build is collected again, it might finish after the newer ones
+	oldestBuilding := running.UnixMilli()
+	building := &branchJobBuilds{JobPath: "job/org/job/repo/", JobName: "main", OldestBuilding: &oldestBuilding, Newest: newest.UnixMilli()}
+	assert.True(t, running.Equal(*building.since()))
+}
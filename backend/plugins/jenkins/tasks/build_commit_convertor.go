@@ -47,9 +47,7 @@ func ConvertBuildRepos(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.Join(`left join _tool_jenkins_builds tjb 
 						on _tool_jenkins_build_commits.build_name = tjb.full_name 
 						and _tool_jenkins_build_commits.connection_id = tjb.connection_id`),
-		dal.Where(`_tool_jenkins_build_commits.connection_id = ?
-							and tjb.job_path = ? and tjb.job_name = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		scopeBuildsClause(data, "tjb"),
 	}
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
@@ -56,15 +56,28 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 
+			// builds of a multibranch pipeline belong to the branch job they were collected from
+			job := &SimpleBranchJob{
+				FullName: data.Options.JobFullName,
+				Name:     data.Options.JobName,
+				Path:     data.Options.JobPath,
+			}
+			if data.Multibranch {
+				err = errors.Convert(json.Unmarshal(row.Input, job))
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			results := make([]interface{}, 0)
 			strList := strings.Split(body.Class, ".")
 			class := strList[len(strList)-1]
 			build := &models.JenkinsBuild{
 				ConnectionId:      data.Options.ConnectionId,
-				JobName:           data.Options.JobName,
-				JobPath:           data.Options.JobPath,
+				JobName:           job.Name,
+				JobPath:           job.Path,
 				Duration:          body.Duration,
-				FullName:          fmt.Sprintf(`%s#%d`, data.Options.JobFullName, body.Number),
+				FullName:          fmt.Sprintf(`%s#%d`, job.FullName, body.Number),
 				EstimatedDuration: body.EstimatedDuration,
 				Number:            body.Number,
 				Result:            body.Result,
@@ -72,6 +85,7 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				Class:             class,
 				Building:          body.Building,
 				StartTime:         time.Unix(body.Timestamp/1000, 0),
+				BranchName:        job.BranchName,
 			}
 			// we also need to collect the commit info from the build which does not have changeSet
 			// changeSet describes the changes that were made in the build
@@ -91,6 +105,9 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				if len(a.LastBuiltRevision.Branches) > 0 {
 					branch = a.LastBuiltRevision.Branches[0].Name
 				}
+				if job.BranchName != "" {
+					branch = job.BranchName
+				}
 				for _, url := range a.RemoteUrls {
 					if url != "" {
 						buildCommitRemoteUrl := models.JenkinsBuildCommit{
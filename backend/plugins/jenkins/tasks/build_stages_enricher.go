@@ -41,9 +41,7 @@ func EnrichApiBuildWithStages(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.Join(`inner join _tool_jenkins_stages tjs 
 						on tjs.build_name = tjb.full_name 
 						and tjs.connection_id = tjb.connection_id`),
-		dal.Where(`tjb.connection_id = ? 
-							and tjb.job_path = ? and tjb.job_name = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		scopeBuildsClause(data, "tjb"),
 	}
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
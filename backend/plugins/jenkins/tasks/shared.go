@@ -18,9 +18,12 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
-	"net/http"
 )
 
 const (
@@ -41,3 +44,14 @@ func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	}
 	return nil
 }
+
+// scopeBuildsClause selects the builds of the job, or the builds of all the branch and pull request jobs under it
+// when the job is a multibranch pipeline or an organization folder
+func scopeBuildsClause(data *JenkinsTaskData, table string) dal.Clause {
+	if data.Multibranch {
+		return dal.Where(fmt.Sprintf(`%s.connection_id = ? and %s.job_path like ?`, table, table),
+			data.Options.ConnectionId, fmt.Sprintf("%sjob/%s/%%", data.Options.JobPath, data.Options.JobName))
+	}
+	return dal.Where(fmt.Sprintf(`%s.connection_id = ? and %s.job_path = ? and %s.job_name = ?`, table, table, table),
+		data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName)
+}
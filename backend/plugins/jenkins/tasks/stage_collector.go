@@ -19,7 +19,6 @@ package tasks
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -43,6 +42,8 @@ var CollectApiStagesMeta = plugin.SubTaskMeta{
 type SimpleBuild struct {
 	Number   string
 	FullName string
+	JobPath  string
+	JobName  string
 }
 
 func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
@@ -62,10 +63,10 @@ func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	clauses := []dal.Clause{
-		dal.Select("tjb.number,tjb.full_name"),
+		dal.Select("tjb.number,tjb.full_name,tjb.job_path,tjb.job_name"),
 		dal.From("_tool_jenkins_builds as tjb"),
-		dal.Where(`tjb.connection_id = ? and tjb.job_path = ? and tjb.job_name = ? and tjb.class = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName, "WorkflowRun"),
+		scopeBuildsClause(data, "tjb"),
+		dal.Where(`tjb.class = ?`, "WorkflowRun"),
 	}
 	if collectorWithState.IsIncremental && collectorWithState.Since != nil {
 		clauses = append(clauses, dal.Where(`tjb.start_time >= ?`, collectorWithState.Since))
@@ -84,7 +85,7 @@ func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
 	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
 		ApiClient:   data.ApiClient,
 		Input:       iterator,
-		UrlTemplate: fmt.Sprintf("%sjob/%s/{{ .Input.Number }}/wfapi/describe", data.Options.JobPath, data.Options.JobName),
+		UrlTemplate: "{{ .Input.JobPath }}job/{{ .Input.JobName }}/{{ .Input.Number }}/wfapi/describe",
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
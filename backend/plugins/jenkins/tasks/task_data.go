@@ -43,6 +43,9 @@ type JenkinsTaskData struct {
 	ApiClient     *api.ApiAsyncClient
 	Connection    *models.JenkinsConnection
 	RegexEnricher *api.RegexEnricher
+	// Multibranch is true when the job is a multibranch pipeline or an organization folder, its builds are collected
+	// from the branch and pull request jobs discovered under it
+	Multibranch bool
 }
 
 func DecodeTaskOptions(options map[string]interface{}) (*JenkinsOptions, errors.Error) {
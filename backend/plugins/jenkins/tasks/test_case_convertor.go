@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/md5"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+type JenkinsTestCaseWithBuild struct {
+	models.JenkinsTestCase
+	StartTime time.Time
+	HasStages bool
+}
+
+var ConvertTestCasesMeta = plugin.SubTaskMeta{
+	Name:             "convertTestCases",
+	EntryPoint:       ConvertTestCases,
+	EnabledByDefault: true,
+	Description:      "convert jenkins_test_cases into test suites, test cases and test case results",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertTestCases(taskCtx plugin.SubTaskContext) (err errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	clauses := []dal.Clause{
+		dal.Select("tjtc.*, tjb.start_time, tjb.has_stages"),
+		dal.From("_tool_jenkins_test_cases as tjtc"),
+		dal.Join(`left join _tool_jenkins_builds tjb on tjb.connection_id = tjtc.connection_id and tjb.full_name = tjtc.build_name`),
+		scopeBuildsClause(data, "tjb"),
+		dal.Orderby("tjtc.build_name, tjtc.suite_name"),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+	jobIdGen := didgen.NewDomainIdGenerator(&models.JenkinsJob{})
+	cicdScopeId := jobIdGen.Generate(data.Options.ConnectionId, data.Options.JobFullName)
+
+	// rows are sorted by build and suite, the suite is accumulated and saved along with each of its cases,
+	// the batch keeps the latest one
+	var suite *devops.CicdTestSuite
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(JenkinsTestCaseWithBuild{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jenkinsTestCase := inputRow.(*JenkinsTestCaseWithBuild)
+			pipelineId := buildIdGen.Generate(jenkinsTestCase.ConnectionId, jenkinsTestCase.BuildName)
+			suiteId := fmt.Sprintf("%s:%x", pipelineId, md5.Sum([]byte(jenkinsTestCase.SuiteName)))
+			if suite == nil || suite.Id != suiteId {
+				suite = &devops.CicdTestSuite{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: suiteId,
+					},
+					CicdScopeId: cicdScopeId,
+					PipelineId:  pipelineId,
+					Name:        jenkinsTestCase.SuiteName,
+					StartedDate: &jenkinsTestCase.StartTime,
+				}
+				// builds without stages were converted to a single task sharing the id of the pipeline
+				if !jenkinsTestCase.HasStages {
+					suite.TaskId = pipelineId
+				}
+			}
+			// reference: hudson.tasks.junit.CaseResult.Status
+			result := devops.TEST_PASSED
+			switch jenkinsTestCase.Status {
+			case "FAILED", "REGRESSION":
+				result = devops.TEST_FAILED
+				suite.Failures++
+			case "SKIPPED":
+				result = devops.TEST_SKIPPED
+				suite.Skipped++
+			}
+			suite.Tests++
+			suite.DurationSec += jenkinsTestCase.Duration
+			testCase := &devops.CicdTestCase{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: devops.CicdTestCaseId(cicdScopeId, jenkinsTestCase.SuiteName, jenkinsTestCase.ClassName, jenkinsTestCase.Name),
+				},
+				CicdScopeId: cicdScopeId,
+				SuiteName:   jenkinsTestCase.SuiteName,
+				ClassName:   jenkinsTestCase.ClassName,
+				Name:        jenkinsTestCase.Name,
+			}
+			testCaseResult := &devops.CicdTestCaseResult{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: pipelineId + ":" + jenkinsTestCase.Id,
+				},
+				TestCaseId:     testCase.Id,
+				TestSuiteId:    suite.Id,
+				CicdScopeId:    cicdScopeId,
+				PipelineId:     pipelineId,
+				TaskId:         suite.TaskId,
+				Result:         result,
+				OriginalResult: jenkinsTestCase.Status,
+				DurationSec:    jenkinsTestCase.Duration,
+				Message:        jenkinsTestCase.ErrorDetails,
+				ExecutedDate:   suite.StartedDate,
+			}
+			return []interface{}{
+				testCase,
+				testCaseResult,
+				suite,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ExtractApiTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTestReports",
+	EntryPoint:       ExtractApiTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports data into tool layer table _tool_jenkins_test_cases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &models.TestSuite{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			input := &SimpleBuild{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0, len(body.Cases))
+			for _, c := range body.Cases {
+				results = append(results, &models.JenkinsTestCase{
+					ConnectionId: data.Options.ConnectionId,
+					BuildName:    input.FullName,
+					Id:           fmt.Sprintf("%x", md5.Sum([]byte(body.Name+"\n"+c.ClassName+"\n"+c.Name))),
+					SuiteName:    body.Name,
+					ClassName:    c.ClassName,
+					Name:         c.Name,
+					Status:       c.Status,
+					Duration:     c.Duration,
+					ErrorDetails: c.ErrorDetails,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+type JqlScopeReqBody struct {
+	Name          string `json:"name"`
+	FilterId      uint64 `json:"filterId"`
+	Jql           string `json:"jql"`
+	ScopeConfigId uint64 `json:"scopeConfigId"`
+}
+
+// PostJqlScope create a scope out of a saved filter or a jql query
+// @Summary create a scope out of a saved filter or a jql query
+// @Description Create a scope which collects the issues matching a saved filter or a jql query instead of a board,
+// @Description either filterId or jql is required, name defaults to the name of the filter
+// @Tags plugins/jira
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param scope body JqlScopeReqBody true "json"
+// @Success 201  {object} models.JiraBoard
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Filter is already a scope"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/jira/connections/{connectionId}/jql-scopes [POST]
+func PostJqlScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection, err := dsHelper.ConnApi.FindByPk(input)
+	if err != nil {
+		return nil, err
+	}
+	body := &JqlScopeReqBody{}
+	err = api.Decode(input.Body, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	body.Jql = strings.TrimSpace(body.Jql)
+	if body.FilterId == 0 && body.Jql == "" {
+		return nil, errors.BadInput.New("either filterId or jql is required")
+	}
+
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, err
+	}
+	db := basicRes.GetDal()
+	// the web ui of jira lives next to the rest api
+	siteUrl := strings.TrimSuffix(strings.TrimSuffix(connection.Endpoint, "/"), "/rest") + "/"
+	scope := &models.JiraBoard{
+		Name:      body.Name,
+		Type:      models.BOARD_TYPE_JQL,
+		FilterId:  body.FilterId,
+		CustomJql: body.Jql,
+	}
+	scope.ConnectionId = connection.ID
+	scope.ScopeConfigId = body.ScopeConfigId
+	if body.FilterId != 0 {
+		count, err := db.Count(
+			dal.From(&models.JiraBoard{}),
+			dal.Where("connection_id = ? AND type = ? AND filter_id = ?", connection.ID, models.BOARD_TYPE_JQL, body.FilterId),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, errors.Conflict.New(fmt.Sprintf("filter %d is already a scope", body.FilterId))
+		}
+		res, err := apiClient.Get(fmt.Sprintf("api/2/filter/%d", body.FilterId), nil, nil)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to get filter %d", body.FilterId))
+		}
+		filter := &tasks.FilterInfo{}
+		err = api.UnmarshalResponse(res, filter)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to get filter %d", body.FilterId))
+		}
+		if strings.TrimSpace(filter.Jql) == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("filter %d has no jql", body.FilterId))
+		}
+		// the scope follows the filter, whatever jql was given
+		scope.CustomJql = ""
+		scope.Jql = filter.Jql
+		if scope.Name == "" {
+			scope.Name = filter.Name
+		}
+		scope.Self = fmt.Sprintf("%sissues/?filter=%d", siteUrl, body.FilterId)
+	} else {
+		if scope.Name == "" {
+			return nil, errors.BadInput.New("name is required for a jql scope")
+		}
+		// let jira validate the jql
+		res, err := apiClient.Get("api/2/search", url.Values{"jql": {body.Jql}, "maxResults": {"0"}}, nil)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid jql: %s", body.Jql))
+		}
+		res.Body.Close()
+		scope.Jql = body.Jql
+		scope.Self = fmt.Sprintf("%sissues/?jql=%s", siteUrl, url.QueryEscape(body.Jql))
+	}
+
+	// jql scopes are numbered after the last one of the connection
+	var last models.JiraBoard
+	err = db.First(
+		&last,
+		dal.Where("connection_id = ? AND board_id > ?", connection.ID, models.JQL_SCOPE_ID_OFFSET),
+		dal.Orderby("board_id DESC"),
+	)
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, err
+	}
+	scope.BoardId = models.JQL_SCOPE_ID_OFFSET + 1
+	if last.BoardId != 0 {
+		scope.BoardId = last.BoardId + 1
+	}
+	err = db.Create(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: scope, Status: http.StatusCreated}, nil
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+// ReceiveSourceEvent verifies the `X-Hub-Signature` of the Jira webhook and maps it to the boards containing the issue,
+// a newly created issue is mapped to the boards of its project since it doesn't belong to any board yet
+func ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	err := api.VerifyHmacSignature(secret, body, header.Get("X-Hub-Signature"))
+	if err != nil {
+		return nil, err
+	}
+	payload := &struct {
+		WebhookEvent string `json:"webhookEvent"`
+		Issue        *struct {
+			Id     string `json:"id"`
+			Fields struct {
+				Project struct {
+					Id string `json:"id"`
+				} `json:"project"`
+			} `json:"fields"`
+		} `json:"issue"`
+	}{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode jira event")
+	}
+	// issue, comment and worklog events carry the issue they belong to
+	if payload.Issue == nil ||
+		!(strings.HasPrefix(payload.WebhookEvent, "jira:issue_") ||
+			strings.HasPrefix(payload.WebhookEvent, "comment_") ||
+			strings.HasPrefix(payload.WebhookEvent, "worklog_")) {
+		return nil, nil
+	}
+	issueId, e := strconv.ParseUint(payload.Issue.Id, 10, 64)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "invalid issue id in jira event")
+	}
+	projectId, e := strconv.ParseUint(payload.Issue.Fields.Project.Id, 10, 64)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "invalid project id in jira event")
+	}
+
+	db := basicRes.GetDal()
+	var boardIds []uint64
+	err = db.Pluck("board_id", &boardIds,
+		dal.From(&models.JiraBoard{}),
+		dal.Where(
+			`connection_id = ? AND (project_id = ? OR board_id IN (
+				SELECT board_id FROM _tool_jira_board_issues WHERE connection_id = ? AND issue_id = ?
+			))`,
+			connectionId, projectId, connectionId, issueId,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	scopeIds := make([]string, 0, len(boardIds))
+	for _, boardId := range boardIds {
+		scopeIds = append(scopeIds, fmt.Sprintf("%d", boardId))
+	}
+	return scopeIds, nil
+}
@@ -41,6 +41,13 @@ func TestBoardDataFlow(t *testing.T) {
 	dataflowTester.FlushTabler(&ticket.Board{})
 	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_boards.csv", &models.JiraBoard{})
 	dataflowTester.Subtask(tasks.ConvertBoardMeta, taskData)
+	// jql scopes get their own boards
+	dataflowTester.Subtask(tasks.ConvertBoardMeta, &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      models.JQL_SCOPE_ID_OFFSET + 1,
+		},
+	})
 	dataflowTester.VerifyTable(
 		ticket.Board{},
 		"./snapshot_tables/boards.csv",
@@ -41,6 +41,7 @@ var _ interface {
 	plugin.PluginModel
 	plugin.PluginMigration
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourceEventReceiver
 	plugin.CloseablePluginTask
 	plugin.PluginSource
 } = (*Jira)(nil)
@@ -197,6 +198,9 @@ func (p Jira) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]int
 		// If we still cannot find the record in db, we have to request from remote server and save it to db
 		db := taskCtx.GetDal()
 		err = db.First(&scope, dal.Where("connection_id = ? AND board_id = ?", op.ConnectionId, op.BoardId))
+		if err != nil && db.IsErrorNotFound(err) && op.BoardId > models.JQL_SCOPE_ID_OFFSET {
+			return nil, errors.NotFound.Wrap(err, fmt.Sprintf("jql scope %d not found, it has to be created first", op.BoardId))
+		}
 		if err != nil && db.IsErrorNotFound(err) {
 			var board *apiv2models.Board
 			board, err = api.GetApiJira(&op, jiraApiClient)
@@ -308,6 +312,9 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": api.GetScopeList,
 			"PUT": api.PutScope,
 		},
+		"connections/:connectionId/jql-scopes": {
+			"POST": api.PostJqlScope,
+		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.CreateScopeConfig,
 			"GET":  api.GetScopeConfigList,
@@ -332,6 +339,10 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	}
 }
 
+func (p Jira) ReceiveSourceEvent(connectionId uint64, secret string, header http.Header, body []byte) ([]string, errors.Error) {
+	return api.ReceiveSourceEvent(connectionId, secret, header, body)
+}
+
 func (p Jira) Close(taskCtx plugin.TaskContext) errors.Error {
 	data, ok := taskCtx.GetData().(*tasks.JiraTaskData)
 	if !ok {
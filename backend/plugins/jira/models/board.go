@@ -26,6 +26,11 @@ import (
 
 var _ plugin.ToolLayerScope = (*JiraBoard)(nil)
 
+// BOARD_TYPE_JQL marks the scopes defined by a JQL query or a saved filter rather than an agile board, they are
+// stored as boards with ids starting from JQL_SCOPE_ID_OFFSET so they never collide with the ids of real boards
+const BOARD_TYPE_JQL = "jql"
+const JQL_SCOPE_ID_OFFSET uint64 = 1 << 32
+
 type JiraBoard struct {
 	common.Scope `mapstructure:",squash"`
 	BoardId      uint64 `json:"boardId" mapstructure:"boardId" validate:"required" gorm:"primaryKey"`
@@ -34,6 +39,13 @@ type JiraBoard struct {
 	Self         string `json:"self" mapstructure:"self" gorm:"type:varchar(255)"`
 	Type         string `json:"type" mapstructure:"type" gorm:"type:varchar(100)"`
 	Jql          string `json:"jql" mapstructure:"jql"`
+	// FilterId and CustomJql define a jql scope, the issues of a filter are collected when FilterId is set
+	FilterId  uint64 `json:"filterId" mapstructure:"filterId"`
+	CustomJql string `json:"customJql" mapstructure:"customJql"`
+}
+
+func (b JiraBoard) IsJqlScope() bool {
+	return b.Type == BOARD_TYPE_JQL
 }
 
 func (b JiraBoard) ScopeId() string {
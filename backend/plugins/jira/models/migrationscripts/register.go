@@ -47,5 +47,6 @@ func All() []plugin.MigrationScript {
 		new(modifyIssueRelationship),
 		new(addComponents20230412),
 		new(addFilterJQL),
+		new(addJqlScopes),
 	}
 }
@@ -40,26 +40,17 @@ func CollectBoardFilterBegin(taskCtx plugin.SubTaskContext) errors.Error {
 	logger := taskCtx.GetLogger()
 	db := taskCtx.GetDal()
 	logger.Info("collect board in collectBoardFilterBegin: %d", data.Options.BoardId)
-	// get board filter id
-	filterId, err := getBoardFilterId(data)
+	var record models.JiraBoard
+	err := db.First(&record, dal.Where("connection_id = ? AND board_id = ? ", data.Options.ConnectionId, data.Options.BoardId))
 	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error getting board filter id for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
+		return errors.Default.Wrap(err, fmt.Sprintf("error finding record in _tool_jira_boards table for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
 	}
-	logger.Info("collect board filter:%s", filterId)
 
-	// get board filter jql
-	filterInfo, err := getBoardFilterJql(data, filterId)
+	jql, err := getScopeJql(data, &record)
 	if err != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("error getting board filter jql for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
 	}
-	logger.Info("collect board filter jql:%s", filterInfo.Jql)
-
-	jql := filterInfo.Jql
-	var record models.JiraBoard
-	err = db.First(&record, dal.Where("connection_id = ? AND board_id = ? ", data.Options.ConnectionId, data.Options.BoardId))
-	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error finding record in _tool_jira_boards table for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
-	}
+	logger.Info("collect board filter jql:%s", jql)
 
 	// full sync
 	syncPolicy := taskCtx.TaskContext().SyncPolicy()
@@ -90,6 +81,27 @@ func CollectBoardFilterBegin(taskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+// getScopeJql returns the jql deciding which issues belong to the scope, that is the jql of the board filter for
+// boards, and the jql of the saved filter or the custom jql for jql scopes
+func getScopeJql(data *JiraTaskData, board *models.JiraBoard) (string, errors.Error) {
+	if board.IsJqlScope() && board.FilterId == 0 {
+		return board.CustomJql, nil
+	}
+	filterId := fmt.Sprintf("%d", board.FilterId)
+	if !board.IsJqlScope() {
+		var err error
+		filterId, err = getBoardFilterId(data)
+		if err != nil {
+			return "", errors.Convert(err)
+		}
+	}
+	filterInfo, err := getBoardFilterJql(data, filterId)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	return filterInfo.Jql, nil
+}
+
 func getBoardFilterId(data *JiraTaskData) (string, error) {
 	url := fmt.Sprintf("agile/1.0/board/%d/configuration", data.Options.BoardId)
 	boardConfiguration, err := data.ApiClient.Get(url, nil, nil)
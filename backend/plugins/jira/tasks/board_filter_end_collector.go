@@ -40,30 +40,19 @@ func CollectBoardFilterEnd(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	logger.Info("collect board in collectBoardFilterEnd: %d", data.Options.BoardId)
 
-	// get board filter id
-	filterId, err := getBoardFilterId(data)
+	// should not change
+	var record models.JiraBoard
+	err := db.First(&record, dal.Where("connection_id = ? AND board_id = ? ", data.Options.ConnectionId, data.Options.BoardId))
 	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error getting board filter id for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
+		return errors.Default.Wrap(err, fmt.Sprintf("error finding record in _tool_jira_boards table for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
 	}
-	logger.Info("collect board filter:%s", filterId)
 
-	// get board filter jql
-	filterInfo, err := getBoardFilterJql(data, filterId)
+	jql, err := getScopeJql(data, &record)
 	if err != nil {
 		return errors.Default.Wrap(err, fmt.Sprintf("error getting board filter jql for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
 	}
-	logger.Info("collect board filter jql:%s", filterInfo.Jql)
-
-	jql := filterInfo.Jql
 	logger.Info("collect board filter jql:%s", jql)
 
-	// should not change
-	var record models.JiraBoard
-	err = db.First(&record, dal.Where("connection_id = ? AND board_id = ? ", data.Options.ConnectionId, data.Options.BoardId))
-	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error finding record in _tool_jira_boards table for connection_id:%d board_id:%d", data.Options.ConnectionId, data.Options.BoardId))
-	}
-
 	if record.Jql != jql {
 		return errors.Default.New(fmt.Sprintf("board filter jql has changed for connection_id:%d board_id:%d, please use fullSync mode!!!", data.Options.ConnectionId, data.Options.BoardId))
 	}
@@ -23,6 +23,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -80,6 +82,28 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	if collectorWithState.Since != nil {
 		jql = buildJQL(*collectorWithState.Since, loc)
 	}
+	// issues of a jql scope are searched by its jql instead of being listed from a board
+	urlTemplate := "agile/1.0/board/{{ .Params.BoardId }}/issue"
+	var board models.JiraBoard
+	err = taskCtx.GetDal().First(&board, dal.Where("connection_id = ? AND board_id = ?", data.Options.ConnectionId, data.Options.BoardId))
+	if err != nil {
+		return err
+	}
+	if board.IsJqlScope() {
+		urlTemplate = "api/2/search"
+		// the jql is saved by collectBoardFilterBegin, which might be skipped
+		scopeJql := board.Jql
+		if scopeJql == "" {
+			scopeJql, err = getScopeJql(data, &board)
+			if err != nil {
+				return err
+			}
+		}
+		jql, err = buildScopeJQL(scopeJql, jql)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to build the jql of scope %d", board.BoardId))
+		}
+	}
 
 	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
@@ -93,7 +117,7 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 			avoid duplicate logic for every tasks, and when we have a better idea like improving performance, we can
 			do it in one place
 		*/
-		UrlTemplate: "agile/1.0/board/{{ .Params.BoardId }}/issue",
+		UrlTemplate: urlTemplate,
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
@@ -160,6 +184,22 @@ func buildJQL(since time.Time, location *time.Location) string {
 	return jql
 }
 
+var orderByPattern = regexp.MustCompile(`(?is)\s*\bORDER\s+BY\b.*$`)
+
+// buildScopeJQL restricts the jql of a scope with the jql built by buildJQL, the ordering of the scope jql is dropped
+// since issues must be collected in a consistent order
+func buildScopeJQL(scopeJql string, jql string) (string, errors.Error) {
+	scopeJql = strings.TrimSpace(orderByPattern.ReplaceAllString(scopeJql, ""))
+	// issues of the whole jira instance would be collected otherwise
+	if scopeJql == "" {
+		return "", errors.BadInput.New("the jql of the scope is empty")
+	}
+	if strings.HasPrefix(jql, "ORDER BY") {
+		return fmt.Sprintf("(%s) %s", scopeJql, jql), nil
+	}
+	return fmt.Sprintf("(%s) AND %s", scopeJql, jql), nil
+}
+
 // getTimeZone get user's timezone from jira API
 func getTimeZone(taskCtx plugin.SubTaskContext) (*time.Location, errors.Error) {
 	data := taskCtx.GetData().(*JiraTaskData)
@@ -20,6 +20,8 @@ package tasks
 import (
 	"testing"
 	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
 )
 
 func Test_buildJQL(t *testing.T) {
@@ -61,3 +63,67 @@ func Test_buildJQL(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildScopeJQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopeJql string
+		jql      string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "full collection",
+			scopeJql: "project = DEV AND labels = incident",
+			jql:      "ORDER BY created ASC",
+			want:     "(project = DEV AND labels = incident) ORDER BY created ASC",
+		},
+		{
+			name:     "incremental collection",
+			scopeJql: "project = DEV OR project = OPS order by rank",
+			jql:      "updated >= '2021/02/02 04:05' ORDER BY created ASC",
+			want:     "(project = DEV OR project = OPS) AND updated >= '2021/02/02 04:05' ORDER BY created ASC",
+		},
+		{
+			name:     "scope without conditions",
+			scopeJql: "ORDER BY updated DESC",
+			jql:      "ORDER BY created ASC",
+			wantErr:  true,
+		},
+		{
+			name:     "empty scope",
+			scopeJql: "",
+			jql:      "ORDER BY created ASC",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildScopeJQL(tt.scopeJql, tt.jql)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("buildScopeJQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("buildScopeJQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getScopeJql(t *testing.T) {
+	// the custom jql of a jql scope is used without asking jira
+	board := &models.JiraBoard{Type: models.BOARD_TYPE_JQL, CustomJql: "project = DEV"}
+	jql, err := getScopeJql(nil, board)
+	if err != nil || jql != "project = DEV" {
+		t.Errorf("getScopeJql() = %v, %v, want project = DEV", jql, err)
+	}
+	board.CustomJql = ""
+	jql, err = getScopeJql(nil, board)
+	if err != nil || jql != "" {
+		t.Errorf("getScopeJql() = %v, %v, want empty", jql, err)
+	}
+	if _, err = buildScopeJQL(jql, "ORDER BY created ASC"); err == nil {
+		t.Errorf("buildScopeJQL() of an empty scope should fail")
+	}
+}
@@ -23,9 +23,11 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
 )
 
 const RAW_SPRINT_TABLE = "jira_api_sprints"
@@ -44,6 +46,15 @@ func CollectSprints(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JiraTaskData)
 	logger := taskCtx.GetLogger()
 	logger.Info("collect sprints")
+	var board models.JiraBoard
+	err := taskCtx.GetDal().First(&board, dal.Where("connection_id = ? AND board_id = ?", data.Options.ConnectionId, data.Options.BoardId))
+	if err != nil {
+		return err
+	}
+	if board.IsJqlScope() {
+		logger.Info("skip collecting sprints for jql scope %d", data.Options.BoardId)
+		return nil
+	}
 	jql := "ORDER BY created ASC"
 	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
 		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/impl"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/tasks"
+)
+
+func TestIncidentResponseDataFlow(t *testing.T) {
+	var plugin impl.PagerDuty
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "pagerduty", plugin)
+	options := tasks.PagerDutyOptions{
+		ConnectionId: 1,
+		ServiceId:    "PIKL83L",
+		ServiceName:  "DevService",
+	}
+	taskData := &tasks.PagerDutyTaskData{
+		Options: &options,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_pagerduty_incidents.csv", &models.Incident{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_log_entries.csv", "_raw_pagerduty_log_entries")
+
+	// verify log entry extraction
+	dataflowTester.FlushTabler(&models.LogEntry{})
+	dataflowTester.Subtask(tasks.ExtractLogEntriesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.LogEntry{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_log_entries.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify log entry conversion
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.FlushTabler(&ticket.IncidentResponse{})
+	dataflowTester.Subtask(tasks.ConvertLogEntriesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IncidentResponse{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/incident_responses.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/impl"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/tasks"
+)
+
+func TestOnCallDataFlow(t *testing.T) {
+	var plugin impl.PagerDuty
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "pagerduty", plugin)
+	options := tasks.PagerDutyOptions{
+		ConnectionId: 1,
+		ServiceId:    "PIKL83L",
+		ServiceName:  "DevService",
+	}
+	taskData := &tasks.PagerDutyTaskData{
+		Options: &options,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_escalation_policies.csv", "_raw_pagerduty_escalation_policies")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_schedules.csv", "_raw_pagerduty_schedules")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_pagerduty_oncalls.csv", "_raw_pagerduty_oncalls")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.EscalationPolicy{})
+	dataflowTester.FlushTabler(&models.EscalationRule{})
+	dataflowTester.Subtask(tasks.ExtractEscalationPoliciesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.EscalationPolicy{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_escalation_policies.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.VerifyTableWithOptions(
+		models.EscalationRule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_escalation_rules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&models.Schedule{})
+	dataflowTester.Subtask(tasks.ExtractSchedulesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.Schedule{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_schedules.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+	dataflowTester.FlushTabler(&models.OnCall{})
+	dataflowTester.Subtask(tasks.ExtractOnCallsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(
+		models.OnCall{},
+		e2ehelper.TableOptions{
+			CSVRelPath:  "./snapshot_tables/_tool_pagerduty_oncalls.csv",
+			IgnoreTypes: []any{common.NoPKModel{}},
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.OnCallShift{})
+	dataflowTester.Subtask(tasks.ConvertOnCallsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.OnCallShift{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/oncall_shifts.csv",
+		IgnoreTypes: []any{common.NoPKModel{}},
+	})
+}
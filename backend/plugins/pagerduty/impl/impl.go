@@ -77,7 +77,17 @@ func (p PagerDuty) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.CollectIncidentsMeta,
 		tasks.ExtractIncidentsMeta,
+		tasks.CollectLogEntriesMeta,
+		tasks.ExtractLogEntriesMeta,
+		tasks.CollectEscalationPoliciesMeta,
+		tasks.ExtractEscalationPoliciesMeta,
+		tasks.CollectSchedulesMeta,
+		tasks.ExtractSchedulesMeta,
+		tasks.CollectOnCallsMeta,
+		tasks.ExtractOnCallsMeta,
 		tasks.ConvertIncidentsMeta,
+		tasks.ConvertLogEntriesMeta,
+		tasks.ConvertOnCallsMeta,
 		tasks.ConvertServicesMeta,
 	}
 }
@@ -88,6 +98,11 @@ func (p PagerDuty) GetTablesInfo() []dal.Tabler {
 		&models.Incident{},
 		&models.User{},
 		&models.Assignment{},
+		&models.LogEntry{},
+		&models.EscalationPolicy{},
+		&models.EscalationRule{},
+		&models.Schedule{},
+		&models.OnCall{},
 		&models.PagerDutyConnection{},
 		&models.PagerdutyScopeConfig{},
 	}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	EscalationTargetTypeUser     = "user_reference"
+	EscalationTargetTypeSchedule = "schedule_reference"
+)
+
+// EscalationPolicy is the escalation policy of a service, a policy shared by several services is stored once
+// for each of them
+type EscalationPolicy struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ServiceId    string `gorm:"primaryKey;type:varchar(255)"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	Url          string
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	NumLoops     int
+}
+
+func (EscalationPolicy) TableName() string {
+	return "_tool_pagerduty_escalation_policies"
+}
+
+// EscalationRule is a target notified at a level of an escalation policy, a rule with several targets is
+// stored as one row per target
+type EscalationRule struct {
+	common.NoPKModel
+	ConnectionId             uint64 `gorm:"primaryKey"`
+	ServiceId                string `gorm:"primaryKey;type:varchar(255)"`
+	Id                       string `gorm:"primaryKey;type:varchar(255)"`
+	TargetId                 string `gorm:"primaryKey;type:varchar(255)"`
+	TargetType               string `gorm:"type:varchar(100)"`
+	TargetName               string `gorm:"type:varchar(255)"`
+	EscalationPolicyId       string `gorm:"type:varchar(255)"`
+	Level                    int
+	EscalationDelayInMinutes int
+}
+
+func (EscalationRule) TableName() string {
+	return "_tool_pagerduty_escalation_rules"
+}
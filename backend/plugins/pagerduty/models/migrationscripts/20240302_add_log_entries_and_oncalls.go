@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addLogEntriesAndOnCalls)(nil)
+
+type logEntry20240302 struct {
+	archived.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Id             string `gorm:"primaryKey;type:varchar(255)"`
+	IncidentNumber int    `gorm:"index"`
+	Type           string `gorm:"type:varchar(100)"`
+	Summary        string
+	AgentId        string `gorm:"type:varchar(255)"`
+	AgentType      string `gorm:"type:varchar(100)"`
+	AgentName      string `gorm:"type:varchar(255)"`
+	ChannelType    string `gorm:"type:varchar(100)"`
+	AssigneeIds    string
+	CreatedDate    time.Time
+}
+
+func (logEntry20240302) TableName() string {
+	return "_tool_pagerduty_log_entries"
+}
+
+type escalationPolicy20240302 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ServiceId    string `gorm:"primaryKey;type:varchar(255)"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	Url          string
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	NumLoops     int
+}
+
+func (escalationPolicy20240302) TableName() string {
+	return "_tool_pagerduty_escalation_policies"
+}
+
+type escalationRule20240302 struct {
+	archived.NoPKModel
+	ConnectionId             uint64 `gorm:"primaryKey"`
+	ServiceId                string `gorm:"primaryKey;type:varchar(255)"`
+	Id                       string `gorm:"primaryKey;type:varchar(255)"`
+	TargetId                 string `gorm:"primaryKey;type:varchar(255)"`
+	TargetType               string `gorm:"type:varchar(100)"`
+	TargetName               string `gorm:"type:varchar(255)"`
+	EscalationPolicyId       string `gorm:"type:varchar(255)"`
+	Level                    int
+	EscalationDelayInMinutes int
+}
+
+func (escalationRule20240302) TableName() string {
+	return "_tool_pagerduty_escalation_rules"
+}
+
+type schedule20240302 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	Url          string
+	Name         string `gorm:"type:varchar(255)"`
+	Description  string
+	TimeZone     string `gorm:"type:varchar(100)"`
+}
+
+func (schedule20240302) TableName() string {
+	return "_tool_pagerduty_schedules"
+}
+
+type onCall20240302 struct {
+	archived.NoPKModel
+	ConnectionId       uint64    `gorm:"primaryKey"`
+	ServiceId          string    `gorm:"primaryKey;type:varchar(255)"`
+	EscalationPolicyId string    `gorm:"primaryKey;type:varchar(255)"`
+	EscalationLevel    int       `gorm:"primaryKey"`
+	UserId             string    `gorm:"primaryKey;type:varchar(255)"`
+	Start              time.Time `gorm:"primaryKey"`
+	End                *time.Time
+	ScheduleId         string `gorm:"type:varchar(255)"`
+	UserName           string `gorm:"type:varchar(255)"`
+}
+
+func (onCall20240302) TableName() string {
+	return "_tool_pagerduty_oncalls"
+}
+
+type addLogEntriesAndOnCalls struct{}
+
+func (*addLogEntriesAndOnCalls) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&logEntry20240302{},
+		&escalationPolicy20240302{},
+		&escalationRule20240302{},
+		&schedule20240302{},
+		&onCall20240302{},
+	)
+}
+
+func (*addLogEntriesAndOnCalls) Version() uint64 {
+	return 20240302000001
+}
+
+func (*addLogEntriesAndOnCalls) Name() string {
+	return "add log entries, escalation policies, schedules and on-calls tables"
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// OnCall is a period a user was on call for a service through a level of its escalation policy, the schedule is
+// empty when the user is a direct target of the escalation rule
+type OnCall struct {
+	common.NoPKModel
+	ConnectionId       uint64    `gorm:"primaryKey"`
+	ServiceId          string    `gorm:"primaryKey;type:varchar(255)"`
+	EscalationPolicyId string    `gorm:"primaryKey;type:varchar(255)"`
+	EscalationLevel    int       `gorm:"primaryKey"`
+	UserId             string    `gorm:"primaryKey;type:varchar(255)"`
+	Start              time.Time `gorm:"primaryKey"`
+	End                *time.Time
+	ScheduleId         string `gorm:"type:varchar(255)"`
+	UserName           string `gorm:"type:varchar(255)"`
+}
+
+func (OnCall) TableName() string {
+	return "_tool_pagerduty_oncalls"
+}
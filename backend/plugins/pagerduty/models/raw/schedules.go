@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raw
+
+import "time"
+
+type Schedule struct {
+	Id                 string      `json:"id"`
+	Type               string      `json:"type"`
+	Summary            string      `json:"summary"`
+	Self               string      `json:"self"`
+	HtmlUrl            string      `json:"html_url"`
+	Name               string      `json:"name"`
+	Description        string      `json:"description"`
+	TimeZone           string      `json:"time_zone"`
+	Users              []Reference `json:"users"`
+	EscalationPolicies []Reference `json:"escalation_policies"`
+}
+
+type OnCall struct {
+	EscalationPolicy Reference  `json:"escalation_policy"`
+	EscalationLevel  int        `json:"escalation_level"`
+	Schedule         *Reference `json:"schedule"`
+	User             Reference  `json:"user"`
+	Start            *time.Time `json:"start"`
+	End              *time.Time `json:"end"`
+}
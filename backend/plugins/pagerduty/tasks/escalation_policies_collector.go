@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ESCALATION_POLICIES_TABLE = "pagerduty_escalation_policies"
+
+var _ plugin.SubTaskEntryPoint = CollectEscalationPolicies
+
+// CollectEscalationPolicies collects the escalation policy of the service, which is included in the service
+// along with its rules
+func CollectEscalationPolicies(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ESCALATION_POLICIES_TABLE,
+		},
+		ApiClient:   data.Client,
+		UrlTemplate: fmt.Sprintf("services/%s", data.Options.ServiceId),
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("include[]", "escalation_policies")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var rawResult struct {
+				Service struct {
+					EscalationPolicy json.RawMessage `json:"escalation_policy"`
+				} `json:"service"`
+			}
+			err := api.UnmarshalResponse(res, &rawResult)
+			if err != nil || rawResult.Service.EscalationPolicy == nil {
+				return nil, err
+			}
+			return []json.RawMessage{rawResult.Service.EscalationPolicy}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectEscalationPoliciesMeta = plugin.SubTaskMeta{
+	Name:             "collectEscalationPolicies",
+	EntryPoint:       CollectEscalationPolicies,
+	EnabledByDefault: true,
+	Description:      "Collect the PagerDuty escalation policy of the service",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
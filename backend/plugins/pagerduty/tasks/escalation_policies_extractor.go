@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractEscalationPolicies
+
+func ExtractEscalationPolicies(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ESCALATION_POLICIES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			policyRaw := &raw.EscalationPolicy{}
+			err := errors.Convert(json.Unmarshal(row.Data, policyRaw))
+			if err != nil {
+				return nil, err
+			}
+			results := []interface{}{
+				&models.EscalationPolicy{
+					ConnectionId: data.Options.ConnectionId,
+					ServiceId:    data.Options.ServiceId,
+					Id:           policyRaw.Id,
+					Url:          policyRaw.HtmlUrl,
+					Name:         policyRaw.Name,
+					Description:  policyRaw.Description,
+					NumLoops:     policyRaw.NumLoops,
+				},
+			}
+			for i, rule := range policyRaw.EscalationRules {
+				for _, target := range rule.Targets {
+					results = append(results, &models.EscalationRule{
+						ConnectionId:             data.Options.ConnectionId,
+						ServiceId:                data.Options.ServiceId,
+						Id:                       rule.Id,
+						TargetId:                 target.Id,
+						TargetType:               target.Type,
+						TargetName:               target.Summary,
+						EscalationPolicyId:       policyRaw.Id,
+						Level:                    i + 1,
+						EscalationDelayInMinutes: rule.EscalationDelayInMinutes,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractEscalationPoliciesMeta = plugin.SubTaskMeta{
+	Name:             "extractEscalationPolicies",
+	EntryPoint:       ExtractEscalationPolicies,
+	EnabledByDefault: true,
+	Description:      "Extract the PagerDuty escalation policy and its rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+// buildIncidentResponse replays the log entries of an incident, which must be sorted by created_date, into
+// status and assignee changelogs and summarizes how the incident was acknowledged, escalated and reassigned.
+// The first acknowledgement counts for the time to acknowledge even if the incident was unacknowledged later
+func buildIncidentResponse(
+	issueId string,
+	incident *models.Incident,
+	logEntries []*models.LogEntry,
+	logEntryIdGen *didgen.DomainIdGenerator,
+) (*ticket.IncidentResponse, []*ticket.IssueChangelogs) {
+	triggeredDate := incident.CreatedDate
+	response := &ticket.IncidentResponse{
+		IssueId:       issueId,
+		TriggeredDate: &triggeredDate,
+	}
+	var changelogs []*ticket.IssueChangelogs
+	status := models.IncidentStatusTriggered
+	assigneeIds := ""
+	newChangelog := func(logEntry *models.LogEntry, field string) *ticket.IssueChangelogs {
+		changelog := &ticket.IssueChangelogs{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: logEntryIdGen.Generate(logEntry.ConnectionId, logEntry.Id),
+			},
+			IssueId:     issueId,
+			FieldId:     field,
+			FieldName:   field,
+			CreatedDate: logEntry.CreatedDate,
+		}
+		if logEntry.AgentType == "user_reference" {
+			changelog.AuthorId = logEntry.AgentId
+			changelog.AuthorName = logEntry.AgentName
+		}
+		changelogs = append(changelogs, changelog)
+		return changelog
+	}
+	changeStatus := func(logEntry *models.LogEntry, to models.IncidentStatus) {
+		if status == to {
+			return
+		}
+		changelog := newChangelog(logEntry, "status")
+		changelog.OriginalFromValue = string(status)
+		changelog.OriginalToValue = string(to)
+		changelog.FromValue = getStatus(&models.Incident{Status: status})
+		changelog.ToValue = getStatus(&models.Incident{Status: to})
+		status = to
+	}
+	changeAssignees := func(logEntry *models.LogEntry) {
+		if logEntry.AssigneeIds == "" || logEntry.AssigneeIds == assigneeIds {
+			return
+		}
+		changelog := newChangelog(logEntry, "assignee")
+		changelog.OriginalFromValue = assigneeIds
+		changelog.OriginalToValue = logEntry.AssigneeIds
+		assigneeIds = logEntry.AssigneeIds
+	}
+	for _, logEntry := range logEntries {
+		switch logEntry.Type {
+		case models.LogEntryTypeTrigger:
+			if logEntry.CreatedDate.Before(*response.TriggeredDate) {
+				triggeredDate := logEntry.CreatedDate
+				response.TriggeredDate = &triggeredDate
+			}
+			changeAssignees(logEntry)
+		case models.LogEntryTypeAcknowledge:
+			changeStatus(logEntry, models.IncidentStatusAcknowledged)
+			if response.AcknowledgedDate == nil {
+				acknowledgedDate := logEntry.CreatedDate
+				response.AcknowledgedDate = &acknowledgedDate
+				response.AcknowledgedBy = logEntry.AgentId
+			}
+		case models.LogEntryTypeUnacknowledge:
+			changeStatus(logEntry, models.IncidentStatusTriggered)
+		case models.LogEntryTypeResolve:
+			changeStatus(logEntry, models.IncidentStatusResolved)
+			resolvedDate := logEntry.CreatedDate
+			response.ResolvedDate = &resolvedDate
+		case models.LogEntryTypeAssign:
+			response.ReassignmentCount++
+			changeAssignees(logEntry)
+		case models.LogEntryTypeEscalate:
+			response.EscalationCount++
+			changeAssignees(logEntry)
+		}
+	}
+	if response.AcknowledgedDate != nil {
+		response.TimeToAcknowledgeMinutes = minutesBetween(*response.TriggeredDate, *response.AcknowledgedDate)
+	}
+	return response, changelogs
+}
+
+func minutesBetween(start, end time.Time) *uint {
+	minutes := uint(0)
+	if end.After(start) {
+		minutes = uint(end.Sub(start).Minutes())
+	}
+	return &minutes
+}
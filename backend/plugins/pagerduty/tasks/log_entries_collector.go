@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+const RAW_LOG_ENTRIES_TABLE = "pagerduty_log_entries"
+
+var _ plugin.SubTaskEntryPoint = CollectLogEntries
+
+type simplifiedIncident struct {
+	Number int `json:"number"`
+}
+
+func CollectLogEntries(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	collectorWithState, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: data.Options,
+		Table:   RAW_LOG_ENTRIES_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+	clauses := []dal.Clause{
+		dal.Select("number"),
+		dal.From(&models.Incident{}),
+		dal.Where("service_id = ? AND connection_id = ?", data.Options.ServiceId, data.Options.ConnectionId),
+	}
+	// only the incidents updated since the last collection might have new log entries
+	if collectorWithState.IsIncremental && collectorWithState.Since != nil {
+		clauses = append(clauses, dal.Where("updated_date >= ?", collectorWithState.Since))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(simplifiedIncident{}))
+	if err != nil {
+		return err
+	}
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.Client,
+		PageSize:    100,
+		Input:       iterator,
+		UrlTemplate: "incidents/{{ .Input.Number }}/log_entries",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("is_overview", "false")
+			query.Set("limit", fmt.Sprintf("%d", reqData.Pager.Size))
+			query.Set("offset", fmt.Sprintf("%d", reqData.Pager.Skip))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var rawResult struct {
+				pagingInfo
+				LogEntries []json.RawMessage `json:"log_entries"`
+			}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return rawResult.LogEntries, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+var CollectLogEntriesMeta = plugin.SubTaskMeta{
+	Name:             "collectLogEntries",
+	EntryPoint:       CollectLogEntries,
+	EnabledByDefault: true,
+	Description:      "Collect PagerDuty incident log entries",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
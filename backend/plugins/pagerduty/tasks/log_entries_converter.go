@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertLogEntries
+
+var ConvertLogEntriesMeta = plugin.SubTaskMeta{
+	Name:             "convertLogEntries",
+	EntryPoint:       ConvertLogEntries,
+	EnabledByDefault: true,
+	Description:      "Convert incident log entries into domain layer tables issue_changelogs and incident_responses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertLogEntries(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	cursor, err := db.Cursor(
+		dal.From(&models.Incident{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+		dal.Orderby("number ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	// the log entries are read along with the incidents in the same order, instead of being queried per incident
+	logEntriesCursor, err := db.Cursor(
+		dal.Select("le.*"),
+		dal.From("_tool_pagerduty_log_entries le"),
+		dal.Join("JOIN _tool_pagerduty_incidents i ON i.connection_id = le.connection_id AND i.number = le.incident_number"),
+		dal.Where("i.connection_id = ? AND i.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+		dal.Orderby("le.incident_number ASC, le.created_date ASC, le.id ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	defer logEntriesCursor.Close()
+	logEntriesReader := &incidentLogEntriesReader{db: db, cursor: logEntriesCursor}
+	idGen := didgen.NewDomainIdGenerator(&models.Incident{})
+	logEntryIdGen := didgen.NewDomainIdGenerator(&models.LogEntry{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_LOG_ENTRIES_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.Incident{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			incident := inputRow.(*models.Incident)
+			logEntries, err := logEntriesReader.read(incident.Number)
+			if err != nil {
+				return nil, err
+			}
+			if len(logEntries) == 0 {
+				return nil, nil
+			}
+			response, changelogs := buildIncidentResponse(
+				idGen.Generate(incident.ConnectionId, incident.Number),
+				incident,
+				logEntries,
+				logEntryIdGen,
+			)
+			results := make([]interface{}, 0, len(changelogs)+1)
+			results = append(results, response)
+			for _, changelog := range changelogs {
+				results = append(results, changelog)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// incidentLogEntriesReader reads the log entries of the incidents from a single cursor ordered by the incident number
+type incidentLogEntriesReader struct {
+	db     dal.Dal
+	cursor dal.Rows
+	next   *models.LogEntry
+}
+
+// read returns the log entries of the incident, incidents must be read in the ascending order of the number, the log
+// entries of the incidents skipped are skipped as well
+func (r *incidentLogEntriesReader) read(number int) ([]*models.LogEntry, errors.Error) {
+	var logEntries []*models.LogEntry
+	for {
+		if r.next == nil {
+			if !r.cursor.Next() {
+				return logEntries, nil
+			}
+			r.next = &models.LogEntry{}
+			err := r.db.Fetch(r.cursor, r.next)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// log entries of the next incident
+		if r.next.IncidentNumber > number {
+			return logEntries, nil
+		}
+		if r.next.IncidentNumber == number {
+			logEntries = append(logEntries, r.next)
+		}
+		r.next = nil
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractLogEntries
+
+func ExtractLogEntries(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_LOG_ENTRIES_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &simplifiedIncident{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			logEntryRaw := &raw.LogEntry{}
+			err = errors.Convert(json.Unmarshal(row.Data, logEntryRaw))
+			if err != nil {
+				return nil, err
+			}
+			logEntry := &models.LogEntry{
+				ConnectionId:   data.Options.ConnectionId,
+				Id:             logEntryRaw.Id,
+				IncidentNumber: input.Number,
+				Type:           logEntryRaw.Type,
+				Summary:        logEntryRaw.Summary,
+				ChannelType:    logEntryRaw.Channel.Type,
+				CreatedDate:    logEntryRaw.CreatedAt,
+			}
+			results := []interface{}{logEntry}
+			if logEntryRaw.Agent != nil {
+				logEntry.AgentId = logEntryRaw.Agent.Id
+				logEntry.AgentType = logEntryRaw.Agent.Type
+				logEntry.AgentName = logEntryRaw.Agent.Summary
+				if logEntryRaw.Agent.Type == "user_reference" {
+					results = append(results, &models.User{
+						ConnectionId: data.Options.ConnectionId,
+						Id:           logEntryRaw.Agent.Id,
+						Url:          logEntryRaw.Agent.HtmlUrl,
+						Name:         logEntryRaw.Agent.Summary,
+					})
+				}
+			}
+			assigneeIds := make([]string, 0, len(logEntryRaw.Assignees))
+			for _, assignee := range logEntryRaw.Assignees {
+				assigneeIds = append(assigneeIds, assignee.Id)
+			}
+			logEntry.AssigneeIds = strings.Join(assigneeIds, ",")
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+var ExtractLogEntriesMeta = plugin.SubTaskMeta{
+	Name:             "extractLogEntries",
+	EntryPoint:       ExtractLogEntries,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty incident log entries",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+const RAW_ONCALLS_TABLE = "pagerduty_oncalls"
+
+// onCallsWindow is the longest period the oncalls api accepts between since and until
+const onCallsWindow = 90 * 24 * time.Hour
+
+var _ plugin.SubTaskEntryPoint = CollectOnCalls
+
+type simplifiedEscalationPolicy struct {
+	Id string `json:"id"`
+}
+
+// CollectOnCalls collects who was on call through the escalation policy of the service in the last 90 days
+func CollectOnCalls(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("id"),
+		dal.From(&models.EscalationPolicy{}),
+		dal.Where("connection_id = ? AND service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(simplifiedEscalationPolicy{}))
+	if err != nil {
+		return err
+	}
+	until := time.Now().UTC().Truncate(time.Hour)
+	since := until.Add(-onCallsWindow)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		ApiClient:   data.Client,
+		PageSize:    100,
+		Input:       iterator,
+		UrlTemplate: "oncalls",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("escalation_policy_ids[]", reqData.Input.(*simplifiedEscalationPolicy).Id)
+			query.Set("since", since.Format(time.RFC3339))
+			query.Set("until", until.Format(time.RFC3339))
+			query.Set("limit", fmt.Sprintf("%d", reqData.Pager.Size))
+			query.Set("offset", fmt.Sprintf("%d", reqData.Pager.Skip))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var rawResult struct {
+				pagingInfo
+				OnCalls []json.RawMessage `json:"oncalls"`
+			}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return rawResult.OnCalls, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectOnCallsMeta = plugin.SubTaskMeta{
+	Name:             "collectOnCalls",
+	EntryPoint:       CollectOnCalls,
+	EnabledByDefault: true,
+	Description:      "Collect the PagerDuty on-call entries of the escalation policy of the service",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
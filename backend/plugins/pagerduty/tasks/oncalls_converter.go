@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertOnCalls
+
+var ConvertOnCallsMeta = plugin.SubTaskMeta{
+	Name:             "convertOnCalls",
+	EntryPoint:       ConvertOnCalls,
+	EnabledByDefault: true,
+	Description:      "Convert on-call entries into domain layer table oncall_shifts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type onCallWithNames struct {
+	models.OnCall
+	ScheduleName         string
+	EscalationPolicyName string
+}
+
+func ConvertOnCalls(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("oc.*, COALESCE(s.name, '') AS schedule_name, COALESCE(ep.name, '') AS escalation_policy_name"),
+		dal.From("_tool_pagerduty_oncalls oc"),
+		dal.Join(`LEFT JOIN _tool_pagerduty_schedules s ON s.connection_id = oc.connection_id AND s.id = oc.schedule_id`),
+		dal.Join(`LEFT JOIN _tool_pagerduty_escalation_policies ep
+			ON ep.connection_id = oc.connection_id AND ep.service_id = oc.service_id AND ep.id = oc.escalation_policy_id`),
+		dal.Where("oc.connection_id = ? AND oc.service_id = ?", data.Options.ConnectionId, data.Options.ServiceId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	idGen := didgen.NewDomainIdGenerator(&models.OnCall{})
+	serviceIdGen := didgen.NewDomainIdGenerator(&models.Service{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(onCallWithNames{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			onCall := inputRow.(*onCallWithNames)
+			start := onCall.Start
+			shift := &ticket.OnCallShift{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: idGen.Generate(
+						onCall.ConnectionId, onCall.ServiceId, onCall.EscalationPolicyId,
+						onCall.EscalationLevel, onCall.UserId, onCall.Start.UTC(),
+					),
+				},
+				BoardId:              serviceIdGen.Generate(onCall.ConnectionId, onCall.ServiceId),
+				ScheduleId:           onCall.ScheduleId,
+				ScheduleName:         onCall.ScheduleName,
+				EscalationPolicyId:   onCall.EscalationPolicyId,
+				EscalationPolicyName: onCall.EscalationPolicyName,
+				EscalationLevel:      onCall.EscalationLevel,
+				AccountId:            onCall.UserId,
+				AccountName:          onCall.UserName,
+				StartDate:            &start,
+				EndDate:              onCall.End,
+			}
+			if onCall.End != nil {
+				shift.DurationMinutes = minutesBetween(onCall.Start, *onCall.End)
+			}
+			return []interface{}{shift}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models/raw"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractOnCalls
+
+func ExtractOnCalls(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_ONCALLS_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			onCallRaw := &raw.OnCall{}
+			err := errors.Convert(json.Unmarshal(row.Data, onCallRaw))
+			if err != nil {
+				return nil, err
+			}
+			start := onCallRaw.Start
+			if start == nil {
+				// permanent on-calls have no start, they are counted from the beginning of the collected window
+				start, err = getOnCallsSince(row.Url)
+				if err != nil {
+					return nil, err
+				}
+			}
+			onCall := &models.OnCall{
+				ConnectionId:       data.Options.ConnectionId,
+				ServiceId:          data.Options.ServiceId,
+				EscalationPolicyId: onCallRaw.EscalationPolicy.Id,
+				EscalationLevel:    onCallRaw.EscalationLevel,
+				UserId:             onCallRaw.User.Id,
+				Start:              *start,
+				End:                onCallRaw.End,
+				UserName:           onCallRaw.User.Summary,
+			}
+			if onCallRaw.Schedule != nil {
+				onCall.ScheduleId = onCallRaw.Schedule.Id
+			}
+			return []interface{}{
+				onCall,
+				&models.User{
+					ConnectionId: data.Options.ConnectionId,
+					Id:           onCallRaw.User.Id,
+					Url:          onCallRaw.User.HtmlUrl,
+					Name:         onCallRaw.User.Summary,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func getOnCallsSince(rawUrl string) (*time.Time, errors.Error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	since, err := time.Parse(time.RFC3339, u.Query().Get("since"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to get the start of a permanent on-call")
+	}
+	return &since, nil
+}
+
+var ExtractOnCallsMeta = plugin.SubTaskMeta{
+	Name:             "extractOnCalls",
+	EntryPoint:       ExtractOnCalls,
+	EnabledByDefault: true,
+	Description:      "Extract PagerDuty on-call entries",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/pagerduty/models"
+)
+
+const RAW_SCHEDULES_TABLE = "pagerduty_schedules"
+
+var _ plugin.SubTaskEntryPoint = CollectSchedules
+
+type simplifiedSchedule struct {
+	Id string `json:"id"`
+}
+
+// CollectSchedules collects the schedules targeted by the escalation rules of the service
+func CollectSchedules(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*PagerDutyTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT r.target_id AS id"),
+		dal.From("_tool_pagerduty_escalation_rules r"),
+		dal.Where(
+			"r.connection_id = ? AND r.service_id = ? AND r.target_type = ?",
+			data.Options.ConnectionId, data.Options.ServiceId, models.EscalationTargetTypeSchedule,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(simplifiedSchedule{}))
+	if err != nil {
+		return err
+	}
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_SCHEDULES_TABLE,
+		},
+		ApiClient:   data.Client,
+		Input:       iterator,
+		UrlTemplate: "schedules/{{ .Input.Id }}",
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var rawResult struct {
+				Schedule json.RawMessage `json:"schedule"`
+			}
+			err := api.UnmarshalResponse(res, &rawResult)
+			return []json.RawMessage{rawResult.Schedule}, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+var CollectSchedulesMeta = plugin.SubTaskMeta{
+	Name:             "collectSchedules",
+	EntryPoint:       CollectSchedules,
+	EnabledByDefault: true,
+	Description:      "Collect the PagerDuty on-call schedules used by the escalation policy of the service",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -55,6 +55,7 @@ func (p Slack) GetTablesInfo() []dal.Tabler {
 		&models.SlackConnection{},
 		&models.SlackChannelMessage{},
 		&models.SlackChannel{},
+		&models.SlackMessageReaction{},
 	}
 }
 
@@ -88,6 +89,12 @@ func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectThreadMeta,
 		tasks.ExtractThreadMeta,
+
+		tasks.ConvertChannelMeta,
+		tasks.ConvertChannelMessageMeta,
+		tasks.ConvertMessageReactionMeta,
+		tasks.ConvertThreadMeta,
+		tasks.ConvertChannelParticipantMeta,
 	}
 }
 
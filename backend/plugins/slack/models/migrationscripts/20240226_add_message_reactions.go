@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type slackMessageReaction20240226 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChannelId    string `gorm:"primaryKey;type:varchar(255)"`
+	MessageTs    string `gorm:"primaryKey;type:varchar(100)"`
+	Name         string `gorm:"primaryKey;type:varchar(100)"`
+	User         string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (slackMessageReaction20240226) TableName() string {
+	return "_tool_slack_message_reactions"
+}
+
+type addMessageReactions struct{}
+
+func (*addMessageReactions) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &slackMessageReaction20240226{})
+}
+
+func (*addMessageReactions) Version() uint64 {
+	return 20240226000001
+}
+
+func (*addMessageReactions) Name() string {
+	return "add _tool_slack_message_reactions"
+}
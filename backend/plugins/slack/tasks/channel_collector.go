@@ -88,4 +88,5 @@ var CollectChannelMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectChannel,
 	EnabledByDefault: true,
 	Description:      "Collect channels from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
 }
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertChannel
+
+func ConvertChannel(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannel{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: SlackApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_CHANNEL_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackChannel{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			channel := inputRow.(*models.SlackChannel)
+			chatChannel := &communication.ChatChannel{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: channelIdGen.Generate(channel.ConnectionId, channel.Id),
+				},
+				Name:       channel.Name,
+				Type:       communication.CHANNEL_TYPE_CHANNEL,
+				IsPrivate:  channel.IsPrivate,
+				IsArchived: channel.IsArchived,
+				CreatorId:  channel.Creator,
+			}
+			if channel.IsIm {
+				chatChannel.Type = communication.CHANNEL_TYPE_DIRECT
+			} else if channel.IsMpim {
+				chatChannel.Type = communication.CHANNEL_TYPE_GROUP
+			}
+			if channel.Created > 0 {
+				createdDate := time.Unix(int64(channel.Created), 0).UTC()
+				chatChannel.CreatedDate = &createdDate
+			}
+			return []interface{}{chatChannel}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertChannelMeta = plugin.SubTaskMeta{
+	Name:             "convertChannel",
+	EntryPoint:       ConvertChannel,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_slack_channels into domain layer table chat_channels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
+}
@@ -59,4 +59,5 @@ var ExtractChannelMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractChannel,
 	EnabledByDefault: true,
 	Description:      "Extract raw channel data into tool layer table",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
 }
@@ -67,7 +67,9 @@ func ExtractChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
 			message.IsLocked = body.IsLocked
 			message.Subscribed = body.Subscribed
 			message.ParentUserId = body.ParentUserId
-			return []interface{}{message}, nil
+			results := []interface{}{message}
+			results = append(results, extractReactions(message, body)...)
+			return results, nil
 		},
 	})
 	if err != nil {
@@ -82,4 +84,5 @@ var ExtractChannelMessageMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractChannelMessage,
 	EnabledByDefault: true,
 	Description:      "Extract raw channel messages data into tool layer table",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
 }
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/communication"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMessageReaction
+
+func ConvertMessageReaction(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackMessageReaction{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: SlackApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_CHANNEL_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackMessageReaction{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			reaction := inputRow.(*models.SlackMessageReaction)
+			return []interface{}{
+				&communication.ChatMessageReaction{
+					MessageId: messageIdGen.Generate(reaction.ConnectionId, reaction.ChannelId, reaction.MessageTs),
+					Name:      reaction.Name,
+					AuthorId:  reaction.User,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertMessageReactionMeta = plugin.SubTaskMeta{
+	Name:             "convertMessageReaction",
+	EntryPoint:       ConvertMessageReaction,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_slack_message_reactions into domain layer table chat_message_reactions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
+}
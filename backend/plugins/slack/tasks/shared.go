@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/plugins/slack/apimodels"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var channelIdGen = didgen.NewDomainIdGenerator(&models.SlackChannel{})
+var messageIdGen = didgen.NewDomainIdGenerator(&models.SlackChannelMessage{})
+
+// tsToTime converts a slack message timestamp like `1681199383.123456` to time
+func tsToTime(ts string) time.Time {
+	seconds, micros, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	usec, _ := strconv.ParseInt(micros, 10, 64)
+	return time.Unix(sec, usec*int64(time.Microsecond)).UTC()
+}
+
+func extractReactions(message *models.SlackChannelMessage, body *apimodels.SlackChannelMessageResultItem) []interface{} {
+	var results []interface{}
+	for _, reaction := range body.Reactions {
+		for _, user := range reaction.Users {
+			results = append(results, &models.SlackMessageReaction{
+				ConnectionId: message.ConnectionId,
+				ChannelId:    message.ChannelId,
+				MessageTs:    message.Ts,
+				Name:         reaction.Name,
+				User:         user,
+			})
+		}
+	}
+	return results
+}
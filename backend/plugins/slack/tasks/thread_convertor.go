@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/chathelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertThread
+
+func ConvertThread(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	return chathelper.ConvertThreads(api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: SlackApiParams{
+			ConnectionId: data.Options.ConnectionId,
+		},
+		Table: RAW_THREAD_TABLE,
+	}, RAW_CHANNEL_MESSAGE_TABLE, RAW_THREAD_TABLE)
+}
+
+var ConvertThreadMeta = plugin.SubTaskMeta{
+	Name:             "convertThread",
+	EntryPoint:       ConvertThread,
+	EnabledByDefault: true,
+	Description:      "Summarize threads of domain layer table chat_messages into chat_threads",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_COMMUNICATION},
+}
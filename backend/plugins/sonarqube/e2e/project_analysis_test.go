@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/impl"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/tasks"
+)
+
+func TestSonarqubeProjectAnalysisDataFlow(t *testing.T) {
+
+	var sonarqube impl.Sonarqube
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "sonarqube", sonarqube)
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_project_analyses.csv",
+		"_raw_sonarqube_api_project_analyses")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_measure_history.csv",
+		"_raw_sonarqube_api_measure_history")
+
+	// Standard data
+	taskData := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 1,
+			ProjectKey:   "fa2cf9cd-c448-4fc3-99a5-1c893f15d84c",
+		},
+		TaskStartTime: time.Now(),
+	}
+	// Interfered data
+	taskData2 := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 2,
+			ProjectKey:   "e2c6d5e9-a321-4e8c-b322-03d9599ef962",
+		},
+		TaskStartTime: time.Now(),
+	}
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SonarqubeProjectAnalysis{})
+	dataflowTester.FlushTabler(&models.SonarqubeMeasureHistory{})
+	dataflowTester.Subtask(tasks.ExtractProjectAnalysesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractProjectAnalysesMeta, taskData2)
+	dataflowTester.Subtask(tasks.ExtractMeasureHistoryMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractMeasureHistoryMeta, taskData2)
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeProjectAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_project_analyses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeMeasureHistory{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_measure_histories.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify convertor
+	dataflowTester.FlushTabler(&codequality.CqProjectAnalysis{})
+	dataflowTester.Subtask(tasks.ConvertProjectAnalysesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqProjectAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/project_analyses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
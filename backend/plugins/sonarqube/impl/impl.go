@@ -85,6 +85,8 @@ func (p Sonarqube) GetTablesInfo() []dal.Tabler {
 		&models.SonarqubeFileMetrics{},
 		&models.SonarqubeAccount{},
 		&models.SonarqubeScopeConfig{},
+		&models.SonarqubeProjectAnalysis{},
+		&models.SonarqubeMeasureHistory{},
 	}
 }
 
@@ -100,12 +102,17 @@ func (p Sonarqube) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractHotspotsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
+		tasks.CollectProjectAnalysesMeta,
+		tasks.ExtractProjectAnalysesMeta,
+		tasks.CollectMeasureHistoryMeta,
+		tasks.ExtractMeasureHistoryMeta,
 		tasks.ConvertProjectsMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueCodeBlocksMeta,
 		tasks.ConvertHotspotsMeta,
 		tasks.ConvertFileMetricsMeta,
 		tasks.ConvertAccountsMeta,
+		tasks.ConvertProjectAnalysesMeta,
 	}
 }
 
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectAnalyses)(nil)
+
+type sonarqubeProjectAnalysis20240303 struct {
+	ConnectionId                uint64 `gorm:"primaryKey"`
+	AnalysisKey                 string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectKey                  string `gorm:"index;type:varchar(255)"`
+	Date                        *time.Time
+	ProjectVersion              string `gorm:"type:varchar(255)"`
+	BuildString                 string `gorm:"type:varchar(255)"`
+	Revision                    string `gorm:"type:varchar(128)"`
+	QualityGateEvent            string `gorm:"type:varchar(100)"`
+	QualityGateEventDescription string
+	archived.NoPKModel
+}
+
+func (sonarqubeProjectAnalysis20240303) TableName() string {
+	return "_tool_sonarqube_project_analyses"
+}
+
+type sonarqubeMeasureHistory20240303 struct {
+	ConnectionId uint64    `gorm:"primaryKey"`
+	ProjectKey   string    `gorm:"primaryKey;type:varchar(255)"`
+	Metric       string    `gorm:"primaryKey;type:varchar(100)"`
+	Date         time.Time `gorm:"primaryKey"`
+	Value        string    `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (sonarqubeMeasureHistory20240303) TableName() string {
+	return "_tool_sonarqube_measure_histories"
+}
+
+type addProjectAnalyses struct{}
+
+func (*addProjectAnalyses) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sonarqubeProjectAnalysis20240303{},
+		&sonarqubeMeasureHistory20240303{},
+	)
+}
+
+func (*addProjectAnalyses) Version() uint64 {
+	return 20240303000001
+}
+
+func (*addProjectAnalyses) Name() string {
+	return "add _tool_sonarqube_project_analyses and _tool_sonarqube_measure_histories"
+}
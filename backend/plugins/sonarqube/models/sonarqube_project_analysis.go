@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type SonarqubeProjectAnalysis struct {
+	ConnectionId                uint64 `gorm:"primaryKey"`
+	AnalysisKey                 string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectKey                  string `gorm:"index;type:varchar(255)"`
+	Date                        *common.Iso8601Time
+	ProjectVersion              string `gorm:"type:varchar(255)"`
+	BuildString                 string `gorm:"type:varchar(255)"`
+	Revision                    string `gorm:"type:varchar(128)"`
+	QualityGateEvent            string `gorm:"type:varchar(100)"`
+	QualityGateEventDescription string
+	common.NoPKModel
+}
+
+func (SonarqubeProjectAnalysis) TableName() string {
+	return "_tool_sonarqube_project_analyses"
+}
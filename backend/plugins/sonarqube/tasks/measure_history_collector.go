@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_MEASURE_HISTORY_TABLE = "sonarqube_api_measure_history"
+
+// historyMetrics are the project level metrics tracked for every analysis
+var historyMetrics = []string{
+	"alert_status",
+	"bugs",
+	"vulnerabilities",
+	"code_smells",
+	"security_hotspots",
+	"sqale_index",
+	"sqale_rating",
+	"reliability_rating",
+	"security_rating",
+	"coverage",
+	"lines_to_cover",
+	"uncovered_lines",
+	"duplicated_lines_density",
+	"ncloc",
+}
+
+var _ plugin.SubTaskEntryPoint = CollectMeasureHistory
+
+func CollectMeasureHistory(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	logger.Info("collect measure history")
+
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_MEASURE_HISTORY_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:     data.ApiClient,
+		PageSize:      1000,
+		UrlTemplate:   "measures/search_history",
+		GetTotalPages: GetTotalPagesFromResponse,
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			/* SONAR CLOUD */
+			sonarCloudOrganization := os.Getenv("ENV_CUSTOM_SONAR_ORGANIZATION")
+			if sonarCloudOrganization != "" {
+				query.Set("organization", sonarCloudOrganization)
+			}
+			query.Set("component", data.Options.ProjectKey)
+			query.Set("metrics", strings.Join(historyMetrics, ","))
+			if collectorWithState.IsIncremental && collectorWithState.Since != nil {
+				query.Set("from", collectorWithState.Since.Format(SONAR_DATETIME_FORMAT))
+			}
+			query.Set("p", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("ps", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var resData struct {
+				Data []json.RawMessage `json:"measures"`
+			}
+			err := helper.UnmarshalResponse(res, &resData)
+			return resData.Data, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+var CollectMeasureHistoryMeta = plugin.SubTaskMeta{
+	Name:             "CollectMeasureHistory",
+	EntryPoint:       CollectMeasureHistory,
+	EnabledByDefault: true,
+	Description:      "Collect project measure history from Sonarqube api, supports incremental data collection",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
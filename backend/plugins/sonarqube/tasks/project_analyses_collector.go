@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PROJECT_ANALYSES_TABLE = "sonarqube_api_project_analyses"
+
+// SONAR_DATETIME_FORMAT is the datetime format accepted by the `from` parameter of sonarqube apis
+const SONAR_DATETIME_FORMAT = "2006-01-02T15:04:05-0700"
+
+var _ plugin.SubTaskEntryPoint = CollectProjectAnalyses
+
+// CollectProjectAnalyses collects analyses incrementally, so the ones removed by the housekeeping of sonarqube are kept
+func CollectProjectAnalyses(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	logger.Info("collect project analyses")
+
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_ANALYSES_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:     data.ApiClient,
+		PageSize:      100,
+		UrlTemplate:   "project_analyses/search",
+		GetTotalPages: GetTotalPagesFromResponse,
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			/* SONAR CLOUD */
+			sonarCloudOrganization := os.Getenv("ENV_CUSTOM_SONAR_ORGANIZATION")
+			if sonarCloudOrganization != "" {
+				query.Set("organization", sonarCloudOrganization)
+			}
+			query.Set("project", data.Options.ProjectKey)
+			if collectorWithState.IsIncremental && collectorWithState.Since != nil {
+				query.Set("from", collectorWithState.Since.Format(SONAR_DATETIME_FORMAT))
+			}
+			query.Set("p", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("ps", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var resData struct {
+				Data []json.RawMessage `json:"analyses"`
+			}
+			err := helper.UnmarshalResponse(res, &resData)
+			return resData.Data, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+var CollectProjectAnalysesMeta = plugin.SubTaskMeta{
+	Name:             "CollectProjectAnalyses",
+	EntryPoint:       CollectProjectAnalyses,
+	EnabledByDefault: true,
+	Description:      "Collect project analyses data from Sonarqube api, supports incremental data collection",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertProjectAnalysesMeta = plugin.SubTaskMeta{
+	Name:             "convertProjectAnalyses",
+	EntryPoint:       ConvertProjectAnalyses,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table sonarqube_project_analyses and sonarqube_measure_histories into domain layer table cq_project_analyses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+func ConvertProjectAnalyses(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_ANALYSES_TABLE)
+
+	// measures of an analysis are recorded with the date of the analysis
+	var histories []sonarqubeModels.SonarqubeMeasureHistory
+	err := db.All(&histories,
+		dal.Where("connection_id = ? and project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	measuresByDate := make(map[int64][]Measure)
+	for _, history := range histories {
+		unix := history.Date.Unix()
+		measuresByDate[unix] = append(measuresByDate[unix], Measure{Metric: history.Metric, Value: history.Value})
+	}
+
+	cursor, err := db.Cursor(dal.From(sonarqubeModels.SonarqubeProjectAnalysis{}),
+		dal.Where("connection_id = ? and project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	analysisIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProjectAnalysis{})
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(sonarqubeModels.SonarqubeProjectAnalysis{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			sonarqubeAnalysis := inputRow.(*sonarqubeModels.SonarqubeProjectAnalysis)
+			domainAnalysis := &codequality.CqProjectAnalysis{
+				DomainEntity:   domainlayer.DomainEntity{Id: analysisIdGen.Generate(data.Options.ConnectionId, sonarqubeAnalysis.AnalysisKey)},
+				ProjectKey:     projectIdGen.Generate(data.Options.ConnectionId, sonarqubeAnalysis.ProjectKey),
+				AnalysisDate:   sonarqubeAnalysis.Date.ToNullableTime(),
+				CommitSha:      sonarqubeAnalysis.Revision,
+				ProjectVersion: sonarqubeAnalysis.ProjectVersion,
+			}
+			if sonarqubeAnalysis.Date != nil {
+				err := setAnalysisMeasures(domainAnalysis, measuresByDate[sonarqubeAnalysis.Date.ToTime().Unix()])
+				if err != nil {
+					return nil, err
+				}
+			}
+			if domainAnalysis.QualityGateStatus == "" {
+				domainAnalysis.QualityGateStatus = getQualityGateStatusFromEvent(sonarqubeAnalysis.QualityGateEvent)
+			}
+			return []interface{}{
+				domainAnalysis,
+			}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func setAnalysisMeasures(analysis *codequality.CqProjectAnalysis, measures []Measure) errors.Error {
+	var err errors.Error
+	for _, v := range measures {
+		switch v.Metric {
+		case "alert_status":
+			analysis.QualityGateStatus = v.Value
+		case "reliability_rating":
+			analysis.ReliabilityRating = alphabetMap[v.Value]
+		case "security_rating":
+			analysis.SecurityRating = alphabetMap[v.Value]
+		case "sqale_rating":
+			analysis.SqaleRating, err = parseFloatMeasure(v.Value)
+		case "coverage":
+			analysis.Coverage, err = parseFloatMeasure(v.Value)
+		case "duplicated_lines_density":
+			analysis.DuplicatedLinesDensity, err = parseFloatMeasure(v.Value)
+		case "bugs":
+			analysis.Bugs, err = parseIntMeasure(v.Value)
+		case "vulnerabilities":
+			analysis.Vulnerabilities, err = parseIntMeasure(v.Value)
+		case "code_smells":
+			analysis.CodeSmells, err = parseIntMeasure(v.Value)
+		case "security_hotspots":
+			analysis.SecurityHotspots, err = parseIntMeasure(v.Value)
+		case "sqale_index":
+			analysis.SqaleIndex, err = parseIntMeasure(v.Value)
+		case "lines_to_cover":
+			analysis.LinesToCover, err = parseIntMeasure(v.Value)
+		case "uncovered_lines":
+			analysis.UncoveredLines, err = parseIntMeasure(v.Value)
+		case "ncloc":
+			analysis.Ncloc, err = parseIntMeasure(v.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseIntMeasure(value string) (*int, errors.Error) {
+	i, err := errors.Convert01(strconv.Atoi(value))
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func parseFloatMeasure(value string) (*float64, errors.Error) {
+	f, err := errors.Convert01(strconv.ParseFloat(value, 64))
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// getQualityGateStatusFromEvent is the fallback when the alert_status measure is missing, the event name is the new
+// status of the quality gate, such as `Red (was Green)` or `Passed`
+func getQualityGateStatusFromEvent(event string) string {
+	status, _, _ := strings.Cut(strings.ToLower(event), " ")
+	switch status {
+	case "green", "passed":
+		return codequality.QUALITY_GATE_OK
+	case "orange", "warning":
+		return codequality.QUALITY_GATE_WARN
+	case "red", "failed":
+		return codequality.QUALITY_GATE_ERROR
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAnalysisMeasures(t *testing.T) {
+	analysis := &codequality.CqProjectAnalysis{}
+	err := setAnalysisMeasures(analysis, []Measure{
+		{Metric: "alert_status", Value: "ERROR"},
+		{Metric: "bugs", Value: "5"},
+		{Metric: "coverage", Value: "76.2"},
+		{Metric: "security_rating", Value: "4.0"},
+		{Metric: "unknown_metric", Value: "whatever"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, codequality.QUALITY_GATE_ERROR, analysis.QualityGateStatus)
+	assert.Equal(t, 5, *analysis.Bugs)
+	assert.Equal(t, 76.2, *analysis.Coverage)
+	assert.Equal(t, "D", analysis.SecurityRating)
+	assert.Nil(t, analysis.Vulnerabilities)
+
+	err = setAnalysisMeasures(analysis, []Measure{{Metric: "ncloc", Value: "n/a"}})
+	assert.NotNil(t, err)
+}
+
+func TestGetQualityGateStatusFromEvent(t *testing.T) {
+	testCases := map[string]string{
+		"Red (was Green)":     codequality.QUALITY_GATE_ERROR,
+		"Green (was Red)":     codequality.QUALITY_GATE_OK,
+		"Orange (was Green)":  codequality.QUALITY_GATE_WARN,
+		"Passed":              codequality.QUALITY_GATE_OK,
+		"Failed":              codequality.QUALITY_GATE_ERROR,
+		"":                    "",
+		"Quality Gate Change": "",
+	}
+	for event, expected := range testCases {
+		assert.Equal(t, expected, getQualityGateStatusFromEvent(event), event)
+	}
+}
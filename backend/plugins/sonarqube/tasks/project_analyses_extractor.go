@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractProjectAnalyses
+
+func ExtractProjectAnalyses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_ANALYSES_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(resData *helper.RawData) ([]interface{}, errors.Error) {
+			var res struct {
+				Key            string              `json:"key"`
+				Date           *common.Iso8601Time `json:"date"`
+				ProjectVersion string              `json:"projectVersion"`
+				BuildString    string              `json:"buildString"`
+				Revision       string              `json:"revision"`
+				Events         []struct {
+					Category    string `json:"category"`
+					Name        string `json:"name"`
+					Description string `json:"description"`
+				} `json:"events"`
+			}
+			err := errors.Convert(json.Unmarshal(resData.Data, &res))
+			if err != nil {
+				return nil, err
+			}
+			body := &models.SonarqubeProjectAnalysis{
+				ConnectionId:   data.Options.ConnectionId,
+				AnalysisKey:    res.Key,
+				ProjectKey:     data.Options.ProjectKey,
+				Date:           res.Date,
+				ProjectVersion: res.ProjectVersion,
+				BuildString:    res.BuildString,
+				Revision:       res.Revision,
+			}
+			for _, event := range res.Events {
+				if event.Category == "QUALITY_GATE" {
+					body.QualityGateEvent = event.Name
+					body.QualityGateEventDescription = event.Description
+				}
+			}
+			return []interface{}{body}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+var ExtractProjectAnalysesMeta = plugin.SubTaskMeta{
+	Name:             "ExtractProjectAnalyses",
+	EntryPoint:       ExtractProjectAnalyses,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table sonarqube_project_analyses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
@@ -52,6 +52,7 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 			"cq_issue_code_blocks",
 			"cq_issues",
 			"cq_projects",
+			"cq_project_analyses",
 		}
 	case "crossdomain":
 		return []string{
@@ -93,6 +94,14 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 			"sprints",
 			"sprint_issues",
 			"issue_worklogs",
+			"issue_status_intervals",
+			"issue_flow_metrics",
+			"sprint_analytics",
+			"sprint_issue_analytics",
+			"sprint_burndowns",
+			"issue_hierarchies",
+			"incident_responses",
+			"oncall_shifts",
 		}
 	}
 	return nil
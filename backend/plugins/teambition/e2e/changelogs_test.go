@@ -56,6 +56,13 @@ func TestTeambitionChangelogs(t *testing.T) {
 		},
 	)
 
+	dataflowTester.FlushTabler(&models.TeambitionTask{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_teambition_tasks.csv",
+		&models.TeambitionTask{})
+	dataflowTester.FlushTabler(&models.TeambitionTaskFlowStatus{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_teambition_task_flow_status.csv",
+		&models.TeambitionTaskFlowStatus{})
+
 	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
 	dataflowTester.Subtask(tasks.ConvertTaskChangelogMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(
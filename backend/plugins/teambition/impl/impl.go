@@ -100,7 +100,6 @@ func (p Teambition) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectTaskActivitiesMeta,
 		tasks.ExtractTaskActivitiesMeta,
 		tasks.ConvertTaskCommentsMeta,
-		tasks.ConvertTaskChangelogMeta,
 		tasks.CollectTaskWorktimeMeta,
 		tasks.ExtractTaskWorktimeMeta,
 		tasks.ConvertTaskWorktimeMeta,
@@ -112,6 +111,7 @@ func (p Teambition) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertSprintsMeta,
 		tasks.CollectTaskFlowStatusMeta,
 		tasks.ExtractTaskFlowStatusMeta,
+		tasks.ConvertTaskChangelogMeta,
 		tasks.CollectTaskScenariosMeta,
 		tasks.ExtractTaskScenariosMeta,
 		tasks.ConvertTasksMeta,
@@ -21,6 +21,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/teambition/models"
@@ -127,6 +128,23 @@ func getStatusMapping(data *TeambitionTaskData) map[string]string {
 	return statusMapping
 }
 
+// getStdStatus returns the standard status of the task flow status, the user defined mapping takes
+// precedence over the kind of the status
+func getStdStatus(stdStatusMappings map[string]string, status *models.TeambitionTaskFlowStatus) string {
+	if v, ok := stdStatusMappings[status.Name]; ok {
+		return v
+	}
+	switch status.Kind {
+	case "start":
+		return ticket.TODO
+	case "unset":
+		return ticket.IN_PROGRESS
+	case "end":
+		return ticket.DONE
+	}
+	return ""
+}
+
 func FindAccountById(db dal.Dal, accountId string) (*models.TeambitionAccount, errors.Error) {
 	if accountId == "" {
 		return nil, errors.Default.New("account id must not empty")
@@ -18,6 +18,7 @@ limitations under the License.
 package tasks
 
 import (
+	"encoding/json"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
@@ -46,6 +47,11 @@ func ConvertTaskChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
 	}
 
+	statusResolver, err := newTaskFlowStatusResolver(db, data)
+	if err != nil {
+		return err
+	}
+
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
 		return err
@@ -67,6 +73,19 @@ func ConvertTaskChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 				FieldId:         userTool.Action,
 				OriginalToValue: userTool.Content,
 			}
+			if userTool.Action == "update.taskflowstatus" {
+				content := &taskFlowStatusActivityContent{}
+				if err := json.Unmarshal([]byte(userTool.Content), content); err == nil {
+					issueComment.FieldName = "status"
+					issueComment.OriginalFromValue = content.OldTaskflowstatus
+					issueComment.OriginalToValue = content.Taskflowstatus
+					issueComment.FromValue = statusResolver.resolve(userTool.TaskId, content.OldTaskflowstatus)
+					issueComment.ToValue = statusResolver.resolve(userTool.TaskId, content.Taskflowstatus)
+					if issueComment.ToValue == "" && content.IsDone {
+						issueComment.ToValue = ticket.DONE
+					}
+				}
+			}
 			return []interface{}{
 				issueComment,
 			}, nil
@@ -79,3 +98,65 @@ func ConvertTaskChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return converter.Execute()
 }
+
+type taskFlowStatusActivityContent struct {
+	IsDone            bool   `json:"isDone"`
+	Taskflowstatus    string `json:"taskflowstatus"`
+	OldTaskflowstatus string `json:"oldTaskflowstatus"`
+}
+
+// taskFlowStatusResolver maps the status names found in the activities to standard statuses, the activities
+// only carry the name of the status, which is looked up in the task flow the task currently belongs to
+type taskFlowStatusResolver struct {
+	stdStatusMappings map[string]string
+	taskFlowIds       map[string]string
+	statuses          map[string]*models.TeambitionTaskFlowStatus
+}
+
+func newTaskFlowStatusResolver(db dal.Dal, data *TeambitionTaskData) (*taskFlowStatusResolver, errors.Error) {
+	var statuses []*models.TeambitionTaskFlowStatus
+	err := db.All(&statuses, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*models.TeambitionTask
+	err = db.All(&tasks, dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	resolver := &taskFlowStatusResolver{
+		stdStatusMappings: getStatusMapping(data),
+		taskFlowIds:       make(map[string]string),
+		statuses:          make(map[string]*models.TeambitionTaskFlowStatus),
+	}
+	flowIds := make(map[string]string)
+	for _, status := range statuses {
+		flowIds[status.Id] = status.TaskflowId
+		resolver.statuses[status.TaskflowId+":"+status.Name] = status
+		// fallback for tasks whose task flow is unknown, the first status of the name wins
+		if _, ok := resolver.statuses[":"+status.Name]; !ok {
+			resolver.statuses[":"+status.Name] = status
+		}
+	}
+	for _, task := range tasks {
+		resolver.taskFlowIds[task.Id] = flowIds[task.TfsId]
+	}
+	return resolver, nil
+}
+
+func (r *taskFlowStatusResolver) resolve(taskId string, name string) string {
+	if name == "" {
+		return ""
+	}
+	status, ok := r.statuses[r.taskFlowIds[taskId]+":"+name]
+	if !ok {
+		status, ok = r.statuses[":"+name]
+	}
+	if !ok {
+		if v, ok := r.stdStatusMappings[name]; ok {
+			return v
+		}
+		return ""
+	}
+	return getStdStatus(r.stdStatusMappings, status)
+}
@@ -100,18 +100,7 @@ func ConvertTasks(taskCtx plugin.SubTaskContext) errors.Error {
 			stdStatusMappings := getStatusMapping(data)
 			if taskflowstatus, err := FindTaskFlowStatusById(db, userTool.TfsId); err == nil {
 				issue.OriginalStatus = taskflowstatus.Name
-				if v, ok := stdStatusMappings[taskflowstatus.Name]; ok {
-					issue.Status = v
-				} else {
-					switch taskflowstatus.Kind {
-					case "start":
-						issue.Status = ticket.TODO
-					case "unset":
-						issue.Status = ticket.IN_PROGRESS
-					case "end":
-						issue.Status = ticket.DONE
-					}
-				}
+				issue.Status = getStdStatus(stdStatusMappings, taskflowstatus)
 			}
 			stdTypeMappings := getStdTypeMappings(data)
 			if scenario, err := FindTaskScenarioById(db, userTool.SfcId); err == nil {
@@ -63,7 +63,7 @@ func makeScopeV200(connectionId uint64, scopes []*coreModels.BlueprintScope) ([]
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
 			domainBoard := &ticket.Board{
 				DomainEntity: domainlayer.DomainEntity{
-					Id: didgen.NewDomainIdGenerator(&models.TrelloConnection{}).Generate(trelloBoard.ConnectionId, trelloBoard.BoardId),
+					Id: didgen.NewDomainIdGenerator(&models.TrelloBoard{}).Generate(trelloBoard.ConnectionId, trelloBoard.BoardId),
 				},
 				Name: trelloBoard.Name,
 			}
@@ -87,14 +87,14 @@ func makePipelinePlanV200(
 		}
 
 		// construct task options for trello
-		options := make(map[string]interface{})
-		options["connectionId"] = connectionId
-		options["scopeId"] = scope.ScopeId
-
-		_, scopeConfig, err := scopeHelper.DbHelper().GetScopeAndConfig(connectionId, scope.ScopeId)
+		trelloBoard, scopeConfig, err := scopeHelper.DbHelper().GetScopeAndConfig(connectionId, scope.ScopeId)
 		if err != nil {
 			return nil, err
 		}
+		options := make(map[string]interface{})
+		options["connectionId"] = connectionId
+		options["scopeId"] = scope.ScopeId
+		options["scopeConfigId"] = trelloBoard.ScopeConfigId
 		// construct subtasks
 		subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, scopeConfig.Entities)
 		if err != nil {
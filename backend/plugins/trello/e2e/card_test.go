@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -34,11 +35,23 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		Options: &tasks.TrelloOptions{
 			ConnectionId: 1,
 			BoardId:      "6402f643d23aa9af56b28f4b",
+			ScopeConfig: &models.TrelloScopeConfig{
+				StatusMappings: map[string]string{
+					"🗒 Backlog":                        "TODO",
+					"📅 Working On":                     "IN_PROGRESS",
+					"📆 Sprint - Done [Version: 1.2.0]": "DONE",
+					"🗄 Sprint - Done [Version: 1.1.0]": "DONE",
+				},
+			},
 		},
 	}
 
 	// import raw data table
 	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_cards.csv", "_raw_trello_cards")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_lists.csv", "_raw_trello_lists")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_labels.csv", "_raw_trello_labels")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_members.csv", "_raw_trello_members")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_trello_card_movements.csv", "_raw_trello_card_movements")
 
 	// verify extraction
 	dataflowTester.FlushTabler(&models.TrelloCard{})
@@ -47,4 +60,39 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/_tool_trello_cards.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// prepare the lists, labels, members and movements referenced by cards
+	dataflowTester.FlushTabler(&models.TrelloList{})
+	dataflowTester.FlushTabler(&models.TrelloLabel{})
+	dataflowTester.FlushTabler(&models.TrelloMember{})
+	dataflowTester.FlushTabler(&models.TrelloCardMovement{})
+	dataflowTester.Subtask(tasks.ExtractListMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractLabelMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractMemberMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractCardMovementMeta, taskData)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.Subtask(tasks.ConvertCardMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues_card.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues_card.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IssueAssignee{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_assignees.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertCardLabelMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
@@ -61,6 +61,7 @@ func (p Trello) GetTablesInfo() []dal.Tabler {
 		&models.TrelloLabel{},
 		&models.TrelloMember{},
 		&models.TrelloCheckItem{},
+		&models.TrelloCardMovement{},
 		&models.TrelloScopeConfig{},
 	}
 }
@@ -89,6 +90,16 @@ func (p Trello) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectMemberMeta,
 		tasks.ExtractMemberMeta,
+
+		tasks.CollectCardMovementMeta,
+		tasks.ExtractCardMovementMeta,
+
+		tasks.ConvertBoardMeta,
+		tasks.ConvertMemberMeta,
+		tasks.ConvertCardMeta,
+		tasks.ConvertCardLabelMeta,
+		tasks.ConvertCheckItemMeta,
+		tasks.ConvertCardMovementMeta,
 	}
 }
 
@@ -123,6 +134,15 @@ func (p Trello) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err != nil {
 		return nil, err
 	}
+
+	if op.ScopeConfig == nil && op.ScopeConfigId != 0 {
+		var scopeConfig models.TrelloScopeConfig
+		err = taskCtx.GetDal().First(&scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "fail to load scope config from database")
+		}
+		op.ScopeConfig = &scopeConfig
+	}
 	return &tasks.TrelloTaskData{
 		Options:   &op,
 		ApiClient: apiClient,
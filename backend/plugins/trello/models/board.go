@@ -26,8 +26,7 @@ var _ plugin.ToolLayerScope = (*TrelloBoard)(nil)
 
 type TrelloBoard struct {
 	common.Scope `mapstructure:",squash"`
-	ConnectionId uint64 `json:"connectionId" mapstructure:"connectionId" gorm:"primaryKey"`
-	BoardId      string `json:"boardId" mapstructure:"boardId" gorm:"type:varchar(255)"`
+	BoardId      string `json:"boardId" mapstructure:"boardId" gorm:"primaryKey;type:varchar(255)"`
 	Name         string `json:"name" mapstructure:"name" gorm:"type:varchar(255)"`
 }
 
@@ -36,6 +36,11 @@ type TrelloCard struct {
 	ShortUrl         string `gorm:"type:varchar(255)"`
 	Subscribed       bool
 	Url              string `gorm:"type:varchar(255)"`
+	Desc             string
+	Due              *time.Time
+	IDMembers        []string `gorm:"serializer:json;type:text"`
+	IDLabels         []string `gorm:"serializer:json;type:text"`
+	CreatedDate      *time.Time
 	common.NoPKModel
 }
 
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addFieldsForConvertors)(nil)
+
+type trelloCard20240224 struct {
+	ID          string `gorm:"primaryKey;type:varchar(255)"`
+	Desc        string
+	Due         *time.Time
+	IDMembers   []string `gorm:"serializer:json;type:text"`
+	IDLabels    []string `gorm:"serializer:json;type:text"`
+	CreatedDate *time.Time
+}
+
+func (trelloCard20240224) TableName() string {
+	return "_tool_trello_cards"
+}
+
+type trelloScopeConfig20240224 struct {
+	StatusMappings map[string]string `gorm:"serializer:json"`
+}
+
+func (trelloScopeConfig20240224) TableName() string {
+	return "_tool_trello_scope_configs"
+}
+
+type trelloCardMovement20240224 struct {
+	ID                string `gorm:"primaryKey;type:varchar(255)"`
+	IDCard            string `gorm:"index;type:varchar(255)"`
+	IDBoard           string `gorm:"type:varchar(255)"`
+	Type              string `gorm:"type:varchar(100)"`
+	IDMemberCreator   string `gorm:"type:varchar(255)"`
+	MemberCreatorName string `gorm:"type:varchar(255)"`
+	IDListBefore      string `gorm:"type:varchar(255)"`
+	ListBeforeName    string `gorm:"type:varchar(255)"`
+	IDListAfter       string `gorm:"type:varchar(255)"`
+	ListAfterName     string `gorm:"type:varchar(255)"`
+	Date              time.Time
+	archived.NoPKModel
+}
+
+func (trelloCardMovement20240224) TableName() string {
+	return "_tool_trello_card_movements"
+}
+
+type addFieldsForConvertors struct{}
+
+func (*addFieldsForConvertors) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&trelloCard20240224{},
+		&trelloScopeConfig20240224{},
+		&trelloCardMovement20240224{},
+	)
+}
+
+func (*addFieldsForConvertors) Version() uint64 {
+	return 20240224000002
+}
+
+func (*addFieldsForConvertors) Name() string {
+	return "add card details, status mappings and card movements for trello convertors"
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*modifyBoardPrimaryKey)(nil)
+
+type trelloBoard20240224 struct {
+	archived.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey;autoIncrement:false"`
+	BoardId       string `gorm:"primaryKey;type:varchar(255)"`
+	ScopeConfigId uint64
+	Name          string `gorm:"type:varchar(255)"`
+}
+
+func (trelloBoard20240224) TableName() string {
+	return "_tool_trello_boards"
+}
+
+type modifyBoardPrimaryKey struct{}
+
+func (script *modifyBoardPrimaryKey) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	// connection_id used to be the only primary key (and auto-incremented), so boards of the same
+	// connection overwrote each other. Rebuild the table with (connection_id, board_id) instead.
+	tmpTableName := "_tool_trello_boards_20240224"
+	err := db.RenameTable(trelloBoard20240224{}.TableName(), tmpTableName)
+	if err != nil {
+		return err
+	}
+	err = migrationhelper.AutoMigrateTables(basicRes, &trelloBoard20240224{})
+	if err != nil {
+		return err
+	}
+	err = migrationhelper.CopyTableColumns(basicRes,
+		tmpTableName,
+		trelloBoard20240224{}.TableName(),
+		func(src *trelloBoard20240224) (*trelloBoard20240224, errors.Error) {
+			return src, nil
+		})
+	if err != nil {
+		return err
+	}
+	return db.DropTables(tmpTableName)
+}
+
+func (*modifyBoardPrimaryKey) Version() uint64 {
+	return 20240224000001
+}
+
+func (*modifyBoardPrimaryKey) Name() string {
+	return "use connection_id and board_id as the primary key of _tool_trello_boards"
+}
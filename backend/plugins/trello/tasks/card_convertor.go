@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCard
+
+var ConvertCardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCard",
+	EntryPoint:       ConvertCard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_cards into domain layer table issues, board_issues and issue_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type trelloCardWithList struct {
+	models.TrelloCard
+	ListName string
+}
+
+func ConvertCard(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+	stdStatusMappings := getStatusMapping(data)
+	boardId := getBoardIdGen().Generate(data.Options.ConnectionId, data.Options.BoardId)
+
+	memberNames, err := getMemberNames(db)
+	if err != nil {
+		return err
+	}
+	// creators and the time a card entered its current list both come from the card movements
+	var movements []models.TrelloCardMovement
+	err = db.All(
+		&movements,
+		dal.Where("id_board = ?", data.Options.BoardId),
+		dal.Orderby("date ASC"),
+	)
+	if err != nil {
+		return err
+	}
+	creators := make(map[string]string)
+	enteredList := make(map[string]map[string]time.Time)
+	for _, movement := range movements {
+		if movement.Type == "createCard" {
+			creators[movement.IDCard] = movement.IDMemberCreator
+		}
+		if enteredList[movement.IDCard] == nil {
+			enteredList[movement.IDCard] = make(map[string]time.Time)
+		}
+		enteredList[movement.IDCard][movement.IDListAfter] = movement.Date
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*, l.name AS list_name"),
+		dal.From("_tool_trello_cards c"),
+		dal.Join("LEFT JOIN _tool_trello_lists l ON l.id = c.id_list"),
+		dal.Where("c.id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_TABLE,
+		},
+		InputRowType: reflect.TypeOf(trelloCardWithList{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*trelloCardWithList)
+			updatedDate := card.DateLastActivity
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCardIdGen().Generate(card.ID),
+				},
+				Url:            card.Url,
+				IssueKey:       strconv.Itoa(card.IDShort),
+				Title:          card.Name,
+				Description:    card.Desc,
+				Type:           ticket.REQUIREMENT,
+				OriginalType:   "card",
+				Status:         getStdStatus(card.ListName, stdStatusMappings),
+				OriginalStatus: card.ListName,
+				CreatedDate:    card.CreatedDate,
+				UpdatedDate:    &updatedDate,
+			}
+			if creatorId, ok := creators[card.ID]; ok {
+				issue.CreatorId = getMemberIdGen().Generate(creatorId)
+				issue.CreatorName = memberNames[creatorId]
+			}
+			if issue.Status == ticket.DONE {
+				if resolutionDate, ok := enteredList[card.ID][card.IDList]; ok {
+					issue.ResolutionDate = &resolutionDate
+					if issue.CreatedDate != nil && resolutionDate.After(*issue.CreatedDate) {
+						leadTimeMinutes := uint(resolutionDate.Sub(*issue.CreatedDate).Minutes())
+						issue.LeadTimeMinutes = &leadTimeMinutes
+					}
+				}
+			}
+
+			results := make([]interface{}, 0, 2+len(card.IDMembers))
+			for i, memberId := range card.IDMembers {
+				assignee := &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   getMemberIdGen().Generate(memberId),
+					AssigneeName: memberNames[memberId],
+				}
+				if i == 0 {
+					issue.AssigneeId = assignee.AssigneeId
+					issue.AssigneeName = assignee.AssigneeName
+				}
+				results = append(results, assignee)
+			}
+			results = append(results, issue, &ticket.BoardIssue{
+				BoardId: boardId,
+				IssueId: issue.Id,
+			})
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getMemberNames returns the full names of the members by their ids
+func getMemberNames(db dal.Dal) (map[string]string, errors.Error) {
+	var members []models.TrelloMember
+	err := db.All(&members)
+	if err != nil {
+		return nil, err
+	}
+	memberNames := make(map[string]string, len(members))
+	for _, member := range members {
+		memberNames[member.ID] = member.FullName
+	}
+	return memberNames, nil
+}
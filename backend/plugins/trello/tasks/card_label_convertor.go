@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCardLabel
+
+var ConvertCardLabelMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCardLabel",
+	EntryPoint:       ConvertCardLabel,
+	EnabledByDefault: true,
+	Description:      "Convert labels of tool layer table trello_cards into domain layer table issue_labels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCardLabel(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+
+	var labels []models.TrelloLabel
+	err := db.All(&labels, dal.Where("id_board = ?", data.Options.BoardId))
+	if err != nil {
+		return err
+	}
+	labelNames := make(map[string]string, len(labels))
+	for _, label := range labels {
+		// labels may be left unnamed on trello and told apart by their color only
+		labelNames[label.ID] = label.Name
+		if label.Name == "" {
+			labelNames[label.ID] = label.Color
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCard{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.TrelloCard{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*models.TrelloCard)
+			results := make([]interface{}, 0, len(card.IDLabels))
+			for _, labelId := range card.IDLabels {
+				labelName, ok := labelNames[labelId]
+				if !ok || labelName == "" {
+					continue
+				}
+				results = append(results, &ticket.IssueLabel{
+					IssueId:   getCardIdGen().Generate(card.ID),
+					LabelName: labelName,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
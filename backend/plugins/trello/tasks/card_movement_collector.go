@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_CARD_MOVEMENT_TABLE = "trello_card_movements"
+
+var _ plugin.SubTaskEntryPoint = CollectCardMovement
+
+var CollectCardMovementMeta = plugin.SubTaskMeta{
+	Name:             "CollectCardMovement",
+	EntryPoint:       CollectCardMovement,
+	EnabledByDefault: true,
+	Description:      "Collect card creations and list changes from Trello api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type trelloApiActionId struct {
+	ID string `json:"id"`
+}
+
+func CollectCardMovement(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_MOVEMENT_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		PageSize:    1000,
+		UrlTemplate: "1/boards/{{ .Params.BoardId }}/actions",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("filter", "createCard,updateCard:idList")
+			query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+			// actions are returned newest first, page backwards with the id of the oldest one collected
+			if before, ok := reqData.CustomData.(string); ok && before != "" {
+				query.Set("before", before)
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			var actions []trelloApiActionId
+			err := api.UnmarshalResponse(prevPageResponse, &actions)
+			if err != nil {
+				return nil, err
+			}
+			if len(actions) < prevReqData.Pager.Size {
+				return nil, api.ErrFinishCollect
+			}
+			return actions[len(actions)-1].ID, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var data []json.RawMessage
+			err := api.UnmarshalResponse(res, &data)
+			return data, err
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
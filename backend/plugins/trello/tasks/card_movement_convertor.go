@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCardMovement
+
+var ConvertCardMovementMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCardMovement",
+	EntryPoint:       ConvertCardMovement,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_card_movements into status changes in domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCardMovement(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+	stdStatusMappings := getStatusMapping(data)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCardMovement{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_MOVEMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.TrelloCardMovement{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			movement := inputRow.(*models.TrelloCardMovement)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCardMovementIdGen().Generate(movement.ID),
+				},
+				IssueId:           getCardIdGen().Generate(movement.IDCard),
+				AuthorName:        movement.MemberCreatorName,
+				FieldId:           "status",
+				FieldName:         "status",
+				OriginalFromValue: movement.ListBeforeName,
+				OriginalToValue:   movement.ListAfterName,
+				FromValue:         getStdStatus(movement.ListBeforeName, stdStatusMappings),
+				ToValue:           getStdStatus(movement.ListAfterName, stdStatusMappings),
+				CreatedDate:       movement.Date,
+			}
+			if movement.IDMemberCreator != "" {
+				changelog.AuthorId = getMemberIdGen().Generate(movement.IDMemberCreator)
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractCardMovement
+
+var ExtractCardMovementMeta = plugin.SubTaskMeta{
+	Name:             "ExtractCardMovement",
+	EntryPoint:       ExtractCardMovement,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table trello_card_movements",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type TrelloApiActionEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TrelloApiAction struct {
+	ID              string    `json:"id"`
+	IDMemberCreator string    `json:"idMemberCreator"`
+	Type            string    `json:"type"`
+	Date            time.Time `json:"date"`
+	Data            struct {
+		Card       *TrelloApiActionEntity `json:"card"`
+		Board      *TrelloApiActionEntity `json:"board"`
+		List       *TrelloApiActionEntity `json:"list"`
+		ListBefore *TrelloApiActionEntity `json:"listBefore"`
+		ListAfter  *TrelloApiActionEntity `json:"listAfter"`
+	} `json:"data"`
+	MemberCreator *struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"memberCreator"`
+}
+
+func ExtractCardMovement(taskCtx plugin.SubTaskContext) errors.Error {
+	taskData := taskCtx.GetData().(*TrelloTaskData)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: taskData.Options.ConnectionId,
+				BoardId:      taskData.Options.BoardId,
+			},
+			Table: RAW_CARD_MOVEMENT_TABLE,
+		},
+		Extract: func(resData *api.RawData) ([]interface{}, errors.Error) {
+			apiAction := &TrelloApiAction{}
+			err := errors.Convert(json.Unmarshal(resData.Data, apiAction))
+			if err != nil {
+				return nil, err
+			}
+			if apiAction.Data.Card == nil {
+				return nil, nil
+			}
+			movement := &models.TrelloCardMovement{
+				ID:              apiAction.ID,
+				IDCard:          apiAction.Data.Card.ID,
+				Type:            apiAction.Type,
+				IDMemberCreator: apiAction.IDMemberCreator,
+				Date:            apiAction.Date,
+			}
+			if apiAction.Data.Board != nil {
+				movement.IDBoard = apiAction.Data.Board.ID
+			}
+			if apiAction.MemberCreator != nil {
+				movement.MemberCreatorName = apiAction.MemberCreator.FullName
+			}
+			switch {
+			case apiAction.Data.ListAfter != nil:
+				movement.IDListAfter = apiAction.Data.ListAfter.ID
+				movement.ListAfterName = apiAction.Data.ListAfter.Name
+				if apiAction.Data.ListBefore != nil {
+					movement.IDListBefore = apiAction.Data.ListBefore.ID
+					movement.ListBeforeName = apiAction.Data.ListBefore.Name
+				}
+			case apiAction.Data.List != nil:
+				// createCard only carries the list the card was created in
+				movement.IDListAfter = apiAction.Data.List.ID
+				movement.ListAfterName = apiAction.Data.List.Name
+			default:
+				return nil, nil
+			}
+			return []interface{}{movement}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
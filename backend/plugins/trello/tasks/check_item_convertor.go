@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCheckItem
+
+var ConvertCheckItemMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCheckItem",
+	EntryPoint:       ConvertCheckItem,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_check_items into sub-tasks of their cards in domain layer table issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCheckItem(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+	boardId := getBoardIdGen().Generate(data.Options.ConnectionId, data.Options.BoardId)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCheckItem{}),
+		dal.Where("id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CHECK_ITEM_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.TrelloCheckItem{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			checkItem := inputRow.(*models.TrelloCheckItem)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: getCheckItemIdGen().Generate(checkItem.ID),
+				},
+				IssueKey:       checkItem.ID,
+				Title:          checkItem.Name,
+				Type:           ticket.TASK,
+				OriginalType:   checkItem.ChecklistName,
+				Status:         ticket.TODO,
+				OriginalStatus: checkItem.State,
+				ParentIssueId:  getCardIdGen().Generate(checkItem.IDCard),
+				CreatedDate:    getCreatedDateFromId(checkItem.ID),
+			}
+			if checkItem.State == "complete" {
+				issue.Status = ticket.DONE
+			}
+			return []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -32,6 +32,7 @@ var ExtractCheckItemMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractCheckItem,
 	EnabledByDefault: true,
 	Description:      "Extract raw data into tool layer table trello_check_items",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 type TrelloApiChecklist struct {
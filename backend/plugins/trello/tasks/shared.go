@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var boardIdGen *didgen.DomainIdGenerator
+var cardIdGen *didgen.DomainIdGenerator
+var checkItemIdGen *didgen.DomainIdGenerator
+var memberIdGen *didgen.DomainIdGenerator
+var cardMovementIdGen *didgen.DomainIdGenerator
+
+func getBoardIdGen() *didgen.DomainIdGenerator {
+	if boardIdGen == nil {
+		boardIdGen = didgen.NewDomainIdGenerator(&models.TrelloBoard{})
+	}
+	return boardIdGen
+}
+
+func getCardIdGen() *didgen.DomainIdGenerator {
+	if cardIdGen == nil {
+		cardIdGen = didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	}
+	return cardIdGen
+}
+
+func getCheckItemIdGen() *didgen.DomainIdGenerator {
+	if checkItemIdGen == nil {
+		checkItemIdGen = didgen.NewDomainIdGenerator(&models.TrelloCheckItem{})
+	}
+	return checkItemIdGen
+}
+
+func getMemberIdGen() *didgen.DomainIdGenerator {
+	if memberIdGen == nil {
+		memberIdGen = didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	}
+	return memberIdGen
+}
+
+func getCardMovementIdGen() *didgen.DomainIdGenerator {
+	if cardMovementIdGen == nil {
+		cardMovementIdGen = didgen.NewDomainIdGenerator(&models.TrelloCardMovement{})
+	}
+	return cardMovementIdGen
+}
+
+// getStatusMapping returns the list name to standard status mapping configured in the scope config
+func getStatusMapping(data *TrelloTaskData) map[string]string {
+	stdStatusMappings := make(map[string]string)
+	if data.Options.ScopeConfig == nil {
+		return stdStatusMappings
+	}
+	for listName, stdStatus := range data.Options.ScopeConfig.StatusMappings {
+		stdStatusMappings[listName] = strings.ToUpper(stdStatus)
+	}
+	return stdStatusMappings
+}
+
+// getStdStatus maps a list name to a standard status, lists without a mapping fall back to OTHER
+func getStdStatus(listName string, stdStatusMappings map[string]string) string {
+	if listName == "" {
+		return ""
+	}
+	if stdStatus, ok := stdStatusMappings[listName]; ok {
+		return stdStatus
+	}
+	return ticket.OTHER
+}
+
+// getCreatedDateFromId extracts the creation time which trello encodes into the first 8 hex digits of an object id
+func getCreatedDateFromId(id string) *time.Time {
+	if len(id) < 8 {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return nil
+	}
+	createdDate := time.Unix(seconds, 0).UTC()
+	return &createdDate
+}
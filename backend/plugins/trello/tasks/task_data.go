@@ -27,6 +27,7 @@ type TrelloOptions struct {
 	BoardId              string `json:"boardId"`
 	ScopeId              string
 	ScopeConfigId        uint64
+	ScopeConfig          *models.TrelloScopeConfig `json:"scopeConfig" mapstructure:"scopeConfig,omitempty"`
 	api.CollectorOptions `mapstructure:",squash"`
 }
 
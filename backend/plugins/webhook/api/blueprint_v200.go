@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -54,5 +55,13 @@ func MakeDataSourcePipelinePlanV200(connectionId uint64) (coreModels.PipelinePla
 		Name: connection.Name,
 	})
 
+	// add cq_project to scopes, coverage reports uploaded to the connection go there
+	scopes = append(scopes, &codequality.CqProject{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d", "webhook", connection.ID),
+		},
+		Name: connection.Name,
+	})
+
 	return nil, scopes, nil
 }
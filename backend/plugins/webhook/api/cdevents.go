@@ -0,0 +1,467 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+// CDEvent is the envelope of the CDEvents (https://cdevents.dev) in json format
+type CDEvent struct {
+	Context    CDEventContext         `mapstructure:"context" validate:"required"`
+	Subject    CDEventSubject         `mapstructure:"subject" validate:"required"`
+	CustomData map[string]interface{} `mapstructure:"customData"`
+}
+
+type CDEventContext struct {
+	Version   string     `mapstructure:"version" validate:"required"`
+	Id        string     `mapstructure:"id" validate:"required"`
+	Source    string     `mapstructure:"source" validate:"required"`
+	Type      string     `mapstructure:"type" validate:"required"`
+	Timestamp *time.Time `mapstructure:"timestamp" validate:"required"`
+}
+
+type CDEventSubject struct {
+	Id      string                 `mapstructure:"id" validate:"required"`
+	Source  string                 `mapstructure:"source"`
+	Type    string                 `mapstructure:"type"`
+	Content map[string]interface{} `mapstructure:"content"`
+}
+
+type CDEventReference struct {
+	Id     string `mapstructure:"id" validate:"required"`
+	Source string `mapstructure:"source"`
+}
+
+type CDEventPipelineRunContent struct {
+	PipelineName string `mapstructure:"pipelineName" validate:"required"`
+	Url          string `mapstructure:"url"`
+	Outcome      string `mapstructure:"outcome" validate:"omitempty,oneof=success error failure cancel"`
+	Errors       string `mapstructure:"errors"`
+}
+
+type CDEventTaskRunContent struct {
+	TaskName    string            `mapstructure:"taskName" validate:"required"`
+	Url         string            `mapstructure:"url"`
+	PipelineRun *CDEventReference `mapstructure:"pipelineRun" validate:"required"`
+	Outcome     string            `mapstructure:"outcome" validate:"omitempty,oneof=success error failure cancel"`
+	Errors      string            `mapstructure:"errors"`
+}
+
+type CDEventServiceDeployedContent struct {
+	Environment *CDEventReference `mapstructure:"environment" validate:"required"`
+	ArtifactId  string            `mapstructure:"artifactId" validate:"required"`
+}
+
+type CDEventIncidentContent struct {
+	Description string            `mapstructure:"description"`
+	Environment *CDEventReference `mapstructure:"environment" validate:"required"`
+	Service     *CDEventReference `mapstructure:"service"`
+	ArtifactId  string            `mapstructure:"artifactId"`
+}
+
+// CDEventCustomData is the part of `customData` understood by devlake, CDEvents doesn't carry the commits
+// of a deployment, so they have to be provided by the sender
+type CDEventCustomData struct {
+	Commits []CDEventCommit `mapstructure:"commits" validate:"omitempty,dive"`
+}
+
+type CDEventCommit struct {
+	RepoUrl   string `mapstructure:"repoUrl" validate:"required"`
+	CommitSha string `mapstructure:"commitSha" validate:"required"`
+	RefName   string `mapstructure:"refName"`
+	CommitMsg string `mapstructure:"commitMsg"`
+}
+
+// cdeventSpecVersions are the CDEvents spec versions supported
+var cdeventSpecVersions = []string{"0.3", "0.4"}
+
+// cdeventTypeVersions are the `<major>.<minor>` versions of the event schemas supported by event type
+var cdeventTypeVersions = map[string][]string{
+	"pipelinerun.started":  {"0.1"},
+	"pipelinerun.finished": {"0.1"},
+	"taskrun.started":      {"0.1"},
+	"taskrun.finished":     {"0.1"},
+	"service.deployed":     {"0.1", "0.2"},
+	"incident.detected":    {"0.1"},
+	"incident.reported":    {"0.1"},
+	"incident.resolved":    {"0.1"},
+}
+
+var cdeventTypePattern = regexp.MustCompile(`^dev\.cdevents\.([a-z]+\.[a-z]+)\.(\d+\.\d+)\.\d+(-.+)?$`)
+
+// parseCDEventType returns the event type without prefix and version, i.e. `pipelinerun.finished`, an error
+// would be returned if the spec or the event schema version is not supported
+func parseCDEventType(event *CDEvent) (string, errors.Error) {
+	if !hasVersionPrefix(event.Context.Version, cdeventSpecVersions) {
+		return "", errors.BadInput.New(fmt.Sprintf("unsupported CDEvents spec version %s", event.Context.Version))
+	}
+	matches := cdeventTypePattern.FindStringSubmatch(event.Context.Type)
+	if matches == nil {
+		return "", errors.BadInput.New(fmt.Sprintf("malformed CDEvents type %s", event.Context.Type))
+	}
+	versions, ok := cdeventTypeVersions[matches[1]]
+	if !ok {
+		return matches[1], nil
+	}
+	if !hasVersionPrefix(matches[2], versions) {
+		return "", errors.BadInput.New(fmt.Sprintf("unsupported schema version %s of %s", matches[2], matches[1]))
+	}
+	return matches[1], nil
+}
+
+func hasVersionPrefix(version string, supported []string) bool {
+	for _, s := range supported {
+		if version == s || strings.HasPrefix(version, s+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// PostCDEvent
+// @Summary receive a CDEvent
+// @Description receive a CDEvent and save it to the domain layer, supported events are pipelineRun started/finished, taskRun started/finished, service deployed and incident detected/reported/resolved, others would be ignored with 202 Accepted.<br/>
+// @Description the commits of a service deployment should be provided as `customData`, example: {"context":{"version":"0.3.0","id":"271069a8-fc18-44f1-b38f-9d70a1695819","source":"/event/source/123","type":"dev.cdevents.service.deployed.0.1.1","timestamp":"2023-03-20T14:27:05.315384Z"},"subject":{"id":"mySubject123","source":"/event/source/123","type":"service","content":{"environment":{"id":"production"},"artifactId":"pkg:oci/myapp@sha256%3A0b31b1c02ff458ad9b7b81cbdf8f028bd54699fa151f221d1e8de6817db93427"}},"customData":{"commits":[{"repoUrl":"https://github.com/apache/incubator-devlake","commitSha":"015e3d3b480e417aede5a1293bd61de9b0fd051d"}]}}
+// @Tags plugins/webhook
+// @Param body body CDEvent true "json body"
+// @Success 200
+// @Success 202
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/cdevents [POST]
+func PostCDEvent(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	event := &CDEvent{}
+	err = api.DecodeMapStruct(input.Body, event, true)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(event))
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	eventType, err := parseCDEventType(event)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+
+	switch eventType {
+	case "pipelinerun.started", "pipelinerun.finished":
+		content := &CDEventPipelineRunContent{}
+		if output := decodeCDEventContent(event.Subject.Content, content); output != nil {
+			return output, nil
+		}
+		err = saveCDEventPipelineRun(connection.ID, event, content, eventType == "pipelinerun.finished")
+	case "taskrun.started", "taskrun.finished":
+		content := &CDEventTaskRunContent{}
+		if output := decodeCDEventContent(event.Subject.Content, content); output != nil {
+			return output, nil
+		}
+		err = saveCDEventTaskRun(connection.ID, event, content, eventType == "taskrun.finished")
+	case "service.deployed":
+		content := &CDEventServiceDeployedContent{}
+		if output := decodeCDEventContent(event.Subject.Content, content); output != nil {
+			return output, nil
+		}
+		customData := &CDEventCustomData{}
+		if output := decodeCDEventContent(event.CustomData, customData); output != nil {
+			return output, nil
+		}
+		if len(customData.Commits) == 0 {
+			return &plugin.ApiResourceOutput{Body: "customData.commits is required for service.deployed", Status: http.StatusBadRequest}, nil
+		}
+		err = saveCDEventServiceDeployed(connection.ID, event, content, customData)
+	case "incident.detected", "incident.reported", "incident.resolved":
+		content := &CDEventIncidentContent{}
+		if output := decodeCDEventContent(event.Subject.Content, content); output != nil {
+			return output, nil
+		}
+		err = saveCDEventIncident(connection.ID, event, content, eventType == "incident.resolved")
+	default:
+		return &plugin.ApiResourceOutput{Body: fmt.Sprintf("event type %s is ignored", event.Context.Type), Status: http.StatusAccepted}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+func decodeCDEventContent(content map[string]interface{}, result interface{}) *plugin.ApiResourceOutput {
+	err := api.DecodeMapStruct(content, result, true)
+	if err == nil {
+		err = errors.Convert(vld.Struct(result))
+	}
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}
+	}
+	return nil
+}
+
+// cdeventId generates the domain id of the entity identified by the source and id in CDEvents
+func cdeventId(connectionId uint64, source string, id string) string {
+	return fmt.Sprintf("%s:%d:%x", "webhook", connectionId, md5.Sum([]byte(source+"\n"+id)))
+}
+
+func cdeventResult(outcome string) string {
+	switch outcome {
+	case "success":
+		return devops.RESULT_SUCCESS
+	case "error", "failure":
+		return devops.RESULT_FAILURE
+	}
+	return devops.RESULT_DEFAULT
+}
+
+func cdeventEnvironment(environment string) string {
+	switch strings.ToLower(environment) {
+	case "prod", "production":
+		return devops.PRODUCTION
+	case "stag", "staging":
+		return devops.STAGING
+	case "test", "testing":
+		return devops.TESTING
+	case "dev", "development":
+		return "DEVELOPMENT"
+	}
+	return strings.ToUpper(environment)
+}
+
+// cdeventDates updates the dates according to the event, the started date is kept if the started event was
+// received before, and corrected by a started event received after the finished one
+func cdeventDates(dates *devops.TaskDatesInfo, timestamp *time.Time, finished bool) float64 {
+	if dates.CreatedDate.IsZero() || timestamp.Before(dates.CreatedDate) {
+		dates.CreatedDate = *timestamp
+	}
+	if dates.StartedDate == nil || (!finished && timestamp.Before(*dates.StartedDate)) {
+		dates.StartedDate = timestamp
+	}
+	if finished {
+		dates.FinishedDate = timestamp
+	}
+	if dates.FinishedDate == nil {
+		return 0
+	}
+	return dates.FinishedDate.Sub(*dates.StartedDate).Seconds()
+}
+
+func cdeventStatus(finished bool) string {
+	if finished {
+		return devops.STATUS_DONE
+	}
+	return devops.STATUS_IN_PROGRESS
+}
+
+func saveCDEventPipelineRun(connectionId uint64, event *CDEvent, content *CDEventPipelineRunContent, finished bool) errors.Error {
+	db := basicRes.GetDal()
+	pipeline := &devops.CICDPipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", cdeventId(connectionId, event.Subject.Source, event.Subject.Id)))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	applyCDEventPipelineRun(connectionId, pipeline, event, content, finished)
+	return db.CreateOrUpdate(pipeline)
+}
+
+// applyCDEventPipelineRun updates the pipeline, which might be saved by a previous event of the run, according to the event
+func applyCDEventPipelineRun(connectionId uint64, pipeline *devops.CICDPipeline, event *CDEvent, content *CDEventPipelineRunContent, finished bool) {
+	pipeline.Id = cdeventId(connectionId, event.Subject.Source, event.Subject.Id)
+	pipeline.Name = content.PipelineName
+	pipeline.CicdScopeId = fmt.Sprintf("%s:%d", "webhook", connectionId)
+	// a started event received after the finished one must not reopen the run
+	if finished || pipeline.FinishedDate == nil {
+		pipeline.Status = cdeventStatus(finished)
+		pipeline.OriginalStatus = pipeline.Status
+		pipeline.Result = cdeventResult(content.Outcome)
+		pipeline.OriginalResult = content.Outcome
+	}
+	pipeline.DurationSec = cdeventDates(&pipeline.TaskDatesInfo, event.Context.Timestamp, finished)
+}
+
+func saveCDEventTaskRun(connectionId uint64, event *CDEvent, content *CDEventTaskRunContent, finished bool) errors.Error {
+	db := basicRes.GetDal()
+	task := &devops.CICDTask{}
+	err := db.First(task, dal.Where("id = ?", cdeventId(connectionId, event.Subject.Source, event.Subject.Id)))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	applyCDEventTaskRun(connectionId, task, event, content, finished)
+	return db.CreateOrUpdate(task)
+}
+
+// applyCDEventTaskRun updates the task, which might be saved by a previous event of the run, according to the event
+func applyCDEventTaskRun(connectionId uint64, task *devops.CICDTask, event *CDEvent, content *CDEventTaskRunContent, finished bool) {
+	pipelineSource := content.PipelineRun.Source
+	if pipelineSource == "" {
+		pipelineSource = event.Subject.Source
+	}
+	task.Id = cdeventId(connectionId, event.Subject.Source, event.Subject.Id)
+	task.Name = content.TaskName
+	task.PipelineId = cdeventId(connectionId, pipelineSource, content.PipelineRun.Id)
+	task.CicdScopeId = fmt.Sprintf("%s:%d", "webhook", connectionId)
+	// a started event received after the finished one must not reopen the run
+	if finished || task.FinishedDate == nil {
+		task.Status = cdeventStatus(finished)
+		task.OriginalStatus = task.Status
+		task.Result = cdeventResult(content.Outcome)
+		task.OriginalResult = content.Outcome
+	}
+	task.DurationSec = cdeventDates(&task.TaskDatesInfo, event.Context.Timestamp, finished)
+}
+
+func saveCDEventServiceDeployed(
+	connectionId uint64,
+	event *CDEvent,
+	content *CDEventServiceDeployedContent,
+	customData *CDEventCustomData,
+) (err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+
+	for _, deploymentCommit := range cdeventDeploymentCommits(connectionId, event, content, customData) {
+		err = tx.CreateOrUpdate(deploymentCommit)
+		if err != nil {
+			return err
+		}
+		err = tx.CreateOrUpdate(deploymentCommit.ToDeployment())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cdeventDeploymentCommits returns the deployment commits of the service deployed, one for each commit in customData
+func cdeventDeploymentCommits(
+	connectionId uint64,
+	event *CDEvent,
+	content *CDEventServiceDeployedContent,
+	customData *CDEventCustomData,
+) []*devops.CicdDeploymentCommit {
+	deploymentId := cdeventId(connectionId, event.Context.Source, event.Context.Id)
+	duration := float64(0)
+	deploymentCommits := make([]*devops.CicdDeploymentCommit, 0, len(customData.Commits))
+	for _, commit := range customData.Commits {
+		urlHash16 := fmt.Sprintf("%x", md5.Sum([]byte(commit.RepoUrl)))[:16]
+		deploymentCommits = append(deploymentCommits, &devops.CicdDeploymentCommit{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%d:%s:%s", "webhook", connectionId, urlHash16, commit.CommitSha),
+			},
+			CicdDeploymentId: deploymentId,
+			CicdScopeId:      fmt.Sprintf("%s:%d", "webhook", connectionId),
+			Name:             fmt.Sprintf(`deployment of %s`, event.Subject.Id),
+			Result:           devops.RESULT_SUCCESS,
+			Status:           devops.STATUS_DONE,
+			OriginalResult:   devops.RESULT_SUCCESS,
+			OriginalStatus:   devops.STATUS_DONE,
+			TaskDatesInfo: devops.TaskDatesInfo{
+				CreatedDate:  *event.Context.Timestamp,
+				StartedDate:  event.Context.Timestamp,
+				FinishedDate: event.Context.Timestamp,
+			},
+			DurationSec: &duration,
+			RepoUrl:     commit.RepoUrl,
+			Environment: cdeventEnvironment(content.Environment.Id),
+			RefName:     commit.RefName,
+			CommitSha:   commit.CommitSha,
+			CommitMsg:   commit.CommitMsg,
+		})
+	}
+	return deploymentCommits
+}
+
+func saveCDEventIncident(connectionId uint64, event *CDEvent, content *CDEventIncidentContent, resolved bool) errors.Error {
+	db := basicRes.GetDal()
+	issueKey := event.Subject.Id
+	issue := &ticket.Issue{}
+	err := db.First(issue, dal.Where("id = ?", fmt.Sprintf("%s:%d:%s", "webhook", connectionId, issueKey)))
+	if err != nil {
+		if !db.IsErrorNotFound(err) {
+			return err
+		}
+		if resolved {
+			return errors.NotFound.New(fmt.Sprintf("incident %s not found", issueKey))
+		}
+		issue = newCDEventIncident(connectionId, event, content)
+		err = saveIssue(db, connectionId, issue)
+		if err != nil {
+			return err
+		}
+	}
+	return saveIssueEvent(db, connectionId, issue, cdeventIncidentEvent(event, resolved))
+}
+
+// newCDEventIncident returns the incident issue detected or reported by the event
+func newCDEventIncident(connectionId uint64, event *CDEvent, content *CDEventIncidentContent) *ticket.Issue {
+	issueKey := event.Subject.Id
+	title := content.Description
+	if title == "" {
+		title = fmt.Sprintf("incident %s", issueKey)
+	}
+	issue := &ticket.Issue{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d:%s", "webhook", connectionId, issueKey),
+		},
+		IssueKey:    issueKey,
+		Title:       title,
+		Description: content.Description,
+		Type:        ticket.INCIDENT,
+		Status:      ticket.TODO,
+		CreatedDate: event.Context.Timestamp,
+	}
+	if content.Service != nil {
+		issue.Component = content.Service.Id
+	}
+	return issue
+}
+
+// cdeventIncidentEvent maps the incident event to the lifecycle event of the issue
+func cdeventIncidentEvent(event *CDEvent, resolved bool) *WebhookIssueEventRequest {
+	incidentEvent := IncidentTriggered
+	if resolved {
+		incidentEvent = IncidentResolved
+	}
+	return &WebhookIssueEventRequest{
+		Event:       incidentEvent,
+		CreatedDate: event.Context.Timestamp,
+	}
+}
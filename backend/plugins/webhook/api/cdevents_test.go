@@ -0,0 +1,203 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCDEvent(version string, eventType string, timestamp time.Time) *CDEvent {
+	return &CDEvent{
+		Context: CDEventContext{
+			Version:   version,
+			Id:        "271069a8-fc18-44f1-b38f-9d70a1695819",
+			Source:    "/event/source/123",
+			Type:      eventType,
+			Timestamp: &timestamp,
+		},
+		Subject: CDEventSubject{
+			Id:     "mySubject123",
+			Source: "/event/source/123",
+		},
+	}
+}
+
+func TestParseCDEventType(t *testing.T) {
+	now := time.Now()
+	eventType, err := parseCDEventType(newTestCDEvent("0.3.0", "dev.cdevents.pipelinerun.finished.0.1.1", now))
+	assert.Nil(t, err)
+	assert.Equal(t, "pipelinerun.finished", eventType)
+
+	eventType, err = parseCDEventType(newTestCDEvent("0.4.1", "dev.cdevents.service.deployed.0.2.0-draft", now))
+	assert.Nil(t, err)
+	assert.Equal(t, "service.deployed", eventType)
+
+	// unknown event types are returned to be ignored
+	eventType, err = parseCDEventType(newTestCDEvent("0.3.0", "dev.cdevents.build.started.0.1.0", now))
+	assert.Nil(t, err)
+	assert.Equal(t, "build.started", eventType)
+
+	// unsupported spec version
+	_, err = parseCDEventType(newTestCDEvent("0.2.0", "dev.cdevents.pipelinerun.finished.0.1.1", now))
+	assert.NotNil(t, err)
+	_, err = parseCDEventType(newTestCDEvent("0.30", "dev.cdevents.pipelinerun.finished.0.1.1", now))
+	assert.NotNil(t, err)
+
+	// unsupported schema version
+	_, err = parseCDEventType(newTestCDEvent("0.3.0", "dev.cdevents.pipelinerun.finished.0.2.0", now))
+	assert.NotNil(t, err)
+	_, err = parseCDEventType(newTestCDEvent("0.3.0", "dev.cdevents.service.deployed.1.0.0", now))
+	assert.NotNil(t, err)
+
+	// malformed type
+	_, err = parseCDEventType(newTestCDEvent("0.3.0", "pipelinerun.finished", now))
+	assert.NotNil(t, err)
+}
+
+func TestCDEventResult(t *testing.T) {
+	assert.Equal(t, devops.RESULT_SUCCESS, cdeventResult("success"))
+	assert.Equal(t, devops.RESULT_FAILURE, cdeventResult("error"))
+	assert.Equal(t, devops.RESULT_FAILURE, cdeventResult("failure"))
+	assert.Equal(t, devops.RESULT_DEFAULT, cdeventResult("cancel"))
+	assert.Equal(t, devops.RESULT_DEFAULT, cdeventResult(""))
+}
+
+func TestApplyCDEventPipelineRun(t *testing.T) {
+	startedAt := time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)
+	pipeline := &devops.CICDPipeline{}
+	content := &CDEventPipelineRunContent{PipelineName: "build"}
+	applyCDEventPipelineRun(1, pipeline, newTestCDEvent("0.3.0", "dev.cdevents.pipelinerun.started.0.1.1", startedAt), content, false)
+	assert.Equal(t, cdeventId(1, "/event/source/123", "mySubject123"), pipeline.Id)
+	assert.Equal(t, "build", pipeline.Name)
+	assert.Equal(t, "webhook:1", pipeline.CicdScopeId)
+	assert.Equal(t, devops.STATUS_IN_PROGRESS, pipeline.Status)
+	assert.Equal(t, devops.RESULT_DEFAULT, pipeline.Result)
+	assert.Equal(t, startedAt, pipeline.CreatedDate)
+	assert.Nil(t, pipeline.FinishedDate)
+
+	// the started date is kept when the run finished
+	finishedAt := startedAt.Add(90 * time.Second)
+	content.Outcome = "failure"
+	applyCDEventPipelineRun(1, pipeline, newTestCDEvent("0.3.0", "dev.cdevents.pipelinerun.finished.0.1.1", finishedAt), content, true)
+	assert.Equal(t, devops.STATUS_DONE, pipeline.Status)
+	assert.Equal(t, devops.STATUS_DONE, pipeline.OriginalStatus)
+	assert.Equal(t, devops.RESULT_FAILURE, pipeline.Result)
+	assert.Equal(t, "failure", pipeline.OriginalResult)
+	assert.Equal(t, startedAt, *pipeline.StartedDate)
+	assert.Equal(t, finishedAt, *pipeline.FinishedDate)
+	assert.Equal(t, float64(90), pipeline.DurationSec)
+
+	// a late started event only corrects the started date of the finished run
+	lateStartedAt := startedAt.Add(-1500 * time.Millisecond)
+	content.Outcome = ""
+	applyCDEventPipelineRun(1, pipeline, newTestCDEvent("0.3.0", "dev.cdevents.pipelinerun.started.0.1.1", lateStartedAt), content, false)
+	assert.Equal(t, devops.STATUS_DONE, pipeline.Status)
+	assert.Equal(t, devops.RESULT_FAILURE, pipeline.Result)
+	assert.Equal(t, "failure", pipeline.OriginalResult)
+	assert.Equal(t, lateStartedAt, *pipeline.StartedDate)
+	assert.Equal(t, lateStartedAt, pipeline.CreatedDate)
+	assert.Equal(t, finishedAt, *pipeline.FinishedDate)
+	assert.Equal(t, 91.5, pipeline.DurationSec)
+}
+
+func TestApplyCDEventTaskRun(t *testing.T) {
+	finishedAt := time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)
+	task := &devops.CICDTask{}
+	applyCDEventTaskRun(1, task, newTestCDEvent("0.3.0", "dev.cdevents.taskrun.finished.0.1.1", finishedAt), &CDEventTaskRunContent{
+		TaskName:    "test",
+		PipelineRun: &CDEventReference{Id: "run1"},
+		Outcome:     "success",
+	}, true)
+	assert.Equal(t, "test", task.Name)
+	// the pipeline run shares the source of the task run if not specified
+	assert.Equal(t, cdeventId(1, "/event/source/123", "run1"), task.PipelineId)
+	assert.Equal(t, devops.STATUS_DONE, task.Status)
+	assert.Equal(t, devops.RESULT_SUCCESS, task.Result)
+	// finished without the started event
+	assert.Equal(t, finishedAt, *task.StartedDate)
+	assert.Equal(t, float64(0), task.DurationSec)
+
+	applyCDEventTaskRun(1, task, newTestCDEvent("0.3.0", "dev.cdevents.taskrun.finished.0.1.1", finishedAt), &CDEventTaskRunContent{
+		TaskName:    "test",
+		PipelineRun: &CDEventReference{Id: "run1", Source: "/another/source"},
+		Outcome:     "error",
+	}, true)
+	assert.Equal(t, cdeventId(1, "/another/source", "run1"), task.PipelineId)
+	assert.Equal(t, devops.RESULT_FAILURE, task.Result)
+}
+
+func TestCDEventDeploymentCommits(t *testing.T) {
+	deployedAt := time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)
+	event := newTestCDEvent("0.3.0", "dev.cdevents.service.deployed.0.1.1", deployedAt)
+	deploymentCommits := cdeventDeploymentCommits(1, event, &CDEventServiceDeployedContent{
+		Environment: &CDEventReference{Id: "prod"},
+		ArtifactId:  "pkg:oci/myapp",
+	}, &CDEventCustomData{
+		Commits: []CDEventCommit{
+			{RepoUrl: "https://github.com/apache/incubator-devlake", CommitSha: "015e3d3b480e417aede5a1293bd61de9b0fd051d"},
+			{RepoUrl: "https://github.com/apache/incubator-devlake-website", CommitSha: "a8e3c1d9b5b5f1b9c1b8e0d4c0d1f5b2e8f1a3c7"},
+		},
+	})
+	assert.Len(t, deploymentCommits, 2)
+	for _, deploymentCommit := range deploymentCommits {
+		assert.Equal(t, cdeventId(1, "/event/source/123", "271069a8-fc18-44f1-b38f-9d70a1695819"), deploymentCommit.CicdDeploymentId)
+		assert.Equal(t, devops.PRODUCTION, deploymentCommit.Environment)
+		assert.Equal(t, devops.STATUS_DONE, deploymentCommit.Status)
+		assert.Equal(t, devops.RESULT_SUCCESS, deploymentCommit.Result)
+		assert.Equal(t, deployedAt, *deploymentCommit.FinishedDate)
+	}
+	assert.NotEqual(t, deploymentCommits[0].Id, deploymentCommits[1].Id)
+	assert.Equal(t, "deployment of mySubject123", deploymentCommits[0].Name)
+	assert.Equal(t, "015e3d3b480e417aede5a1293bd61de9b0fd051d", deploymentCommits[0].CommitSha)
+	assert.Equal(t, "STAGING", cdeventEnvironment("Staging"))
+	assert.Equal(t, "QA", cdeventEnvironment("qa"))
+}
+
+func TestCDEventIncident(t *testing.T) {
+	detectedAt := time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)
+	issue := newCDEventIncident(1, newTestCDEvent("0.3.0", "dev.cdevents.incident.detected.0.1.0", detectedAt), &CDEventIncidentContent{
+		Environment: &CDEventReference{Id: "prod"},
+		Service:     &CDEventReference{Id: "myapp"},
+	})
+	assert.Equal(t, "webhook:1:mySubject123", issue.Id)
+	assert.Equal(t, "incident mySubject123", issue.Title)
+	assert.Equal(t, ticket.INCIDENT, issue.Type)
+	assert.Equal(t, ticket.TODO, issue.Status)
+	assert.Equal(t, "myapp", issue.Component)
+	assert.Equal(t, detectedAt, *issue.CreatedDate)
+
+	event := cdeventIncidentEvent(newTestCDEvent("0.3.0", "dev.cdevents.incident.detected.0.1.0", detectedAt), false)
+	assert.Equal(t, IncidentTriggered, event.Event)
+	changelogs := applyIssueEvent(1, issue, event)
+	assert.Equal(t, ticket.TODO, changelogs[0].ToValue)
+
+	resolvedAt := detectedAt.Add(time.Hour)
+	event = cdeventIncidentEvent(newTestCDEvent("0.3.0", "dev.cdevents.incident.resolved.0.1.0", resolvedAt), true)
+	assert.Equal(t, IncidentResolved, event.Event)
+	changelogs = applyIssueEvent(1, issue, event)
+	assert.Equal(t, ticket.TODO, changelogs[0].FromValue)
+	assert.Equal(t, ticket.DONE, changelogs[0].ToValue)
+	assert.Equal(t, ticket.DONE, issue.Status)
+	assert.Equal(t, resolvedAt, *issue.ResolutionDate)
+	assert.Equal(t, uint(60), *issue.LeadTimeMinutes)
+}
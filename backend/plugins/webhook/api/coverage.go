@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/coveragehelper"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+// PostCoverage
+// @Summary upload coverage report of a commit
+// @Description Upload the coverage report(LCOV, Cobertura XML or Go coverprofile) of a commit, the format would be detected by the content if omitted. Uploading again replaces the coverage of the commit.
+// @Tags plugins/webhook
+// @Accept multipart/form-data
+// @Param commitSha formData string true "the commit the report was generated from"
+// @Param format formData string false "lcov, cobertura or gocover"
+// @Param goModulePath formData string false "the module path stripped from the file paths of the go coverprofile, i.e. github.com/org/repo"
+// @Param file formData file true "coverage report"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/coverage [POST]
+func PostCoverage(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	if input.Request == nil {
+		return nil, errors.BadInput.New("coverage report is required")
+	}
+	if input.Request.MultipartForm == nil {
+		if err := input.Request.ParseMultipartForm(maxMemory); err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse the multipart form")
+		}
+	}
+	commitSha := strings.TrimSpace(input.Request.FormValue("commitSha"))
+	if commitSha == "" {
+		return nil, errors.BadInput.New("commitSha is required")
+	}
+	file, _, e := input.Request.FormFile("file")
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "coverage report is required")
+	}
+	// nolint
+	defer file.Close()
+	content, e := io.ReadAll(file)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to read the coverage report")
+	}
+	format := strings.TrimSpace(input.Request.FormValue("format"))
+	if format == "" {
+		format = coveragehelper.DetectFormat(content)
+	}
+	files, err := coveragehelper.Parse(format, content, &coveragehelper.ParseOptions{
+		GoModulePath: strings.TrimSpace(input.Request.FormValue("goModulePath")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	project := &codequality.CqProject{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d", "webhook", connection.ID),
+		},
+		Name:      connection.Name,
+		CommitSha: commitSha,
+	}
+	now := time.Now()
+	coverages := coveragehelper.ToCqFileCoverages(project.Id, commitSha, format, &now, files)
+
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	err = tx.CreateOrUpdate(project)
+	if err != nil {
+		return nil, err
+	}
+	// files missing from the new report shouldn't be left behind
+	err = tx.Delete(&codequality.CqFileCoverage{}, dal.Where("project_key = ? AND commit_sha = ?", project.Id, commitSha))
+	if err != nil {
+		return nil, err
+	}
+	for _, coverage := range coverages {
+		err = tx.Create(coverage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
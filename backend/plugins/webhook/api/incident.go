@@ -126,7 +126,17 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 		domainIssue.ParentIssueId = fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, request.ParentIssueKey)
 	}
 
-	domainBoardId := fmt.Sprintf("%s:%d", "webhook", connection.ID)
+	err = saveIssue(db, connection.ID, domainIssue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// saveIssue creates or updates the issue along with the board of the connection
+func saveIssue(db dal.Dal, connectionId uint64, domainIssue *ticket.Issue) errors.Error {
+	domainBoardId := fmt.Sprintf("%s:%d", "webhook", connectionId)
 
 	boardIssue := &ticket.BoardIssue{
 		BoardId: domainBoardId,
@@ -136,7 +146,7 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 	// check if board exists
 	count, err := db.Count(dal.From(&ticket.Board{}), dal.Where("id = ?", domainBoardId))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// only create board with domainBoard non-existent
@@ -148,27 +158,25 @@ func PostIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, error
 		}
 		err = db.Create(domainBoard)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	// save
 	err = db.CreateOrUpdate(domainIssue)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	err = db.CreateOrUpdate(boardIssue)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return nil
 }
 
 // CloseIssue
 // @Summary set issue's status to DONE
-// @Description set issue's status to DONE
+// @Description set issue's status to DONE and record the status changelog
 // @Tags plugins/webhook
 // @Success 200  {string} noResponse ""
 // @Failure 400  {string} errcode.Error "Bad Request"
@@ -187,13 +195,166 @@ func CloseIssue(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, erro
 	if err != nil {
 		return nil, errors.NotFound.Wrap(err, `issue not found`)
 	}
-	domainIssue.Status = ticket.DONE
-	domainIssue.OriginalStatus = ``
+	// closing is recorded as the resolution of the issue, so the status changelog is available to DORA
+	now := time.Now()
+	err = saveIssueEvent(db, connection.ID, domainIssue, &WebhookIssueEventRequest{
+		Event:       IncidentResolved,
+		CreatedDate: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+const (
+	IncidentTriggered    = "triggered"
+	IncidentAcknowledged = "acknowledged"
+	IncidentEscalated    = "escalated"
+	IncidentResolved     = "resolved"
+	IncidentReopened     = "reopened"
+)
 
-	// save
-	err = db.Update(domainIssue)
+type WebhookIssueEventRequest struct {
+	Event        string     `mapstructure:"event" validate:"required,oneof=triggered acknowledged escalated resolved reopened"`
+	CreatedDate  *time.Time `mapstructure:"created_date" validate:"required"`
+	AuthorId     string     `mapstructure:"author_id"`
+	AuthorName   string     `mapstructure:"author_name"`
+	AssigneeId   string     `mapstructure:"assignee_id"`
+	AssigneeName string     `mapstructure:"assignee_name"`
+	Severity     string     `mapstructure:"severity"`
+	Priority     string     `mapstructure:"priority"`
+}
+
+// PostIssueEvent
+// @Summary record a lifecycle event of an incident
+// @Description record a lifecycle event of the issue as changelogs and update the issue accordingly, the status changelogs are used by DORA to calculate the time to acknowledge/restore. example: {"event":"acknowledged","created_date":"2020-01-01T12:00:00+00:00","author_id":"user1131","author_name":"Nick name 1","assignee_id":"user1132","assignee_name":"Nick name 2","severity":"","priority":""}
+// @Tags plugins/webhook
+// @Param body body WebhookIssueEventRequest true "json body"
+// @Success 200  {string} noResponse ""
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/issue/:issueKey/events [POST]
+func PostIssueEvent(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	// get request
+	request := &WebhookIssueEventRequest{}
+	err = helper.DecodeMapStruct(input.Body, request, true)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	// validate
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(request))
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+
+	db := basicRes.GetDal()
+	domainIssue := &ticket.Issue{}
+	err = db.First(domainIssue, dal.Where("id = ?", fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, input.Params[`issueKey`])))
+	if err != nil {
+		return nil, errors.NotFound.Wrap(err, `issue not found`)
+	}
+	err = saveIssueEvent(db, connection.ID, domainIssue, request)
 	if err != nil {
 		return nil, err
 	}
 	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
 }
+
+// saveIssueEvent applies the event to the issue and saves it along with the changelogs in a transaction
+func saveIssueEvent(db dal.Dal, connectionId uint64, issue *ticket.Issue, request *WebhookIssueEventRequest) (err errors.Error) {
+	changelogs := applyIssueEvent(connectionId, issue, request)
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, changelog := range changelogs {
+		err = tx.CreateOrUpdate(changelog)
+		if err != nil {
+			return err
+		}
+	}
+	err = tx.CreateOrUpdate(issue)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applyIssueEvent updates the issue according to the event and returns the changelogs of the fields changed.
+// The status changelog is always recorded since the original status is the event itself
+func applyIssueEvent(connectionId uint64, issue *ticket.Issue, request *WebhookIssueEventRequest) []*ticket.IssueChangelogs {
+	newChangelog := func(field, originalFrom, originalTo, from, to string) *ticket.IssueChangelogs {
+		changelog := &ticket.IssueChangelogs{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%d:%s:%d:%s", "webhook", connectionId, issue.IssueKey, request.CreatedDate.UnixMilli(), field),
+			},
+			IssueId:           issue.Id,
+			AuthorName:        request.AuthorName,
+			FieldId:           field,
+			FieldName:         field,
+			OriginalFromValue: originalFrom,
+			OriginalToValue:   originalTo,
+			FromValue:         from,
+			ToValue:           to,
+			CreatedDate:       *request.CreatedDate,
+		}
+		if request.AuthorId != "" {
+			changelog.AuthorId = fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.AuthorId)
+		}
+		return changelog
+	}
+
+	status := issue.Status
+	switch request.Event {
+	case IncidentTriggered, IncidentReopened:
+		status = ticket.TODO
+		issue.ResolutionDate = nil
+		issue.LeadTimeMinutes = nil
+	case IncidentAcknowledged:
+		status = ticket.IN_PROGRESS
+	case IncidentResolved:
+		status = ticket.DONE
+		issue.ResolutionDate = request.CreatedDate
+		if issue.CreatedDate != nil && !request.CreatedDate.Before(*issue.CreatedDate) {
+			leadTimeMinutes := uint(request.CreatedDate.Sub(*issue.CreatedDate).Minutes())
+			issue.LeadTimeMinutes = &leadTimeMinutes
+		}
+	}
+	changelogs := []*ticket.IssueChangelogs{
+		newChangelog("status", issue.OriginalStatus, request.Event, issue.Status, status),
+	}
+	issue.Status = status
+	issue.OriginalStatus = request.Event
+
+	assigneeId := ""
+	if request.AssigneeId != "" {
+		assigneeId = fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.AssigneeId)
+	}
+	if assigneeId != "" && assigneeId != issue.AssigneeId {
+		changelogs = append(changelogs, newChangelog("assignee", issue.AssigneeName, request.AssigneeName, issue.AssigneeId, assigneeId))
+		issue.AssigneeId = assigneeId
+		issue.AssigneeName = request.AssigneeName
+	}
+	if request.Severity != "" && request.Severity != issue.Severity {
+		changelogs = append(changelogs, newChangelog("severity", issue.Severity, request.Severity, issue.Severity, request.Severity))
+		issue.Severity = request.Severity
+	}
+	if request.Priority != "" && request.Priority != issue.Priority {
+		changelogs = append(changelogs, newChangelog("priority", issue.Priority, request.Priority, issue.Priority, request.Priority))
+		issue.Priority = request.Priority
+	}
+	if issue.UpdatedDate == nil || issue.UpdatedDate.Before(*request.CreatedDate) {
+		issue.UpdatedDate = request.CreatedDate
+	}
+	return changelogs
+}
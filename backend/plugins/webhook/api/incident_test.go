@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyIssueEvent(t *testing.T) {
+	createdDate := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	issue := &ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "webhook:1:INC-1"},
+		IssueKey:       "INC-1",
+		Type:           ticket.INCIDENT,
+		Status:         ticket.TODO,
+		OriginalStatus: IncidentTriggered,
+		CreatedDate:    &createdDate,
+		Severity:       "SEV2",
+	}
+
+	// acknowledged with a new assignee
+	acknowledgedAt := createdDate.Add(15 * time.Minute)
+	changelogs := applyIssueEvent(1, issue, &WebhookIssueEventRequest{
+		Event:        IncidentAcknowledged,
+		CreatedDate:  &acknowledgedAt,
+		AuthorId:     "user1",
+		AuthorName:   "User 1",
+		AssigneeId:   "user2",
+		AssigneeName: "User 2",
+		Severity:     "SEV2",
+	})
+	assert.Len(t, changelogs, 2)
+	assert.Equal(t, "webhook:1:INC-1:1709288100000:status", changelogs[0].Id)
+	assert.Equal(t, "webhook:1:INC-1", changelogs[0].IssueId)
+	assert.Equal(t, "webhook:1:user1", changelogs[0].AuthorId)
+	assert.Equal(t, IncidentTriggered, changelogs[0].OriginalFromValue)
+	assert.Equal(t, IncidentAcknowledged, changelogs[0].OriginalToValue)
+	assert.Equal(t, ticket.TODO, changelogs[0].FromValue)
+	assert.Equal(t, ticket.IN_PROGRESS, changelogs[0].ToValue)
+	assert.Equal(t, acknowledgedAt, changelogs[0].CreatedDate)
+	assert.Equal(t, "assignee", changelogs[1].FieldName)
+	assert.Equal(t, "webhook:1:user2", changelogs[1].ToValue)
+	assert.Equal(t, ticket.IN_PROGRESS, issue.Status)
+	assert.Equal(t, IncidentAcknowledged, issue.OriginalStatus)
+	assert.Equal(t, "webhook:1:user2", issue.AssigneeId)
+	assert.Equal(t, &acknowledgedAt, issue.UpdatedDate)
+
+	// resolved with a new severity
+	resolvedAt := createdDate.Add(90 * time.Minute)
+	changelogs = applyIssueEvent(1, issue, &WebhookIssueEventRequest{
+		Event:       IncidentResolved,
+		CreatedDate: &resolvedAt,
+		Severity:    "SEV1",
+	})
+	assert.Len(t, changelogs, 2)
+	assert.Equal(t, ticket.IN_PROGRESS, changelogs[0].FromValue)
+	assert.Equal(t, ticket.DONE, changelogs[0].ToValue)
+	assert.Empty(t, changelogs[0].AuthorId)
+	assert.Equal(t, "severity", changelogs[1].FieldName)
+	assert.Equal(t, "SEV2", changelogs[1].FromValue)
+	assert.Equal(t, "SEV1", changelogs[1].ToValue)
+	assert.Equal(t, ticket.DONE, issue.Status)
+	assert.Equal(t, &resolvedAt, issue.ResolutionDate)
+	assert.Equal(t, uint(90), *issue.LeadTimeMinutes)
+
+	// reopened clears the resolution
+	reopenedAt := createdDate.Add(120 * time.Minute)
+	changelogs = applyIssueEvent(1, issue, &WebhookIssueEventRequest{
+		Event:       IncidentReopened,
+		CreatedDate: &reopenedAt,
+	})
+	assert.Len(t, changelogs, 1)
+	assert.Equal(t, ticket.DONE, changelogs[0].FromValue)
+	assert.Equal(t, ticket.TODO, changelogs[0].ToValue)
+	assert.Equal(t, ticket.TODO, issue.Status)
+	assert.Nil(t, issue.ResolutionDate)
+	assert.Nil(t, issue.LeadTimeMinutes)
+
+	// an event delivered late does not move the updated date backwards
+	changelogs = applyIssueEvent(1, issue, &WebhookIssueEventRequest{
+		Event:       IncidentEscalated,
+		CreatedDate: &acknowledgedAt,
+	})
+	assert.Len(t, changelogs, 1)
+	assert.Equal(t, ticket.TODO, changelogs[0].ToValue)
+	assert.Equal(t, &reopenedAt, issue.UpdatedDate)
+}
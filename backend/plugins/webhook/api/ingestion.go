@@ -0,0 +1,191 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+const (
+	signatureHeader          = "X-Signature"
+	signatureTimestampHeader = "X-Signature-Timestamp"
+	idempotencyKeyHeader     = "Idempotency-Key"
+	signatureTolerance       = 5 * time.Minute
+	idempotencyKeyExpiry     = 24 * time.Hour
+)
+
+// Ingestion wraps the handlers receiving data from the callers. The payload must be signed if the connection has
+// a signing secret, and the requests sent with the `Idempotency-Key` header would be processed only once
+func Ingestion(handler plugin.ApiResourceHandler) plugin.ApiResourceHandler {
+	return func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		connection := &models.WebhookConnection{}
+		err := connectionHelper.First(connection, input.Params)
+		if err != nil {
+			return nil, err
+		}
+		header := http.Header{}
+		if input.Request != nil {
+			header = input.Request.Header
+		}
+		body, err := readRawBody(input)
+		if err != nil {
+			return nil, err
+		}
+		if connection.SigningSecret != "" {
+			err = verifySignature(connection.SigningSecret, header, body, time.Now())
+			if err != nil {
+				return nil, err
+			}
+		}
+		idempotencyKey := header.Get(idempotencyKeyHeader)
+		if idempotencyKey == "" {
+			return handler(input)
+		}
+		requestHash := sha256.Sum256([]byte(fmt.Sprintf("%s %s\n%s", input.Request.Method, input.Request.URL.Path, body)))
+		return idempotent(connection.ID, idempotencyKey, hex.EncodeToString(requestHash[:]), func() (*plugin.ApiResourceOutput, errors.Error) {
+			return handler(input)
+		})
+	}
+}
+
+func readRawBody(input *plugin.ApiResourceInput) ([]byte, errors.Error) {
+	if input.Request == nil || input.Request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(input.Request.Body)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read request body")
+	}
+	input.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// verifySignature checks the `X-Signature` header which is the hex encoded HMAC-SHA256 of `<timestamp>.<body>`,
+// optionally prefixed with `sha256=`, the timestamp is sent as unix seconds in the `X-Signature-Timestamp` header
+func verifySignature(secret string, header http.Header, body []byte, now time.Time) errors.Error {
+	signature := strings.TrimPrefix(header.Get(signatureHeader), "sha256=")
+	timestamp := header.Get(signatureTimestampHeader)
+	if signature == "" || timestamp == "" {
+		return errors.Unauthorized.New(fmt.Sprintf("%s and %s headers are required", signatureHeader, signatureTimestampHeader))
+	}
+	seconds, parseErr := strconv.ParseInt(timestamp, 10, 64)
+	if parseErr != nil {
+		return errors.Unauthorized.New(fmt.Sprintf("invalid %s header", signatureTimestampHeader))
+	}
+	diff := now.Sub(time.Unix(seconds, 0))
+	if diff > signatureTolerance || diff < -signatureTolerance {
+		return errors.Unauthorized.New("signature timestamp is out of tolerance")
+	}
+	actual, decodeErr := hex.DecodeString(strings.ToLower(signature))
+	if decodeErr != nil || !hmac.Equal(actual, signPayload(secret, timestamp, body)) {
+		return errors.Unauthorized.New("signature mismatch")
+	}
+	return nil
+}
+
+func signPayload(secret string, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// idempotent processes the request only if the key was not seen before, or replays the response saved
+func idempotent(
+	connectionId uint64,
+	idempotencyKey string,
+	requestHash string,
+	process func() (*plugin.ApiResourceOutput, errors.Error),
+) (*plugin.ApiResourceOutput, errors.Error) {
+	db := basicRes.GetDal()
+	err := db.Delete(&models.WebhookIdempotencyKey{}, dal.Where("created_at < ?", time.Now().Add(-idempotencyKeyExpiry)))
+	if err != nil {
+		return nil, err
+	}
+	record := &models.WebhookIdempotencyKey{
+		ConnectionId:   connectionId,
+		IdempotencyKey: idempotencyKey,
+		RequestHash:    requestHash,
+		CreatedAt:      time.Now(),
+	}
+	err = db.Create(record)
+	if err != nil {
+		if !db.IsDuplicationError(err) {
+			return nil, err
+		}
+		existing := &models.WebhookIdempotencyKey{}
+		err = db.First(existing, dal.Where("connection_id = ? AND idempotency_key = ?", connectionId, idempotencyKey))
+		if err != nil {
+			return nil, err
+		}
+		if existing.RequestHash != requestHash {
+			return nil, errors.Conflict.New(fmt.Sprintf("%s was used by a different request", idempotencyKeyHeader))
+		}
+		if existing.Status == 0 {
+			return nil, errors.Conflict.New(fmt.Sprintf("the request with the same %s is being processed", idempotencyKeyHeader))
+		}
+		return &plugin.ApiResourceOutput{
+			Body:   existing.ResponseBody,
+			Status: existing.Status,
+			Header: http.Header{"Idempotent-Replayed": []string{"true"}},
+		}, nil
+	}
+
+	output, err := process()
+	if err != nil {
+		// the request could be retried with the same key since nothing was saved
+		if deleteErr := db.Delete(record); deleteErr != nil {
+			logger.Error(deleteErr, "failed to delete idempotency key %s", idempotencyKey)
+		}
+		return output, err
+	}
+	record.Status = http.StatusOK
+	var body interface{}
+	if output != nil {
+		body = output.Body
+		if output.Status >= http.StatusContinue {
+			record.Status = output.Status
+		}
+	}
+	responseBody, marshalErr := json.Marshal(body)
+	if marshalErr != nil {
+		return nil, errors.Default.Wrap(marshalErr, "failed to save the response of idempotent request")
+	}
+	record.ResponseBody = responseBody
+	err = db.Update(record)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"issue_key":"DLK-1234"}`)
+	now := time.Unix(1707955200, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := hex.EncodeToString(signPayload("secret", timestamp, body))
+
+	header := http.Header{}
+	header.Set(signatureHeader, "sha256="+signature)
+	header.Set(signatureTimestampHeader, timestamp)
+	assert.Nil(t, verifySignature("secret", header, body, now))
+	assert.Nil(t, verifySignature("secret", header, body, now.Add(signatureTolerance)))
+
+	// without prefix
+	header.Set(signatureHeader, signature)
+	assert.Nil(t, verifySignature("secret", header, body, now))
+
+	assert.NotNil(t, verifySignature("another secret", header, body, now))
+	assert.NotNil(t, verifySignature("secret", header, []byte(`{"issue_key":"DLK-1235"}`), now))
+	assert.NotNil(t, verifySignature("secret", header, body, now.Add(signatureTolerance+time.Second)))
+	assert.NotNil(t, verifySignature("secret", header, body, now.Add(-signatureTolerance-time.Second)))
+
+	// the timestamp is signed as well
+	header.Set(signatureTimestampHeader, strconv.FormatInt(now.Unix()+1, 10))
+	assert.NotNil(t, verifySignature("secret", header, body, now))
+
+	assert.NotNil(t, verifySignature("secret", http.Header{}, body, now))
+}
+
+// mockIdempotencyKeySeen mocks the dal as if the idempotency key was saved by a previous request
+func mockIdempotencyKeySeen(existing *models.WebhookIdempotencyKey) *mockdal.Dal {
+	var db *mockdal.Dal
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		db = mockDal
+		mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+		mockDal.On("Create", mock.Anything).Return(errors.Default.New("duplicate entry")).Once()
+		mockDal.On("IsDuplicationError", mock.Anything).Return(true).Once()
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*models.WebhookIdempotencyKey) = *existing
+		}).Return(nil).Once()
+	})
+	return db
+}
+
+func TestIdempotentReplayed(t *testing.T) {
+	db := mockIdempotencyKeySeen(&models.WebhookIdempotencyKey{
+		ConnectionId:   1,
+		IdempotencyKey: "key",
+		RequestHash:    "hash",
+		Status:         http.StatusCreated,
+		ResponseBody:   json.RawMessage(`{"id":1}`),
+	})
+	output, err := idempotent(1, "key", "hash", func() (*plugin.ApiResourceOutput, errors.Error) {
+		t.Fatal("the request should not be processed again")
+		return nil, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, output.Status)
+	assert.Equal(t, json.RawMessage(`{"id":1}`), output.Body)
+	assert.Equal(t, "true", output.Header.Get("Idempotent-Replayed"))
+	db.AssertExpectations(t)
+}
+
+func TestIdempotentKeyReused(t *testing.T) {
+	db := mockIdempotencyKeySeen(&models.WebhookIdempotencyKey{
+		ConnectionId:   1,
+		IdempotencyKey: "key",
+		RequestHash:    "another hash",
+		Status:         http.StatusOK,
+	})
+	_, err := idempotent(1, "key", "hash", func() (*plugin.ApiResourceOutput, errors.Error) {
+		t.Fatal("the request should not be processed")
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.GetType().GetHttpCode())
+	db.AssertExpectations(t)
+}
+
+func TestIdempotentInFlight(t *testing.T) {
+	db := mockIdempotencyKeySeen(&models.WebhookIdempotencyKey{
+		ConnectionId:   1,
+		IdempotencyKey: "key",
+		RequestHash:    "hash",
+	})
+	_, err := idempotent(1, "key", "hash", func() (*plugin.ApiResourceOutput, errors.Error) {
+		t.Fatal("the request should not be processed")
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.GetType().GetHttpCode())
+	db.AssertExpectations(t)
+}
+
+func TestIdempotentProcessed(t *testing.T) {
+	var db *mockdal.Dal
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		db = mockDal
+		mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+		mockDal.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			record := args.Get(0).(*models.WebhookIdempotencyKey)
+			assert.Equal(t, "hash", record.RequestHash)
+			assert.Equal(t, 0, record.Status)
+		}).Return(nil).Once()
+		// the response is saved to be replayed
+		mockDal.On("Update", mock.Anything).Run(func(args mock.Arguments) {
+			record := args.Get(0).(*models.WebhookIdempotencyKey)
+			assert.Equal(t, http.StatusAccepted, record.Status)
+			assert.Equal(t, json.RawMessage(`"ignored"`), record.ResponseBody)
+		}).Return(nil).Once()
+	})
+	output, err := idempotent(1, "key", "hash", func() (*plugin.ApiResourceOutput, errors.Error) {
+		return &plugin.ApiResourceOutput{Body: "ignored", Status: http.StatusAccepted}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, output.Status)
+	db.AssertExpectations(t)
+}
+
+func TestIdempotentKeyReleasedOnError(t *testing.T) {
+	var db *mockdal.Dal
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		db = mockDal
+		mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+		mockDal.On("Create", mock.Anything).Return(nil).Once()
+		// the key is deleted so the request could be retried with it
+		mockDal.On("Delete", mock.MatchedBy(func(record *models.WebhookIdempotencyKey) bool {
+			return record.ConnectionId == 1 && record.IdempotencyKey == "key"
+		})).Return(nil).Once()
+	})
+	logger = unithelper.DummyLogger()
+	_, err := idempotent(1, "key", "hash", func() (*plugin.ApiResourceOutput, errors.Error) {
+		return nil, errors.BadInput.New("invalid request")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.GetType().GetHttpCode())
+	db.AssertExpectations(t)
+}
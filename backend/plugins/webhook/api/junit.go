@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+)
+
+// junitTestSuite is either the `<testsuites>` or the `<testsuite>` element of a JUnit XML report, suites could
+// be nested by some tools
+type junitTestSuite struct {
+	XMLName   xml.Name         `xml:""`
+	Name      string           `xml:"name,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Cases     []junitTestCase  `xml:"testcase"`
+	Suites    []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// parseJUnitReport returns the test suites containing test cases in the JUnit XML report
+func parseJUnitReport(reader io.Reader) ([]*junitTestSuite, errors.Error) {
+	root := &junitTestSuite{}
+	err := xml.NewDecoder(reader).Decode(root)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "malformed JUnit XML report")
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, errors.BadInput.New("JUnit XML report must start with <testsuites> or <testsuite>")
+	}
+	var suites []*junitTestSuite
+	var flatten func(suite *junitTestSuite)
+	flatten = func(suite *junitTestSuite) {
+		if len(suite.Cases) > 0 {
+			suites = append(suites, suite)
+		}
+		for i := range suite.Suites {
+			flatten(&suite.Suites[i])
+		}
+	}
+	flatten(root)
+	return suites, nil
+}
+
+func (testCase *junitTestCase) result() (result string, message string) {
+	switch {
+	case testCase.Failure != nil:
+		return devops.TEST_FAILED, testCase.Failure.String()
+	case testCase.Error != nil:
+		return devops.TEST_ERROR, testCase.Error.String()
+	case testCase.Skipped != nil:
+		return devops.TEST_SKIPPED, testCase.Skipped.String()
+	}
+	return devops.TEST_PASSED, ""
+}
+
+func (message *junitMessage) String() string {
+	if message.Message != "" {
+		return message.Message
+	}
+	return strings.TrimSpace(message.Text)
+}
+
+// parseJUnitSeconds parses the time attribute, some tools format it with thousands separators
+func parseJUnitSeconds(seconds string) float64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(seconds), ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func parseJUnitTimestamp(timestamp string) *time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
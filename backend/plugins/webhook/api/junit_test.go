@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJUnitReport(t *testing.T) {
+	suites, err := parseJUnitReport(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="api" time="1,234.5" timestamp="2024-02-18T10:00:00">
+    <testcase classname="api.UserTest" name="testCreate" time="0.5"/>
+    <testcase classname="api.UserTest" name="testDelete" time="0.25">
+      <failure message="expected 204 but was 500">stack trace</failure>
+    </testcase>
+    <testcase classname="api.UserTest" name="testUpdate"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="nested">
+    <testsuite name="db">
+      <testcase classname="db.Migration" name="up"><error>connection refused</error></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`))
+	assert.Nil(t, err)
+	assert.Len(t, suites, 2)
+
+	assert.Equal(t, "api", suites[0].Name)
+	assert.Equal(t, 1234.5, parseJUnitSeconds(suites[0].Time))
+	assert.NotNil(t, parseJUnitTimestamp(suites[0].Timestamp))
+	assert.Len(t, suites[0].Cases, 3)
+	result, message := suites[0].Cases[0].result()
+	assert.Equal(t, devops.TEST_PASSED, result)
+	assert.Empty(t, message)
+	result, message = suites[0].Cases[1].result()
+	assert.Equal(t, devops.TEST_FAILED, result)
+	assert.Equal(t, "expected 204 but was 500", message)
+	result, _ = suites[0].Cases[2].result()
+	assert.Equal(t, devops.TEST_SKIPPED, result)
+
+	assert.Equal(t, "db", suites[1].Name)
+	result, message = suites[1].Cases[0].result()
+	assert.Equal(t, devops.TEST_ERROR, result)
+	assert.Equal(t, "connection refused", message)
+
+	// single test suite as the root element
+	suites, err = parseJUnitReport(strings.NewReader(`<testsuite name="unit"><testcase name="a"/></testsuite>`))
+	assert.Nil(t, err)
+	assert.Len(t, suites, 1)
+
+	_, err = parseJUnitReport(strings.NewReader(`<html></html>`))
+	assert.NotNil(t, err)
+}
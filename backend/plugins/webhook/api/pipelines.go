@@ -0,0 +1,291 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+// WebhookRunRequest is the common part of pipelines and tasks, the fields omitted would keep the values sent
+// previously, so the run could be reported when it was queued, started and finished separately
+type WebhookRunRequest struct {
+	// Id is supplied by the caller to identify the run, requests with the same id update the same run
+	Id             string     `mapstructure:"id" validate:"required"`
+	Name           string     `mapstructure:"name"`
+	Status         string     `mapstructure:"status" validate:"omitempty,oneof=IN_PROGRESS DONE OTHER"`
+	OriginalStatus string     `mapstructure:"original_status"`
+	Result         string     `mapstructure:"result" validate:"omitempty,oneof=SUCCESS FAILURE"`
+	OriginalResult string     `mapstructure:"original_result"`
+	Environment    string     `mapstructure:"environment"`
+	CreatedDate    *time.Time `mapstructure:"create_time"`
+	QueuedDate     *time.Time `mapstructure:"queue_time"`
+	StartedDate    *time.Time `mapstructure:"start_time"`
+	FinishedDate   *time.Time `mapstructure:"end_time"`
+	DurationSec    *float64   `mapstructure:"duration_sec"`
+}
+
+type WebhookPipelineRequest struct {
+	WebhookRunRequest `mapstructure:",squash"`
+	Type              string                  `mapstructure:"type" validate:"omitempty,oneof=CI CD"`
+	Commits           []WebhookPipelineCommit `mapstructure:"commits" validate:"omitempty,dive"`
+	Tasks             []WebhookTaskRequest    `mapstructure:"tasks" validate:"omitempty,dive"`
+}
+
+type WebhookPipelineCommit struct {
+	// RepoId should be unique string, fill url or other unique data
+	RepoId    string `mapstructure:"repo_id"`
+	RepoUrl   string `mapstructure:"repo_url" validate:"required"`
+	Branch    string `mapstructure:"branch"`
+	CommitSha string `mapstructure:"commit_sha" validate:"required"`
+	CommitMsg string `mapstructure:"commit_msg"`
+}
+
+type WebhookTaskRequest struct {
+	WebhookRunRequest `mapstructure:",squash"`
+	Type              string `mapstructure:"type" validate:"omitempty,oneof=TEST LINT BUILD DEPLOYMENT"`
+}
+
+// PostPipeline
+// @Summary create or update a ci pipeline by webhook
+// @Description Create or update a pipeline along with its commits and tasks, requests with the same id update the same pipeline and omitted fields keep their previous values.<br/>
+// @Description example: {"id":"build-1024","name":"build","type":"CI","status":"DONE","result":"SUCCESS","queue_time":"2020-01-01T11:59:00+00:00","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:10:00+00:00","commits":[{"repo_url":"https://github.com/apache/incubator-devlake","branch":"main","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d"}],"tasks":[{"id":"unit-test","name":"unit test","type":"TEST","result":"SUCCESS","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:05:00+00:00"}]}
+// @Tags plugins/webhook
+// @Param body body WebhookPipelineRequest true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/pipelines [POST]
+func PostPipeline(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	request := &WebhookPipelineRequest{}
+	err = api.DecodeMapStruct(input.Body, request, true)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(request))
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+
+	pipeline := &devops.CICDPipeline{}
+	err = tx.First(pipeline, dal.Where("id = ?", webhookPipelineId(connection.ID, request.Id)))
+	if err != nil && !tx.IsErrorNotFound(err) {
+		return nil, err
+	}
+	pipeline.Id = webhookPipelineId(connection.ID, request.Id)
+	pipeline.CicdScopeId = fmt.Sprintf("%s:%d", "webhook", connection.ID)
+	if request.Type != "" {
+		pipeline.Type = request.Type
+	}
+	pipeline.DurationSec, pipeline.QueuedDurationSec = applyWebhookRun(
+		&request.WebhookRunRequest,
+		&pipeline.Name, &pipeline.Status, &pipeline.OriginalStatus, &pipeline.Result, &pipeline.OriginalResult, &pipeline.Environment,
+		&pipeline.TaskDatesInfo, pipeline.DurationSec,
+	)
+	err = tx.CreateOrUpdate(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range request.Commits {
+		err = tx.CreateOrUpdate(&devops.CiCDPipelineCommit{
+			PipelineId: pipeline.Id,
+			CommitSha:  commit.CommitSha,
+			CommitMsg:  commit.CommitMsg,
+			Branch:     commit.Branch,
+			RepoId:     commit.RepoId,
+			RepoUrl:    commit.RepoUrl,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range request.Tasks {
+		err = saveWebhookTask(tx, connection.ID, pipeline.Id, &request.Tasks[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// PostPipelineTask
+// @Summary create or update a task of a ci pipeline by webhook
+// @Description Create or update a task of the pipeline created by webhook before, requests with the same id update the same task and omitted fields keep their previous values.<br/>
+// @Description example: {"id":"unit-test","name":"unit test","type":"TEST","status":"IN_PROGRESS","start_time":"2020-01-01T12:00:00+00:00"}
+// @Tags plugins/webhook
+// @Param body body WebhookTaskRequest true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/pipelines/:pipelineId/tasks [POST]
+func PostPipelineTask(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	request := &WebhookTaskRequest{}
+	err = api.DecodeMapStruct(input.Body, request, true)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(request))
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+
+	pipelineId := webhookPipelineId(connection.ID, input.Params["pipelineId"])
+	count, err := tx.Count(dal.From(&devops.CICDPipeline{}), dal.Where("id = ?", pipelineId))
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		err = errors.NotFound.New(fmt.Sprintf("pipeline %s not found", input.Params["pipelineId"]))
+		return nil, err
+	}
+	err = saveWebhookTask(tx, connection.ID, pipelineId, request)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+func webhookPipelineId(connectionId uint64, id string) string {
+	return fmt.Sprintf("%s:%d:%s", "webhook", connectionId, id)
+}
+
+func saveWebhookTask(db dal.Dal, connectionId uint64, pipelineId string, request *WebhookTaskRequest) errors.Error {
+	taskId := fmt.Sprintf("%s:%s", pipelineId, request.Id)
+	task := &devops.CICDTask{}
+	err := db.First(task, dal.Where("id = ?", taskId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return err
+	}
+	task.Id = taskId
+	task.PipelineId = pipelineId
+	task.CicdScopeId = fmt.Sprintf("%s:%d", "webhook", connectionId)
+	if request.Type != "" {
+		task.Type = request.Type
+	}
+	task.DurationSec, task.QueuedDurationSec = applyWebhookRun(
+		&request.WebhookRunRequest,
+		&task.Name, &task.Status, &task.OriginalStatus, &task.Result, &task.OriginalResult, &task.Environment,
+		&task.TaskDatesInfo, task.DurationSec,
+	)
+	return db.CreateOrUpdate(task)
+}
+
+// applyWebhookRun merges the fields sent by the request into the run, the status would be derived from the dates
+// if it was not sent. It returns the duration and the queued duration of the run
+func applyWebhookRun(
+	request *WebhookRunRequest,
+	name, status, originalStatus, result, originalResult, environment *string,
+	dates *devops.TaskDatesInfo,
+	durationSec float64,
+) (float64, *float64) {
+	setIfNotEmpty := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	setIfNotEmpty(name, request.Name)
+	if *name == "" {
+		*name = request.Id
+	}
+	setIfNotEmpty(result, request.Result)
+	setIfNotEmpty(originalResult, request.OriginalResult)
+	if request.OriginalResult == "" && request.Result != "" {
+		*originalResult = request.Result
+	}
+	setIfNotEmpty(environment, request.Environment)
+
+	if request.CreatedDate != nil {
+		dates.CreatedDate = *request.CreatedDate
+	}
+	if request.QueuedDate != nil {
+		dates.QueuedDate = request.QueuedDate
+	}
+	if request.StartedDate != nil {
+		dates.StartedDate = request.StartedDate
+	}
+	if request.FinishedDate != nil {
+		dates.FinishedDate = request.FinishedDate
+	}
+	if dates.CreatedDate.IsZero() {
+		switch {
+		case dates.QueuedDate != nil:
+			dates.CreatedDate = *dates.QueuedDate
+		case dates.StartedDate != nil:
+			dates.CreatedDate = *dates.StartedDate
+		default:
+			dates.CreatedDate = time.Now()
+		}
+	}
+
+	derivedStatus := devops.STATUS_IN_PROGRESS
+	derivedOriginalStatus := "QUEUED"
+	if dates.StartedDate != nil {
+		derivedOriginalStatus = devops.STATUS_IN_PROGRESS
+	}
+	if dates.FinishedDate != nil {
+		derivedStatus = devops.STATUS_DONE
+		derivedOriginalStatus = devops.STATUS_DONE
+	}
+	if request.Status != "" {
+		*status = request.Status
+		*originalStatus = request.Status
+	} else {
+		*status = derivedStatus
+		*originalStatus = derivedOriginalStatus
+	}
+	setIfNotEmpty(originalStatus, request.OriginalStatus)
+
+	if request.DurationSec != nil {
+		durationSec = *request.DurationSec
+	} else if dates.StartedDate != nil && dates.FinishedDate != nil {
+		durationSec = float64(dates.FinishedDate.Sub(*dates.StartedDate).Milliseconds() / 1e3)
+	}
+	return durationSec, dates.CalculateQueueDuration()
+}
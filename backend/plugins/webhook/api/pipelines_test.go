@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func applyTestWebhookRun(pipeline *devops.CICDPipeline, request *WebhookRunRequest) {
+	pipeline.DurationSec, pipeline.QueuedDurationSec = applyWebhookRun(
+		request,
+		&pipeline.Name, &pipeline.Status, &pipeline.OriginalStatus, &pipeline.Result, &pipeline.OriginalResult, &pipeline.Environment,
+		&pipeline.TaskDatesInfo, pipeline.DurationSec,
+	)
+}
+
+func TestApplyWebhookRun(t *testing.T) {
+	queuedAt := time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)
+	startedAt := queuedAt.Add(time.Minute)
+	finishedAt := startedAt.Add(10 * time.Minute)
+	pipeline := &devops.CICDPipeline{}
+
+	// queued, the name defaults to the id and the created date to the queued date
+	applyTestWebhookRun(pipeline, &WebhookRunRequest{
+		Id:          "build-1024",
+		Environment: devops.PRODUCTION,
+		QueuedDate:  &queuedAt,
+	})
+	assert.Equal(t, "build-1024", pipeline.Name)
+	assert.Equal(t, devops.STATUS_IN_PROGRESS, pipeline.Status)
+	assert.Equal(t, "QUEUED", pipeline.OriginalStatus)
+	assert.Equal(t, queuedAt, pipeline.CreatedDate)
+	assert.Equal(t, devops.RESULT_DEFAULT, pipeline.Result)
+
+	// started, the fields omitted keep their previous values
+	applyTestWebhookRun(pipeline, &WebhookRunRequest{
+		Id:          "build-1024",
+		Name:        "build",
+		StartedDate: &startedAt,
+	})
+	assert.Equal(t, "build", pipeline.Name)
+	assert.Equal(t, devops.STATUS_IN_PROGRESS, pipeline.Status)
+	assert.Equal(t, devops.STATUS_IN_PROGRESS, pipeline.OriginalStatus)
+	assert.Equal(t, devops.PRODUCTION, pipeline.Environment)
+	assert.Equal(t, queuedAt, *pipeline.QueuedDate)
+	assert.Equal(t, float64(60), *pipeline.QueuedDurationSec)
+
+	// finished, the original result defaults to the result
+	applyTestWebhookRun(pipeline, &WebhookRunRequest{
+		Id:           "build-1024",
+		Result:       devops.RESULT_FAILURE,
+		FinishedDate: &finishedAt,
+	})
+	assert.Equal(t, "build", pipeline.Name)
+	assert.Equal(t, devops.STATUS_DONE, pipeline.Status)
+	assert.Equal(t, devops.STATUS_DONE, pipeline.OriginalStatus)
+	assert.Equal(t, devops.RESULT_FAILURE, pipeline.Result)
+	assert.Equal(t, devops.RESULT_FAILURE, pipeline.OriginalResult)
+	assert.Equal(t, startedAt, *pipeline.StartedDate)
+	assert.Equal(t, float64(600), pipeline.DurationSec)
+	assert.Equal(t, queuedAt, pipeline.CreatedDate)
+
+	// the status, original values and duration sent explicitly take precedence
+	durationSec := float64(500)
+	applyTestWebhookRun(pipeline, &WebhookRunRequest{
+		Id:             "build-1024",
+		Status:         "OTHER",
+		OriginalStatus: "aborted",
+		Result:         devops.RESULT_SUCCESS,
+		OriginalResult: "passed",
+		DurationSec:    &durationSec,
+	})
+	assert.Equal(t, "OTHER", pipeline.Status)
+	assert.Equal(t, "aborted", pipeline.OriginalStatus)
+	assert.Equal(t, devops.RESULT_SUCCESS, pipeline.Result)
+	assert.Equal(t, "passed", pipeline.OriginalResult)
+	assert.Equal(t, float64(500), pipeline.DurationSec)
+
+	// the original result is kept if neither result nor original result was sent
+	applyTestWebhookRun(pipeline, &WebhookRunRequest{Id: "build-1024"})
+	assert.Equal(t, devops.RESULT_SUCCESS, pipeline.Result)
+	assert.Equal(t, "passed", pipeline.OriginalResult)
+}
+
+func TestApplyWebhookRunWithoutDates(t *testing.T) {
+	task := &devops.CICDTask{}
+	before := time.Now()
+	task.DurationSec, task.QueuedDurationSec = applyWebhookRun(
+		&WebhookRunRequest{Id: "unit-test", Status: devops.STATUS_DONE, Result: devops.RESULT_SUCCESS},
+		&task.Name, &task.Status, &task.OriginalStatus, &task.Result, &task.OriginalResult, &task.Environment,
+		&task.TaskDatesInfo, task.DurationSec,
+	)
+	assert.Equal(t, devops.STATUS_DONE, task.Status)
+	assert.Equal(t, devops.STATUS_DONE, task.OriginalStatus)
+	assert.Equal(t, devops.RESULT_SUCCESS, task.OriginalResult)
+	assert.False(t, task.CreatedDate.Before(before))
+	assert.Equal(t, float64(0), task.DurationSec)
+	assert.Nil(t, task.QueuedDurationSec)
+}
@@ -0,0 +1,292 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// sarifLog is the subset of the SARIF 2.1.0 log used to import code scanning results,
+// see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	Id                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifProperties struct {
+	Tags []string `json:"tags"`
+	// the CVSS score used by GitHub code scanning, it is a string like "7.5"
+	SecuritySeverity string `json:"security-severity"`
+}
+
+type sarifResult struct {
+	RuleId    string `json:"ruleId"`
+	RuleIndex *int   `json:"ruleIndex"`
+	Rule      *struct {
+		Id    string `json:"id"`
+		Index *int   `json:"index"`
+	} `json:"rule"`
+	Kind                string            `json:"kind"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations"`
+	Fingerprints        map[string]string `json:"fingerprints"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Suppressions        []struct {
+		Status string `json:"status"`
+	} `json:"suppressions"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			Uri string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+			EndLine     int `json:"endLine"`
+			EndColumn   int `json:"endColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+	Message sarifMessage `json:"message"`
+}
+
+// sarifIssue is a result of a run resolved against its rule
+type sarifIssue struct {
+	Fingerprint string
+	RuleId      string
+	Severity    string
+	Type        string
+	Tags        []string
+	Message     string
+	Locations   []sarifLocation
+}
+
+// parseSarifLog decodes the SARIF log, only version 2.1.0 is supported
+func parseSarifLog(reader io.Reader) (*sarifLog, errors.Error) {
+	log := &sarifLog{}
+	err := json.NewDecoder(reader).Decode(log)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "malformed SARIF log")
+	}
+	if log.Version != "2.1.0" {
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported SARIF version %s", log.Version))
+	}
+	for _, run := range log.Runs {
+		if run.Tool.Driver.Name == "" {
+			return nil, errors.BadInput.New("tool.driver.name of the run is required")
+		}
+	}
+	return log, nil
+}
+
+// issues returns the results reported as problems by the run, results with the same fingerprint are merged
+func (run *sarifRun) issues() []*sarifIssue {
+	rules := make(map[string]*sarifRule, len(run.Tool.Driver.Rules))
+	for i := range run.Tool.Driver.Rules {
+		rules[run.Tool.Driver.Rules[i].Id] = &run.Tool.Driver.Rules[i]
+	}
+	var issues []*sarifIssue
+	seen := make(map[string]bool)
+	for i := range run.Results {
+		result := &run.Results[i]
+		if !result.isProblem() {
+			continue
+		}
+		rule := run.rule(result, rules)
+		issue := &sarifIssue{
+			RuleId:  result.RuleId,
+			Message: result.Message.Text,
+			// the primary location goes first, followed by the related ones
+			Locations: append(append([]sarifLocation{}, result.Locations...), result.RelatedLocations...),
+		}
+		if issue.RuleId == "" && rule != nil {
+			issue.RuleId = rule.Id
+		}
+		level := result.Level
+		properties := result.Properties
+		if rule != nil {
+			if level == "" {
+				level = rule.DefaultConfiguration.Level
+			}
+			if properties.SecuritySeverity == "" {
+				properties.SecuritySeverity = rule.Properties.SecuritySeverity
+			}
+			properties.Tags = append(properties.Tags, rule.Properties.Tags...)
+		}
+		issue.Tags = properties.Tags
+		issue.Severity, issue.Type = sarifSeverityAndType(level, properties)
+		issue.Fingerprint = result.fingerprint(issue.RuleId)
+		if seen[issue.Fingerprint] {
+			continue
+		}
+		seen[issue.Fingerprint] = true
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// sarifToolIssues merges the issues of the runs by tool, as a tool may split its results into several runs, tools are
+// returned in the order they appear
+func sarifToolIssues(runs []sarifRun) ([]string, map[string][]*sarifIssue) {
+	var tools []string
+	issues := make(map[string][]*sarifIssue)
+	seen := make(map[string]map[string]bool)
+	for i := range runs {
+		tool := strings.ToLower(runs[i].Tool.Driver.Name)
+		if seen[tool] == nil {
+			tools = append(tools, tool)
+			seen[tool] = make(map[string]bool)
+		}
+		for _, issue := range runs[i].issues() {
+			if seen[tool][issue.Fingerprint] {
+				continue
+			}
+			seen[tool][issue.Fingerprint] = true
+			issues[tool] = append(issues[tool], issue)
+		}
+	}
+	return tools, issues
+}
+
+func (run *sarifRun) rule(result *sarifResult, rules map[string]*sarifRule) *sarifRule {
+	index := result.RuleIndex
+	if result.Rule != nil && result.Rule.Index != nil {
+		index = result.Rule.Index
+	}
+	if index != nil && *index >= 0 && *index < len(run.Tool.Driver.Rules) {
+		return &run.Tool.Driver.Rules[*index]
+	}
+	ruleId := result.RuleId
+	if ruleId == "" && result.Rule != nil {
+		ruleId = result.Rule.Id
+	}
+	return rules[ruleId]
+}
+
+// isProblem tells if the result should be tracked as an issue, passed checks and suppressed results are not
+func (result *sarifResult) isProblem() bool {
+	switch result.Kind {
+	case "pass", "notApplicable", "informational":
+		return false
+	}
+	for _, suppression := range result.Suppressions {
+		if suppression.Status == "" || suppression.Status == "accepted" {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprint identifies the result across runs, the stable fingerprints computed by the tool are preferred, the
+// rule, file, region and message are used otherwise, so the same problem reported at different places of a file is
+// tracked as different issues
+func (result *sarifResult) fingerprint(ruleId string) string {
+	identity := ""
+	for _, fingerprints := range []map[string]string{result.Fingerprints, result.PartialFingerprints} {
+		if len(fingerprints) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(fingerprints))
+		for key := range fingerprints {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		identity = keys[0] + "=" + fingerprints[keys[0]]
+		break
+	}
+	if identity == "" {
+		uri, region := "", ""
+		if len(result.Locations) > 0 {
+			location := result.Locations[0].PhysicalLocation
+			uri = location.ArtifactLocation.Uri
+			region = fmt.Sprintf("%d:%d-%d:%d", location.Region.StartLine, location.Region.StartColumn, location.Region.EndLine, location.Region.EndColumn)
+		}
+		identity = strings.Join([]string{ruleId, uri, region, result.Message.Text}, "\n")
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(ruleId+"\n"+identity)))
+}
+
+// sarifSeverityAndType maps the result to the severity and type used by cq_issues, the security severity takes
+// precedence over the level
+func sarifSeverityAndType(level string, properties sarifProperties) (string, string) {
+	issueType := "CODE_SMELL"
+	for _, tag := range properties.Tags {
+		if strings.EqualFold(tag, "security") {
+			issueType = "VULNERABILITY"
+		} else if issueType == "CODE_SMELL" && strings.EqualFold(tag, "correctness") {
+			issueType = "BUG"
+		}
+	}
+	if score, err := strconv.ParseFloat(properties.SecuritySeverity, 64); err == nil {
+		issueType = "VULNERABILITY"
+		switch {
+		case score >= 9:
+			return "BLOCKER", issueType
+		case score >= 7:
+			return "CRITICAL", issueType
+		case score >= 4:
+			return "MAJOR", issueType
+		default:
+			return "MINOR", issueType
+		}
+	}
+	// level defaults to warning, see 3.27.10 of the spec
+	switch level {
+	case "error":
+		return "CRITICAL", issueType
+	case "note":
+		return "MINOR", issueType
+	case "none":
+		return "INFO", issueType
+	}
+	return "MAJOR", issueType
+}
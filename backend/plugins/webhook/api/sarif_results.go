@@ -0,0 +1,265 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+// status of cq_issues imported from SARIF logs
+const (
+	SarifIssueOpen     = "OPEN"
+	SarifIssueReopened = "REOPENED"
+	SarifIssueClosed   = "CLOSED"
+)
+
+// SarifImportResult counts the issues of the uploaded SARIF log
+type SarifImportResult struct {
+	New   int `json:"new"`
+	Fixed int `json:"fixed"`
+	Open  int `json:"open"`
+}
+
+// PostSarif
+// @Summary upload SARIF log of a commit
+// @Description Upload the SARIF 2.1.0 log produced by code scanning tools like Semgrep, CodeQL, gosec and Trivy. Results are tracked by fingerprint per tool of the project, issues missing from the latest upload of the tool are closed as fixed by the commit. Runs of the same tool in the log are merged. Uploads of a tool should be sent in the order of commits.
+// @Tags plugins/webhook
+// @Accept multipart/form-data
+// @Param projectKey formData string true "the key of the project analyzed, e.g. the domain id of the repo"
+// @Param commitSha formData string true "the commit analyzed"
+// @Param file formData file true "SARIF log"
+// @Success 200  {object} SarifImportResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/sarif [POST]
+func PostSarif(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	if input.Request == nil {
+		return nil, errors.BadInput.New("SARIF log is required")
+	}
+	if input.Request.MultipartForm == nil {
+		if err := input.Request.ParseMultipartForm(maxMemory); err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse the multipart form")
+		}
+	}
+	projectKey := strings.TrimSpace(input.Request.FormValue("projectKey"))
+	if projectKey == "" {
+		return nil, errors.BadInput.New("projectKey is required")
+	}
+	commitSha := strings.TrimSpace(input.Request.FormValue("commitSha"))
+	if commitSha == "" {
+		return nil, errors.BadInput.New("commitSha is required")
+	}
+	file, _, e := input.Request.FormFile("file")
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "SARIF log is required")
+	}
+	// nolint
+	defer file.Close()
+	log, err := parseSarifLog(file)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	project := &codequality.CqProject{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: projectKey,
+		},
+		Name:             projectKey,
+		CommitSha:        commitSha,
+		LastAnalysisDate: &common.Iso8601Time{Time: now},
+	}
+	result := &SarifImportResult{}
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	err = tx.CreateOrUpdate(project)
+	if err != nil {
+		return nil, err
+	}
+	tools, issues := sarifToolIssues(log.Runs)
+	for _, tool := range tools {
+		err = saveSarifRun(tx, connection.ID, project.Id, commitSha, now, tool, issues[tool], result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
+
+// sarifIssueId identifies the cq_issue of the fingerprint, the project key and the tool are hashed along with the
+// fingerprint to keep the id within 255 characters
+func sarifIssueId(connectionId uint64, projectKey, tool, fingerprint string) string {
+	return fmt.Sprintf("%s:%d:%x", "webhook", connectionId, md5.Sum([]byte(projectKey+"\n"+tool+"\n"+fingerprint)))
+}
+
+// saveSarifRun saves the issues reported by the runs of the tool, and closes the previous issues of the tool in the
+// project missing from the runs
+func saveSarifRun(tx dal.Transaction, connectionId uint64, projectKey, commitSha string, now time.Time, tool string, issues []*sarifIssue, result *SarifImportResult) errors.Error {
+	var fingerprints []*models.WebhookSarifFingerprint
+	err := tx.All(&fingerprints, dal.Where("connection_id = ? AND project_key = ? AND tool = ?", connectionId, projectKey, tool))
+	if err != nil {
+		return err
+	}
+	previous := make(map[string]*models.WebhookSarifFingerprint, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		previous[fingerprint.Fingerprint] = fingerprint
+	}
+
+	for _, sarifIssue := range issues {
+		fingerprint, ok := previous[sarifIssue.Fingerprint]
+		delete(previous, sarifIssue.Fingerprint)
+		status := SarifIssueOpen
+		if !ok {
+			fingerprint = &models.WebhookSarifFingerprint{
+				ConnectionId:       connectionId,
+				ProjectKey:         projectKey,
+				Tool:               tool,
+				Fingerprint:        sarifIssue.Fingerprint,
+				IssueId:            sarifIssueId(connectionId, projectKey, tool, sarifIssue.Fingerprint),
+				FirstSeenCommitSha: commitSha,
+				FirstSeenDate:      now,
+			}
+			result.New++
+		} else if fingerprint.FixedDate != nil {
+			status = SarifIssueReopened
+			fingerprint.FixedCommitSha = ""
+			fingerprint.FixedDate = nil
+			result.New++
+		}
+		fingerprint.LastSeenCommitSha = commitSha
+		fingerprint.LastSeenDate = now
+		result.Open++
+		err = tx.CreateOrUpdate(fingerprint)
+		if err != nil {
+			return err
+		}
+		err = saveSarifIssue(tx, projectKey, fingerprint, status, sarifIssue)
+		if err != nil {
+			return err
+		}
+	}
+
+	// issues not reported by the tool anymore are fixed by the commit
+	for _, fingerprint := range previous {
+		if fingerprint.FixedDate != nil {
+			continue
+		}
+		fingerprint.FixedCommitSha = commitSha
+		fingerprint.FixedDate = &now
+		result.Fixed++
+		err = tx.CreateOrUpdate(fingerprint)
+		if err != nil {
+			return err
+		}
+		err = tx.UpdateColumns(
+			&codequality.CqIssue{},
+			[]dal.DalSet{
+				{ColumnName: "status", Value: SarifIssueClosed},
+				{ColumnName: "updated_date", Value: now},
+			},
+			dal.Where("id = ?", fingerprint.IssueId),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveSarifIssue(tx dal.Transaction, projectKey string, fingerprint *models.WebhookSarifFingerprint, status string, sarifIssue *sarifIssue) errors.Error {
+	issue := &codequality.CqIssue{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fingerprint.IssueId,
+		},
+		Rule:        sarifIssue.RuleId,
+		Severity:    sarifIssue.Severity,
+		ProjectKey:  projectKey,
+		Status:      status,
+		Message:     sarifIssue.Message,
+		Hash:        fingerprint.Fingerprint,
+		Tags:        strings.Join(sarifIssue.Tags, ","),
+		Type:        sarifIssue.Type,
+		Scope:       fingerprint.Tool,
+		CreatedDate: &common.Iso8601Time{Time: fingerprint.FirstSeenDate},
+		UpdatedDate: &common.Iso8601Time{Time: fingerprint.LastSeenDate},
+	}
+	var codeBlocks []*codequality.CqIssueCodeBlock
+	for i, location := range sarifIssue.Locations {
+		region := location.PhysicalLocation.Region
+		component := location.PhysicalLocation.ArtifactLocation.Uri
+		endLine := region.EndLine
+		if endLine == 0 {
+			endLine = region.StartLine
+		}
+		if i == 0 {
+			issue.Component = component
+			issue.Line = region.StartLine
+			issue.StartLine = region.StartLine
+			issue.EndLine = endLine
+			issue.StartOffset = region.StartColumn
+			issue.EndOffset = region.EndColumn
+		}
+		codeBlocks = append(codeBlocks, &codequality.CqIssueCodeBlock{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%d", issue.Id, i),
+			},
+			IssueKey:    issue.Id,
+			Component:   component,
+			StartLine:   region.StartLine,
+			EndLine:     endLine,
+			StartOffset: region.StartColumn,
+			EndOffset:   region.EndColumn,
+			Msg:         location.Message.Text,
+		})
+	}
+	err := tx.CreateOrUpdate(issue)
+	if err != nil {
+		return err
+	}
+	// locations of the issue may change between commits
+	err = tx.Delete(&codequality.CqIssueCodeBlock{}, dal.Where("issue_key = ?", issue.Id))
+	if err != nil {
+		return err
+	}
+	for _, codeBlock := range codeBlocks {
+		err = tx.Create(codeBlock)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSarifProjectKey = "github:GithubRepo:1:1"
+
+func newTestSarifIssue(fingerprint string) *sarifIssue {
+	location := sarifLocation{}
+	location.PhysicalLocation.ArtifactLocation.Uri = "db/user.go"
+	location.PhysicalLocation.Region.StartLine = 10
+	return &sarifIssue{
+		Fingerprint: fingerprint,
+		RuleId:      "go.sql-injection",
+		Severity:    "CRITICAL",
+		Type:        "VULNERABILITY",
+		Message:     "user input flows into the query",
+		Locations:   []sarifLocation{location},
+	}
+}
+
+func newTestSarifFingerprint(fingerprint string, fixedDate *time.Time) *models.WebhookSarifFingerprint {
+	return &models.WebhookSarifFingerprint{
+		ConnectionId:       1,
+		ProjectKey:         testSarifProjectKey,
+		Tool:               "semgrep",
+		Fingerprint:        fingerprint,
+		IssueId:            sarifIssueId(1, testSarifProjectKey, "semgrep", fingerprint),
+		FirstSeenCommitSha: "c1",
+		FirstSeenDate:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		FixedDate:          fixedDate,
+	}
+}
+
+// mockSarifTx mocks the transaction with the fingerprints saved by previous uploads of the project, entities saved
+// by saveSarifRun are collected to the maps
+func mockSarifTx(
+	t *testing.T,
+	previous []*models.WebhookSarifFingerprint,
+	fingerprints map[string]*models.WebhookSarifFingerprint,
+	issues map[string]*codequality.CqIssue,
+) *mockdal.Transaction {
+	tx := new(mockdal.Transaction)
+	tx.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(1).(dal.Clause).Data.(dal.DalClause)
+		assert.Equal(t, []interface{}{uint64(1), testSarifProjectKey, "semgrep"}, where.Params)
+		*args.Get(0).(*[]*models.WebhookSarifFingerprint) = previous
+	}).Return(nil).Once()
+	tx.On("CreateOrUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		switch entity := args.Get(0).(type) {
+		case *models.WebhookSarifFingerprint:
+			fingerprints[entity.Fingerprint] = entity
+		case *codequality.CqIssue:
+			issues[entity.Id] = entity
+		}
+	}).Return(nil)
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	tx.On("Create", mock.Anything).Return(nil)
+	return tx
+}
+
+func TestSaveSarifRun(t *testing.T) {
+	fixedDate := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	previous := []*models.WebhookSarifFingerprint{
+		newTestSarifFingerprint("a", nil),
+		newTestSarifFingerprint("b", nil),
+		newTestSarifFingerprint("c", &fixedDate),
+	}
+	fingerprints := make(map[string]*models.WebhookSarifFingerprint)
+	issues := make(map[string]*codequality.CqIssue)
+	tx := mockSarifTx(t, previous, fingerprints, issues)
+	tx.On("UpdateColumns", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(2).(dal.Clause).Data.(dal.DalClause)
+		assert.Equal(t, []interface{}{previous[1].IssueId}, where.Params)
+	}).Return(nil).Once()
+
+	now := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	result := &SarifImportResult{}
+	err := saveSarifRun(tx, 1, testSarifProjectKey, "c5", now, "semgrep", []*sarifIssue{
+		newTestSarifIssue("a"),
+		newTestSarifIssue("c"),
+		newTestSarifIssue("d"),
+	}, result)
+	assert.Nil(t, err)
+	assert.Equal(t, &SarifImportResult{New: 2, Fixed: 1, Open: 3}, result)
+
+	// still open
+	assert.Equal(t, "c1", fingerprints["a"].FirstSeenCommitSha)
+	assert.Equal(t, "c5", fingerprints["a"].LastSeenCommitSha)
+	assert.Equal(t, SarifIssueOpen, issues[previous[0].IssueId].Status)
+	// fixed
+	assert.Equal(t, "c5", fingerprints["b"].FixedCommitSha)
+	assert.Equal(t, &now, fingerprints["b"].FixedDate)
+	// reopened
+	assert.Nil(t, fingerprints["c"].FixedDate)
+	assert.Equal(t, SarifIssueReopened, issues[previous[2].IssueId].Status)
+	// new
+	assert.Equal(t, testSarifProjectKey, fingerprints["d"].ProjectKey)
+	assert.Equal(t, "c5", fingerprints["d"].FirstSeenCommitSha)
+	assert.Equal(t, sarifIssueId(1, testSarifProjectKey, "semgrep", "d"), fingerprints["d"].IssueId)
+	issue := issues[fingerprints["d"].IssueId]
+	assert.Equal(t, SarifIssueOpen, issue.Status)
+	assert.Equal(t, testSarifProjectKey, issue.ProjectKey)
+	assert.Equal(t, "db/user.go", issue.Component)
+	assert.Equal(t, 10, issue.Line)
+	tx.AssertExpectations(t)
+}
+
+func TestSaveSarifRunMergedRuns(t *testing.T) {
+	// the results of a tool split into two runs must not close each other
+	runs := []sarifRun{{}, {}}
+	for i, fingerprint := range []string{"abc:1", "abc:2"} {
+		runs[i].Tool.Driver.Name = "Semgrep"
+		runs[i].Results = []sarifResult{{
+			RuleId:              "go.sql-injection",
+			Message:             sarifMessage{Text: "user input flows into the query"},
+			Locations:           newTestSarifIssue(fingerprint).Locations,
+			PartialFingerprints: map[string]string{"primaryLocationLineHash": fingerprint},
+		}}
+	}
+	tools, toolIssues := sarifToolIssues(runs)
+	assert.Equal(t, []string{"semgrep"}, tools)
+
+	previous := []*models.WebhookSarifFingerprint{
+		newTestSarifFingerprint(toolIssues["semgrep"][0].Fingerprint, nil),
+		newTestSarifFingerprint(toolIssues["semgrep"][1].Fingerprint, nil),
+	}
+	fingerprints := make(map[string]*models.WebhookSarifFingerprint)
+	issues := make(map[string]*codequality.CqIssue)
+	tx := mockSarifTx(t, previous, fingerprints, issues)
+
+	result := &SarifImportResult{}
+	err := saveSarifRun(tx, 1, testSarifProjectKey, "c5", time.Now(), "semgrep", toolIssues["semgrep"], result)
+	assert.Nil(t, err)
+	assert.Equal(t, &SarifImportResult{New: 0, Fixed: 0, Open: 2}, result)
+	tx.AssertNotCalled(t, "UpdateColumns", mock.Anything, mock.Anything, mock.Anything)
+	tx.AssertExpectations(t)
+}
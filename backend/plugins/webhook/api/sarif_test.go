@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSarifLog = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "Semgrep", "rules": [
+      {"id": "go.sql-injection", "defaultConfiguration": {"level": "error"}, "properties": {"tags": ["security"], "security-severity": "9.8"}},
+      {"id": "go.unused-result", "defaultConfiguration": {"level": "note"}, "properties": {"tags": ["correctness"]}}
+    ]}},
+    "results": [
+      {
+        "ruleId": "go.sql-injection",
+        "message": {"text": "user input flows into the query"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "db/user.go"}, "region": {"startLine": 10, "startColumn": 2, "endColumn": 30}}}],
+        "relatedLocations": [{"physicalLocation": {"artifactLocation": {"uri": "api/user.go"}, "region": {"startLine": 5}}, "message": {"text": "source"}}],
+        "partialFingerprints": {"primaryLocationLineHash": "abc:1"}
+      },
+      {
+        "ruleIndex": 1,
+        "message": {"text": "result is ignored"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "db/user.go"}, "region": {"startLine": 20}}}]
+      },
+      {
+        "ruleId": "go.unused-result",
+        "level": "warning",
+        "message": {"text": "result is ignored"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "db/user.go"}, "region": {"startLine": 42}}}]
+      },
+      {"ruleId": "go.unused-result", "kind": "pass", "message": {"text": "ok"}},
+      {"ruleId": "go.unused-result", "message": {"text": "accepted"}, "suppressions": [{"kind": "inSource"}]}
+    ]
+  }]
+}`
+
+func TestParseSarifLog(t *testing.T) {
+	log, err := parseSarifLog(strings.NewReader(testSarifLog))
+	assert.Nil(t, err)
+	assert.Len(t, log.Runs, 1)
+
+	issues := log.Runs[0].issues()
+	// the third result is the same rule as the second one in another line, passed and suppressed results are ignored
+	assert.Len(t, issues, 3)
+
+	assert.Equal(t, "go.sql-injection", issues[0].RuleId)
+	assert.Equal(t, "BLOCKER", issues[0].Severity)
+	assert.Equal(t, "VULNERABILITY", issues[0].Type)
+	assert.Len(t, issues[0].Locations, 2)
+	assert.Equal(t, "api/user.go", issues[0].Locations[1].PhysicalLocation.ArtifactLocation.Uri)
+
+	assert.Equal(t, "go.unused-result", issues[1].RuleId)
+	assert.Equal(t, "MINOR", issues[1].Severity)
+	assert.Equal(t, "BUG", issues[1].Type)
+	assert.Equal(t, []string{"correctness"}, issues[1].Tags)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
+
+func TestParseSarifLogInvalid(t *testing.T) {
+	_, err := parseSarifLog(strings.NewReader(`{"version": "1.0.0", "runs": []}`))
+	assert.NotNil(t, err)
+	_, err = parseSarifLog(strings.NewReader(`{"version": "2.1.0", "runs": [{"tool": {"driver": {}}}]}`))
+	assert.NotNil(t, err)
+	_, err = parseSarifLog(strings.NewReader(`not json`))
+	assert.NotNil(t, err)
+}
+
+func TestSarifFingerprint(t *testing.T) {
+	result := &sarifResult{
+		Message:   sarifMessage{Text: "result is ignored"},
+		Locations: []sarifLocation{{}},
+	}
+	result.Locations[0].PhysicalLocation.ArtifactLocation.Uri = "db/user.go"
+	result.Locations[0].PhysicalLocation.Region.StartLine = 1
+	fingerprint := result.fingerprint("rule")
+	assert.Equal(t, fingerprint, result.fingerprint("rule"))
+	// the same problem at another place of the file
+	result.Locations[0].PhysicalLocation.Region.StartLine = 2
+	assert.NotEqual(t, fingerprint, result.fingerprint("rule"))
+	// fingerprints computed by the tool take precedence
+	result.Fingerprints = map[string]string{"b/v1": "2", "a/v1": "1"}
+	withToolFingerprint := result.fingerprint("rule")
+	assert.NotEqual(t, fingerprint, withToolFingerprint)
+	result.Message.Text = "changed"
+	assert.Equal(t, withToolFingerprint, result.fingerprint("rule"))
+}
+
+func TestSarifToolIssues(t *testing.T) {
+	log, err := parseSarifLog(strings.NewReader(testSarifLog))
+	assert.Nil(t, err)
+	semgrep := log.Runs[0]
+	// the same tool split into another run, with a duplicated result
+	another := semgrep
+	another.Results = append([]sarifResult{semgrep.Results[0]}, sarifResult{
+		RuleId:    "go.sql-injection",
+		Message:   sarifMessage{Text: "user input flows into the command"},
+		Locations: semgrep.Results[0].Locations,
+	})
+	gosec := sarifRun{Results: semgrep.Results[:1]}
+	gosec.Tool.Driver.Name = "gosec"
+
+	tools, issues := sarifToolIssues([]sarifRun{semgrep, gosec, another})
+	assert.Equal(t, []string{"semgrep", "gosec"}, tools)
+	assert.Len(t, issues["semgrep"], 4)
+	assert.Equal(t, "user input flows into the command", issues["semgrep"][3].Message)
+	assert.Len(t, issues["gosec"], 1)
+	assert.Equal(t, issues["semgrep"][0].Fingerprint, issues["gosec"][0].Fingerprint)
+}
@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+const maxMemory = 32 << 20 // 32 MB
+
+// PostTestResults
+// @Summary upload JUnit test results of a ci pipeline
+// @Description Upload the JUnit XML report of the pipeline created by webhook before, the results would be linked to the task if `taskId` was provided. Uploading the same report again updates the results.
+// @Tags plugins/webhook
+// @Accept multipart/form-data
+// @Param taskId formData string false "the id of the task which ran the tests"
+// @Param file formData file true "JUnit XML report"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/connections/:connectionId/pipelines/:pipelineId/tests [POST]
+func PostTestResults(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	if input.Request == nil {
+		return nil, errors.BadInput.New("JUnit XML report is required")
+	}
+	if input.Request.MultipartForm == nil {
+		if err := input.Request.ParseMultipartForm(maxMemory); err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse the multipart form")
+		}
+	}
+	file, _, e := input.Request.FormFile("file")
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, "JUnit XML report is required")
+	}
+	// nolint
+	defer file.Close()
+	suites, err := parseJUnitReport(file)
+	if err != nil {
+		return nil, err
+	}
+
+	db := basicRes.GetDal()
+	pipelineId := webhookPipelineId(connection.ID, input.Params["pipelineId"])
+	pipeline := &devops.CICDPipeline{}
+	err = db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("pipeline %s not found", input.Params["pipelineId"]))
+		}
+		return nil, err
+	}
+	taskId := ""
+	if id := strings.TrimSpace(input.Request.FormValue("taskId")); id != "" {
+		taskId = fmt.Sprintf("%s:%s", pipelineId, id)
+	}
+
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	for _, suite := range suites {
+		err = saveJUnitTestSuite(tx, pipeline, taskId, suite)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+func saveJUnitTestSuite(tx dal.Transaction, pipeline *devops.CICDPipeline, taskId string, suite *junitTestSuite) errors.Error {
+	executionId := pipeline.Id
+	if taskId != "" {
+		executionId = taskId
+	}
+	testSuite := &devops.CicdTestSuite{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%x", executionId, md5.Sum([]byte(suite.Name))),
+		},
+		CicdScopeId: pipeline.CicdScopeId,
+		PipelineId:  pipeline.Id,
+		TaskId:      taskId,
+		Name:        suite.Name,
+		DurationSec: parseJUnitSeconds(suite.Time),
+		StartedDate: parseJUnitTimestamp(suite.Timestamp),
+	}
+	executedDate := testSuite.StartedDate
+	if executedDate == nil {
+		executedDate = pipeline.StartedDate
+	}
+	var caseDurationSec float64
+	for i := range suite.Cases {
+		junitCase := &suite.Cases[i]
+		result, message := junitCase.result()
+		testCase := &devops.CicdTestCase{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: devops.CicdTestCaseId(pipeline.CicdScopeId, suite.Name, junitCase.ClassName, junitCase.Name),
+			},
+			CicdScopeId: pipeline.CicdScopeId,
+			SuiteName:   suite.Name,
+			ClassName:   junitCase.ClassName,
+			Name:        junitCase.Name,
+			File:        junitCase.File,
+		}
+		testCaseResult := &devops.CicdTestCaseResult{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%x", testSuite.Id, md5.Sum([]byte(junitCase.ClassName+"\n"+junitCase.Name))),
+			},
+			TestCaseId:     testCase.Id,
+			TestSuiteId:    testSuite.Id,
+			CicdScopeId:    pipeline.CicdScopeId,
+			PipelineId:     pipeline.Id,
+			TaskId:         taskId,
+			Result:         result,
+			OriginalResult: result,
+			DurationSec:    parseJUnitSeconds(junitCase.Time),
+			Message:        message,
+			ExecutedDate:   executedDate,
+		}
+		caseDurationSec += testCaseResult.DurationSec
+		testSuite.Tests++
+		switch result {
+		case devops.TEST_FAILED:
+			testSuite.Failures++
+		case devops.TEST_ERROR:
+			testSuite.Errors++
+		case devops.TEST_SKIPPED:
+			testSuite.Skipped++
+		}
+		err := tx.CreateOrUpdate(testCase)
+		if err != nil {
+			return err
+		}
+		err = tx.CreateOrUpdate(testCaseResult)
+		if err != nil {
+			return err
+		}
+	}
+	if testSuite.DurationSec == 0 {
+		testSuite.DurationSec = caseDurationSec
+	}
+	return tx.CreateOrUpdate(testSuite)
+}
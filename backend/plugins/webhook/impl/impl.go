@@ -33,6 +33,7 @@ var _ interface {
 	plugin.PluginMeta
 	plugin.PluginInit
 	plugin.PluginApi
+	plugin.PluginRawBodyApi
 	plugin.PluginModel
 	plugin.PluginMigration
 	plugin.DataSourcePluginBlueprintV200
@@ -57,6 +58,8 @@ func (p Webhook) Init(basicRes context.BasicRes) errors.Error {
 func (p Webhook) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.WebhookConnection{},
+		&models.WebhookIdempotencyKey{},
+		&models.WebhookSarifFingerprint{},
 	}
 }
 
@@ -77,7 +80,7 @@ func (p Webhook) MigrationScripts() []plugin.MigrationScript {
 }
 
 func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
-	return map[string]map[string]plugin.ApiResourceHandler{
+	resources := map[string]map[string]plugin.ApiResourceHandler{
 		"connections": {
 			"POST": api.PostConnections,
 			"GET":  api.ListConnections,
@@ -87,23 +90,69 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
-		"connections/:connectionId/deployments": {
-			"POST": api.PostDeploymentCicdTask,
-		},
-		"connections/:connectionId/issues": {
-			"POST": api.PostIssue,
-		},
-		"connections/:connectionId/issue/:issueKey/close": {
-			"POST": api.CloseIssue,
-		},
-		":connectionId/deployments": {
-			"POST": api.PostDeploymentCicdTask,
-		},
-		":connectionId/issues": {
-			"POST": api.PostIssue,
-		},
-		":connectionId/issue/:issueKey/close": {
-			"POST": api.CloseIssue,
-		},
 	}
+	for resourcePath, handlers := range ingestionResources {
+		resources[resourcePath] = make(map[string]plugin.ApiResourceHandler, len(handlers))
+		for method, handler := range handlers {
+			resources[resourcePath][method] = api.Ingestion(handler)
+		}
+	}
+	return resources
+}
+
+// RawBodyApiResources returns the ingestion apis, the signatures and idempotency keys are verified against the raw body
+func (p Webhook) RawBodyApiResources() map[string][]string {
+	resources := make(map[string][]string, len(ingestionResources))
+	for resourcePath, handlers := range ingestionResources {
+		for method := range handlers {
+			resources[resourcePath] = append(resources[resourcePath], method)
+		}
+	}
+	return resources
+}
+
+// ingestionResources are the apis receiving data from the callers
+var ingestionResources = map[string]map[string]plugin.ApiResourceHandler{
+	"connections/:connectionId/deployments": {
+		"POST": api.PostDeploymentCicdTask,
+	},
+	"connections/:connectionId/cdevents": {
+		"POST": api.PostCDEvent,
+	},
+	"connections/:connectionId/pipelines": {
+		"POST": api.PostPipeline,
+	},
+	"connections/:connectionId/pipelines/:pipelineId/tasks": {
+		"POST": api.PostPipelineTask,
+	},
+	"connections/:connectionId/pipelines/:pipelineId/tests": {
+		"POST": api.PostTestResults,
+	},
+	"connections/:connectionId/coverage": {
+		"POST": api.PostCoverage,
+	},
+	"connections/:connectionId/sarif": {
+		"POST": api.PostSarif,
+	},
+	"connections/:connectionId/issues": {
+		"POST": api.PostIssue,
+	},
+	"connections/:connectionId/issue/:issueKey/close": {
+		"POST": api.CloseIssue,
+	},
+	"connections/:connectionId/issue/:issueKey/events": {
+		"POST": api.PostIssueEvent,
+	},
+	":connectionId/deployments": {
+		"POST": api.PostDeploymentCicdTask,
+	},
+	":connectionId/issues": {
+		"POST": api.PostIssue,
+	},
+	":connectionId/issue/:issueKey/close": {
+		"POST": api.CloseIssue,
+	},
+	":connectionId/issue/:issueKey/events": {
+		"POST": api.PostIssueEvent,
+	},
 }
@@ -18,11 +18,19 @@ limitations under the License.
 package models
 
 import (
+	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
 type WebhookConnection struct {
 	helper.BaseConnection `mapstructure:",squash"`
+	// SigningSecret is used to verify the HMAC signature of the payloads if set
+	SigningSecret string `mapstructure:"signingSecret" json:"signingSecret" gorm:"serializer:encdec"`
+}
+
+func (connection WebhookConnection) Sanitize() WebhookConnection {
+	connection.SigningSecret = utils.SanitizeString(connection.SigningSecret)
+	return connection
 }
 
 func (WebhookConnection) TableName() string {
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type webhookConnection20240216 struct {
+	SigningSecret string
+}
+
+func (webhookConnection20240216) TableName() string {
+	return "_tool_webhook_connections"
+}
+
+type webhookIdempotencyKey20240216 struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	IdempotencyKey string `gorm:"primaryKey;type:varchar(255)"`
+	RequestHash    string `gorm:"type:varchar(64)"`
+	Status         int
+	ResponseBody   json.RawMessage `gorm:"type:json"`
+	CreatedAt      time.Time
+}
+
+func (webhookIdempotencyKey20240216) TableName() string {
+	return "_tool_webhook_idempotency_keys"
+}
+
+type addSigningSecretAndIdempotencyKeys struct{}
+
+func (u *addSigningSecretAndIdempotencyKeys) Up(baseRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		baseRes,
+		&webhookConnection20240216{},
+		&webhookIdempotencyKey20240216{},
+	)
+}
+
+func (*addSigningSecretAndIdempotencyKeys) Version() uint64 {
+	return 20240216000001
+}
+
+func (*addSigningSecretAndIdempotencyKeys) Name() string {
+	return "add signing secret to webhook connections and idempotency keys"
+}
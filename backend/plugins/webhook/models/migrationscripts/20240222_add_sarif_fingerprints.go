@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type webhookSarifFingerprint20240222 struct {
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	ProjectKey         string `gorm:"primaryKey;type:varchar(255)"`
+	Tool               string `gorm:"primaryKey;type:varchar(100)"`
+	Fingerprint        string `gorm:"primaryKey;type:varchar(32)"`
+	IssueId            string `gorm:"type:varchar(255)"`
+	FirstSeenCommitSha string `gorm:"type:varchar(40)"`
+	FirstSeenDate      time.Time
+	LastSeenCommitSha  string `gorm:"type:varchar(40)"`
+	LastSeenDate       time.Time
+	FixedCommitSha     string `gorm:"type:varchar(40)"`
+	FixedDate          *time.Time
+}
+
+func (webhookSarifFingerprint20240222) TableName() string {
+	return "_tool_webhook_sarif_fingerprints"
+}
+
+type addSarifFingerprints struct{}
+
+func (u *addSarifFingerprints) Up(baseRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(baseRes, &webhookSarifFingerprint20240222{})
+}
+
+func (*addSarifFingerprints) Version() uint64 {
+	return 20240222000001
+}
+
+func (*addSarifFingerprints) Name() string {
+	return "add _tool_webhook_sarif_fingerprints"
+}
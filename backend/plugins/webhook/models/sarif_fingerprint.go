@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// WebhookSarifFingerprint tracks the issue reported by a tool across the SARIF uploads of the project, an issue
+// missing from the latest upload of the tool is considered fixed by the commit of the upload
+type WebhookSarifFingerprint struct {
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	ProjectKey         string `gorm:"primaryKey;type:varchar(255)"`
+	Tool               string `gorm:"primaryKey;type:varchar(100)"`
+	Fingerprint        string `gorm:"primaryKey;type:varchar(32)"`
+	IssueId            string `gorm:"type:varchar(255)"`
+	FirstSeenCommitSha string `gorm:"type:varchar(40)"`
+	FirstSeenDate      time.Time
+	LastSeenCommitSha  string `gorm:"type:varchar(40)"`
+	LastSeenDate       time.Time
+	FixedCommitSha     string `gorm:"type:varchar(40)"`
+	FixedDate          *time.Time
+}
+
+func (WebhookSarifFingerprint) TableName() string {
+	return "_tool_webhook_sarif_fingerprints"
+}
@@ -75,6 +75,11 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 	storyIdGen := didgen.NewDomainIdGenerator(&models.ZentaoStory{})
 	taskIdGen := didgen.NewDomainIdGenerator(&models.ZentaoTask{})
 	bugIdGen := didgen.NewDomainIdGenerator(&models.ZentaoBug{})
+	statusMappings := map[string]map[string]string{
+		"story": getStoryStatusMapping(data),
+		"task":  getTaskStatusMapping(data),
+		"bug":   getBugStatusMapping(data),
+	}
 	cn := models.ZentaoChangelog{}.TableName()
 	cdn := models.ZentaoChangelogDetail{}.TableName()
 	an := models.ZentaoAccount{}.TableName()
@@ -147,6 +152,10 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 					}
 				}
 			}
+			if domainCl.FieldName == "status" {
+				domainCl.FromValue = getChangelogStdStatus(statusMappings[cl.ObjectType], cl.ObjectType, cl.Old)
+				domainCl.ToValue = getChangelogStdStatus(statusMappings[cl.ObjectType], cl.ObjectType, cl.New)
+			}
 			if domainCl.FieldName == "execution" {
 				domainCl.FieldName = "Sprint"
 				if cl.Old != "" {
@@ -177,3 +186,37 @@ func ConvertChangelog(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return convertor.Execute()
 }
+
+// getChangelogStdStatus maps the status in a changelog to the standard status with the same rules the
+// extractors apply to the current status of stories, tasks and bugs
+func getChangelogStdStatus(statusMappings map[string]string, objectType string, status string) string {
+	if status == "" {
+		return ""
+	}
+	if len(statusMappings) != 0 {
+		if stdStatus, ok := statusMappings[status]; ok {
+			return stdStatus
+		}
+		return status
+	}
+	switch objectType {
+	case "story":
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"closed"},
+			Todo:    []string{"draft"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	case "task":
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"done", "closed", "cancel"},
+			Todo:    []string{"wait"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	case "bug":
+		return ticket.GetStatus(&ticket.StatusRule{
+			Done:    []string{"resolved"},
+			Default: ticket.IN_PROGRESS,
+		}, status)
+	}
+	return status
+}
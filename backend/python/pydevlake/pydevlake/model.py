@@ -82,6 +82,7 @@ class DomainType(Enum):
     CROSS = "CROSS"
     CICD = "CICD"
     CODE_QUALITY = "CODEQUALITY"
+    COMMUNICATION = "COMMUNICATION"
 
 
 class ScopeConfig(ToolTable, Model):
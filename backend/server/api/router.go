@@ -18,7 +18,9 @@ limitations under the License.
 package api
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/sourceevents"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/services"
 
@@ -80,6 +83,11 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.PUT("/api-keys/:apiKeyId", apikeys.PutApiKey)
 	r.DELETE("/api-keys/:apiKeyId", apikeys.DeleteApiKey)
 
+	// source events api
+	r.POST("/source-events/:plugin/connections/:connectionId", sourceevents.Post)
+	r.GET("/source-events/:plugin/connections/:connectionId/setting", sourceevents.GetSetting)
+	r.PUT("/source-events/:plugin/connections/:connectionId/setting", sourceevents.PutSetting)
+
 	// mount all api resources for all plugins
 	resources, err := services.GetPluginsApiResources()
 	if err != nil {
@@ -92,18 +100,21 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 }
 
 func registerPluginEndpoints(r *gin.Engine, basicRes context.BasicRes, pluginName string, apiResources map[string]map[string]plugin.ApiResourceHandler) {
+	rawBodyResources := services.GetPluginRawBodyApiResources(pluginName)
 	for resourcePath, resourceHandlers := range apiResources {
 		for method, h := range resourceHandlers {
 			r.Handle(
 				method,
 				fmt.Sprintf("/plugins/%s/%s", pluginName, resourcePath),
-				handlePluginCall(basicRes, pluginName, h),
+				handlePluginCall(basicRes, pluginName, h, rawBodyResources[resourcePath][method]),
 			)
 		}
 	}
 }
 
-func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plugin.ApiResourceHandler) func(c *gin.Context) {
+// handlePluginCall adapts the plugin api to gin, the raw body of json requests is kept readable from input.Request
+// only if rawBody is true
+func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plugin.ApiResourceHandler, rawBody bool) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var err errors.Error
 		input := &plugin.ApiResourceInput{}
@@ -124,6 +135,20 @@ func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plug
 		if c.Request.Body != nil {
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
+			} else if rawBody {
+				body, readErr := io.ReadAll(c.Request.Body)
+				if readErr != nil {
+					shared.ApiOutputError(c, errors.BadInput.Wrap(readErr, "failed to read request body"))
+					return
+				}
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				shouldBindJSONErr := c.ShouldBindJSON(&input.Body)
+				if shouldBindJSONErr != nil && shouldBindJSONErr.Error() != "EOF" {
+					shared.ApiOutputError(c, shouldBindJSONErr)
+					return
+				}
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				input.Request = c.Request
 			} else {
 				shouldBindJSONErr := c.ShouldBindJSON(&input.Body)
 				if shouldBindJSONErr != nil && shouldBindJSONErr.Error() != "EOF" {
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlePluginCallRawBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var received *plugin.ApiResourceInput
+	var receivedRawBody string
+	handler := func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		received = input
+		receivedRawBody = ""
+		if input.Request != nil {
+			rawBody, err := io.ReadAll(input.Request.Body)
+			assert.Nil(t, err)
+			receivedRawBody = string(rawBody)
+		}
+		return nil, nil
+	}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(common.USER, &common.User{Name: "tester"})
+	})
+	r.POST("/raw", handlePluginCall(nil, "test", handler, true))
+	r.POST("/parsed", handlePluginCall(nil, "test", handler, false))
+
+	body := `{"name": "devlake"}`
+	post := func(path string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the raw body is kept readable along with the parsed body
+	assert.Equal(t, http.StatusOK, post("/raw"))
+	assert.Equal(t, "devlake", received.Body["name"])
+	assert.NotNil(t, received.Request)
+	assert.Equal(t, body, receivedRawBody)
+
+	// the request is not exposed to the other apis
+	assert.Equal(t, http.StatusOK, post("/parsed"))
+	assert.Equal(t, "devlake", received.Body["name"])
+	assert.Nil(t, received.Request)
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourceevents
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+func getPluginAndConnectionId(c *gin.Context) (string, uint64, errors.Error) {
+	connectionId, err := strconv.ParseUint(c.Param("connectionId"), 10, 64)
+	if err != nil {
+		return "", 0, errors.BadInput.Wrap(err, "bad connectionId format supplied")
+	}
+	return c.Param("plugin"), connectionId, nil
+}
+
+// @Summary receive source event
+// @Description receive an event (webhook) pushed by the source system of the connection, i.e. GitHub push events,
+// @Description the affected scopes would be synced incrementally by their blueprints after the debounce period
+// @Tags framework/source-events
+// @Accept application/json
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Success 200  {object} services.SourceEventOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 401  {object} shared.ApiBody "Unauthorized"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /source-events/{plugin}/connections/{connectionId} [post]
+func Post(c *gin.Context) {
+	pluginName, connectionId, err := getPluginAndConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	// the raw body is required for signature verification
+	body, readErr := io.ReadAll(c.Request.Body)
+	if readErr != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(readErr, shared.BadRequestBody))
+		return
+	}
+	output, err := services.ReceiveSourceEvent(pluginName, connectionId, c.Request.Header, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error receiving source event"))
+		return
+	}
+	shared.ApiOutputSuccess(c, output, http.StatusOK)
+}
+
+// @Summary get source event setting
+// @Description get the source event setting of the connection, the secret is always sanitized
+// @Tags framework/source-events
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Success 200  {object} models.SourceEventSetting
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /source-events/{plugin}/connections/{connectionId}/setting [get]
+func GetSetting(c *gin.Context) {
+	pluginName, connectionId, err := getPluginAndConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	setting, err := services.GetSourceEventSetting(pluginName, connectionId)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting source event setting"))
+		return
+	}
+	shared.ApiOutputSuccess(c, setting, http.StatusOK)
+}
+
+// @Summary update source event setting
+// @Description create or update the source event setting of the connection, leave the secret empty to keep it
+// @Tags framework/source-events
+// @Accept application/json
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Param setting body services.SourceEventSettingInput true "json"
+// @Success 200  {object} models.SourceEventSetting
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /source-events/{plugin}/connections/{connectionId}/setting [put]
+func PutSetting(c *gin.Context) {
+	pluginName, connectionId, err := getPluginAndConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	input := &services.SourceEventSettingInput{}
+	if e := c.ShouldBindJSON(input); e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	setting, err := services.PutSourceEventSetting(pluginName, connectionId, input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error updating source event setting"))
+		return
+	}
+	shared.ApiOutputSuccess(c, setting, http.StatusOK)
+}
@@ -18,17 +18,68 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/plugin"
 	_ "github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/server/api"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
 )
 
 func main() {
-	v := config.GetConfig()
-	encryptionSecret := v.GetString(plugin.EncodeKeyEnvStr)
-	if encryptionSecret == "" {
-		panic("ENCRYPTION_SECRET must be set in environment variable or .env file")
+	cmd := &cobra.Command{
+		Use:   "lake",
+		Short: "Apache DevLake server",
+		Run: func(cmd *cobra.Command, args []string) {
+			v := config.GetConfig()
+			encryptionSecret := v.GetString(plugin.EncodeKeyEnvStr)
+			if encryptionSecret == "" {
+				panic("ENCRYPTION_SECRET must be set in environment variable or .env file")
+			}
+			api.CreateAndRunApiServer()
+		},
+	}
+	cmd.AddCommand(rotateEncryptionKeyCmd())
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func rotateEncryptionKeyCmd() *cobra.Command {
+	var oldSecret, newSecret string
+	cmd := &cobra.Command{
+		Use:          "rotate-encryption-key",
+		Short:        "Re-encrypt all encrypted columns with a new ENCRYPTION_SECRET",
+		SilenceUsage: true,
+		Long: `Re-encrypt connection secrets, pipeline plans and task options from the old ENCRYPTION_SECRET to a new one
+in a single transaction. The devlake server must be stopped before the rotation, and ENCRYPTION_SECRET must be
+updated to the new secret before it is started again.
+The secrets are read from ENCRYPTION_SECRET and NEW_ENCRYPTION_SECRET if the flags are omitted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := config.GetConfig()
+			if oldSecret == "" {
+				oldSecret = v.GetString(plugin.EncodeKeyEnvStr)
+			}
+			if newSecret == "" {
+				newSecret = v.GetString("NEW_ENCRYPTION_SECRET")
+			}
+			rotation, err := services.RotateEncryptionKey(oldSecret, newSecret)
+			if err != nil {
+				return err
+			}
+			total := 0
+			for table, count := range rotation.Values {
+				fmt.Printf("%s: %d values re-encrypted\n", table, count)
+				total += count
+			}
+			fmt.Printf("%d values re-encrypted in total, please update ENCRYPTION_SECRET to the new secret before starting devlake\n", total)
+			return nil
+		},
 	}
-	api.CreateAndRunApiServer()
+	cmd.Flags().StringVar(&oldSecret, "old-key", "", "the current encryption secret, defaults to ENCRYPTION_SECRET")
+	cmd.Flags().StringVar(&newSecret, "new-key", "", "the new encryption secret, defaults to NEW_ENCRYPTION_SECRET")
+	return cmd
 }
@@ -54,7 +54,7 @@ type BlueprintJob struct {
 
 func (bj BlueprintJob) Run() {
 	blueprint := bj.Blueprint
-	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy)
+	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy, nil)
 	if err == ErrEmptyPlan {
 		blueprintLog.Info("Empty plan, blueprint id:[%d] blueprint name:[%s]", blueprint.ID, blueprint.Name)
 		return
@@ -184,7 +184,7 @@ func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 		}
 	} else if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
 		var e errors.Error
-		blueprint.Plan, e = MakePlanForBlueprint(blueprint, &blueprint.SyncPolicy)
+		blueprint.Plan, e = MakePlanForBlueprint(blueprint, &blueprint.SyncPolicy, nil)
 		if e != nil {
 			return e
 		}
@@ -298,16 +298,19 @@ func ReloadBlueprints(c *cron.Cron) (err errors.Error) {
 	return nil
 }
 
-func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy) (*models.Pipeline, errors.Error) {
+func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy, filter *models.BlueprintTriggerFilter) (*models.Pipeline, errors.Error) {
 	var plan models.PipelinePlan
 	var err errors.Error
 	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
-		plan, err = MakePlanForBlueprint(blueprint, syncPolicy)
+		plan, err = MakePlanForBlueprint(blueprint, syncPolicy, filter)
 		if err != nil {
 			blueprintLog.Error(err, fmt.Sprintf("failed to MakePlanForBlueprint on blueprint:[%d][%s]", blueprint.ID, blueprint.Name))
 			return nil, err
 		}
 	} else {
+		if filter != nil {
+			return nil, errors.BadInput.New("filter is not supported by blueprint in advanced mode")
+		}
 		plan = blueprint.Plan
 	}
 
@@ -323,7 +326,7 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.S
 	for _, stage := range plan {
 		for _, task := range stage {
 			switch task.Plugin {
-			case "org", "refdiff", "dora":
+			case "org", "refdiff", "dora", "issue_trace":
 			default:
 				if !plan.IsEmpty() {
 					shouldCreatePipeline = true
@@ -343,9 +346,16 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.S
 	return pipeline, nil
 }
 
-// MakePlanForBlueprint generates pipeline plan by version
-func MakePlanForBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy) (models.PipelinePlan, errors.Error) {
+// MakePlanForBlueprint generates pipeline plan by version, the plan would be reduced to the
+// connections/scopes/subtasks specified by the `filter` if it is not nil
+func MakePlanForBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy, filter *models.BlueprintTriggerFilter) (models.PipelinePlan, errors.Error) {
 	var plan models.PipelinePlan
+	if filter != nil {
+		err := validateBlueprintTriggerFilter(blueprint, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// load project metric plugins and convert it to a map
 	metrics := make(map[string]json.RawMessage)
 	projectMetrics := make([]models.ProjectMetricSetting, 0)
@@ -362,7 +372,7 @@ func MakePlanForBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPo
 	if syncPolicy != nil && syncPolicy.SkipCollectors {
 		skipCollectors = true
 	}
-	plan, err := GeneratePlanJsonV200(blueprint.ProjectName, blueprint.Connections, metrics, skipCollectors)
+	plan, err := GeneratePlanJsonV200(blueprint.ProjectName, blueprint.Connections, metrics, skipCollectors, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -398,8 +408,37 @@ func SequencializePipelinePlans(plans ...models.PipelinePlan) models.PipelinePla
 	return merged
 }
 
-// TriggerBlueprint triggers blueprint immediately
-func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, shouldSanitize bool) (*models.Pipeline, errors.Error) {
+// validateBlueprintTriggerFilter makes sure all connections and scopes in the filter belong to the blueprint
+func validateBlueprintTriggerFilter(blueprint *models.Blueprint, filter *models.BlueprintTriggerFilter) errors.Error {
+	for _, fc := range filter.Connections {
+		var connection *models.BlueprintConnection
+		for _, c := range blueprint.Connections {
+			if c.PluginName == fc.PluginName && c.ConnectionId == fc.ConnectionId {
+				connection = c
+				break
+			}
+		}
+		if connection == nil {
+			return errors.BadInput.New(fmt.Sprintf("connection [%s:%d] does not belong to the blueprint", fc.PluginName, fc.ConnectionId))
+		}
+		for _, fs := range fc.Scopes {
+			found := false
+			for _, s := range connection.Scopes {
+				if s.ScopeId == fs.ScopeId {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.BadInput.New(fmt.Sprintf("scope [%s] does not belong to connection [%s:%d] of the blueprint", fs.ScopeId, fc.PluginName, fc.ConnectionId))
+			}
+		}
+	}
+	return nil
+}
+
+// TriggerBlueprint triggers blueprint immediately, the `filter` is optional and limits the pipeline to part of the blueprint
+func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, filter *models.BlueprintTriggerFilter, shouldSanitize bool) (*models.Pipeline, errors.Error) {
 	// load record from db
 	blueprint, err := GetBlueprint(id, false)
 	if err != nil {
@@ -411,7 +450,7 @@ func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, shouldSanitize b
 	}
 	blueprint.SkipCollectors = syncPolicy.SkipCollectors
 	blueprint.FullSync = syncPolicy.FullSync
-	pipeline, err := createPipelineByBlueprint(blueprint, syncPolicy)
+	pipeline, err := createPipelineByBlueprint(blueprint, syncPolicy, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -27,12 +27,14 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 )
 
-// GeneratePlanJsonV200 generates pipeline plan according v2.0.0 definition
+// GeneratePlanJsonV200 generates pipeline plan according v2.0.0 definition,
+// the data-source part of the plan would be reduced by the `filter` if provided
 func GeneratePlanJsonV200(
 	projectName string,
 	connections []*coreModels.BlueprintConnection,
 	metrics map[string]json.RawMessage,
 	skipCollectors bool,
+	filter *coreModels.BlueprintTriggerFilter,
 ) (coreModels.PipelinePlan, errors.Error) {
 	var err errors.Error
 	// make plan for data-source coreModels fist. generate plan for each
@@ -63,6 +65,21 @@ func GeneratePlanJsonV200(
 			// collect scopes for the project. a github repository may produce
 			// 2 scopes, 1 repo and 1 board
 			scopes = append(scopes, pluginScopes...)
+			// the project mapping requires all scopes of the project, so only the
+			// plan is reduced when the connection is filtered, note that the scopes
+			// of the filter were validated to be a subset of the blueprint scopes
+			selectedScopes, selected := filterConnectionScopes(filter, connection)
+			if !selected {
+				sourcePlans[i] = nil
+			} else if len(selectedScopes) != len(connection.Scopes) {
+				sourcePlans[i], _, err = pluginBp.MakeDataSourcePipelinePlanV200(
+					connection.ConnectionId,
+					selectedScopes,
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
 		} else {
 			return nil, errors.Default.New(
 				fmt.Sprintf("plugin %s does not support DataSourcePluginBlueprintV200", connection.PluginName),
@@ -95,6 +112,16 @@ func GeneratePlanJsonV200(
 		}
 	}
 
+	// limit the data-source tasks to the specified subtasks
+	if filter != nil && len(filter.Subtasks) > 0 {
+		for i, plan := range sourcePlans {
+			sourcePlans[i], err = filterSubtasks(plan, filter.Subtasks)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// make plans for metric plugins
 	metricPlans := make([]coreModels.PipelinePlan, len(metrics))
 	i := 0
@@ -155,3 +182,68 @@ func removeCollectorTasks(plan coreModels.PipelinePlan) coreModels.PipelinePlan
 	}
 	return plan
 }
+
+// filterConnectionScopes returns the scopes of the blueprint connection to run according to the filter, and false if
+// the connection is not selected at all. Empty connections in the filter means all connections, and a selected
+// connection without scopes means all its scopes in the blueprint
+func filterConnectionScopes(filter *coreModels.BlueprintTriggerFilter, connection *coreModels.BlueprintConnection) ([]*coreModels.BlueprintScope, bool) {
+	if filter == nil || len(filter.Connections) == 0 {
+		return connection.Scopes, true
+	}
+	for _, c := range filter.Connections {
+		if c.PluginName == connection.PluginName && c.ConnectionId == connection.ConnectionId {
+			if len(c.Scopes) == 0 {
+				return connection.Scopes, true
+			}
+			return c.Scopes, true
+		}
+	}
+	return nil, false
+}
+
+// filterSubtasks keeps the given subtasks in the plan only, tasks and stages left empty would be removed
+func filterSubtasks(plan coreModels.PipelinePlan, subtasks []string) (coreModels.PipelinePlan, errors.Error) {
+	wanted := make(map[string]bool, len(subtasks))
+	for _, subtask := range subtasks {
+		wanted[strings.ToLower(subtask)] = true
+	}
+	filtered := make(coreModels.PipelinePlan, 0, len(plan))
+	for _, stage := range plan {
+		newStage := make(coreModels.PipelineStage, 0, len(stage))
+		for _, task := range stage {
+			candidates := task.Subtasks
+			// empty subtasks means all subtasks enabled by default
+			if len(candidates) == 0 {
+				p, err := plugin.GetPlugin(task.Plugin)
+				if err != nil {
+					return nil, err
+				}
+				pluginTask, ok := p.(plugin.PluginTask)
+				if !ok {
+					newStage = append(newStage, task)
+					continue
+				}
+				for _, meta := range pluginTask.SubTaskMetas() {
+					if meta.EnabledByDefault {
+						candidates = append(candidates, meta.Name)
+					}
+				}
+			}
+			newSubtasks := make([]string, 0, len(candidates))
+			for _, subtask := range candidates {
+				if wanted[strings.ToLower(subtask)] {
+					newSubtasks = append(newSubtasks, subtask)
+				}
+			}
+			if len(newSubtasks) > 0 {
+				newTask := *task
+				newTask.Subtasks = newSubtasks
+				newStage = append(newStage, &newTask)
+			}
+		}
+		if len(newStage) > 0 {
+			filtered = append(filtered, newStage)
+		}
+	}
+	return filtered, nil
+}
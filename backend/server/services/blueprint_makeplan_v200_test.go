@@ -96,8 +96,135 @@ func TestMakePlanV200(t *testing.T) {
 		doraName: nil,
 	}
 
-	plan, err := GeneratePlanJsonV200(projectName, connections, metrics, false)
+	plan, err := GeneratePlanJsonV200(projectName, connections, metrics, false, nil)
 	assert.Nil(t, err)
 
 	assert.Equal(t, expectedPlan, plan)
 }
+
+func TestFilterSubtasks(t *testing.T) {
+	plan := coreModels.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiIssues", "extractApiIssues", "convertIssues"}},
+			{Plugin: "gitextractor", Subtasks: []string{"Clone Git Repo"}},
+		},
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiPullRequests", "extractApiPullRequests"}},
+		},
+	}
+	filtered, err := filterSubtasks(plan, []string{"ExtractApiIssues", "convertIssues"})
+	assert.Nil(t, err)
+	assert.Equal(t, coreModels.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"extractApiIssues", "convertIssues"}},
+		},
+	}, filtered)
+	// the original plan should be untouched
+	assert.Equal(t, []string{"collectApiIssues", "extractApiIssues", "convertIssues"}, plan[0][0].Subtasks)
+}
+
+func TestMakePlanV200WithFilter(t *testing.T) {
+	githubName := "TestMakePlanV200WithFilter-github"
+	gitlabName := "TestMakePlanV200WithFilter-gitlab"
+	githubScopes := []*coreModels.BlueprintScope{
+		{ScopeId: "1"},
+		{ScopeId: "2"},
+	}
+	gitlabScopes := []*coreModels.BlueprintScope{
+		{ScopeId: "3"},
+	}
+	githubPlan := coreModels.PipelinePlan{
+		{
+			{Plugin: githubName, Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"githubId": 1}},
+			{Plugin: githubName, Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"githubId": 2}},
+		},
+	}
+	githubReducedPlan := coreModels.PipelinePlan{
+		{
+			{Plugin: githubName, Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"githubId": 2}},
+		},
+	}
+	gitlabPlan := coreModels.PipelinePlan{
+		{
+			{Plugin: gitlabName, Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"projectId": 3}},
+		},
+	}
+	github := new(mockplugin.CompositeDataSourcePluginBlueprintV200)
+	github.On("MakeDataSourcePipelinePlanV200", uint64(1), githubScopes).Return(githubPlan, []plugin.Scope{}, nil)
+	github.On("MakeDataSourcePipelinePlanV200", uint64(1), githubScopes[1:]).Return(githubReducedPlan, []plugin.Scope{}, nil)
+	gitlab := new(mockplugin.CompositeDataSourcePluginBlueprintV200)
+	gitlab.On("MakeDataSourcePipelinePlanV200", uint64(2), gitlabScopes).Return(gitlabPlan, []plugin.Scope{}, nil)
+	plugin.RegisterPlugin(githubName, github)
+	plugin.RegisterPlugin(gitlabName, gitlab)
+
+	connections := []*coreModels.BlueprintConnection{
+		{PluginName: githubName, ConnectionId: 1, Scopes: githubScopes},
+		{PluginName: gitlabName, ConnectionId: 2, Scopes: gitlabScopes},
+	}
+
+	// a filter with subtasks only should keep all connections
+	plan, err := GeneratePlanJsonV200("", connections, nil, false, &coreModels.BlueprintTriggerFilter{
+		Subtasks: []string{"extractApiIssues"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, coreModels.PipelinePlan{
+		{
+			{Plugin: githubName, Subtasks: []string{"extractApiIssues"}, Options: map[string]interface{}{"githubId": 1}},
+			{Plugin: githubName, Subtasks: []string{"extractApiIssues"}, Options: map[string]interface{}{"githubId": 2}},
+			{Plugin: gitlabName, Subtasks: []string{"extractApiIssues"}, Options: map[string]interface{}{"projectId": 3}},
+		},
+	}, plan)
+
+	// the plan should be reduced to the filtered connections and scopes
+	plan, err = GeneratePlanJsonV200("", connections, nil, false, &coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: githubName, ConnectionId: 1, Scopes: githubScopes[1:]},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, githubReducedPlan, plan)
+
+	// a filtered connection without scopes should keep all its scopes
+	plan, err = GeneratePlanJsonV200("", connections, nil, false, &coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: gitlabName, ConnectionId: 2},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, gitlabPlan, plan)
+}
+
+func TestFilterConnectionScopes(t *testing.T) {
+	scopes := []*coreModels.BlueprintScope{
+		{ScopeId: "1"},
+		{ScopeId: "2"},
+	}
+	connection := &coreModels.BlueprintConnection{PluginName: "github", ConnectionId: 1, Scopes: scopes}
+
+	selectedScopes, selected := filterConnectionScopes(nil, connection)
+	assert.True(t, selected)
+	assert.Equal(t, scopes, selectedScopes)
+
+	// empty connections in the filter means all connections
+	selectedScopes, selected = filterConnectionScopes(&coreModels.BlueprintTriggerFilter{Subtasks: []string{"collectApiIssues"}}, connection)
+	assert.True(t, selected)
+	assert.Equal(t, scopes, selectedScopes)
+
+	// a connection without scopes means all its scopes
+	selectedScopes, selected = filterConnectionScopes(&coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{{PluginName: "github", ConnectionId: 1}},
+	}, connection)
+	assert.True(t, selected)
+	assert.Equal(t, scopes, selectedScopes)
+
+	selectedScopes, selected = filterConnectionScopes(&coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{{PluginName: "github", ConnectionId: 1, Scopes: scopes[1:]}},
+	}, connection)
+	assert.True(t, selected)
+	assert.Equal(t, scopes[1:], selectedScopes)
+
+	_, selected = filterConnectionScopes(&coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{{PluginName: "gitlab", ConnectionId: 1}},
+	}, connection)
+	assert.False(t, selected)
+}
@@ -147,3 +147,33 @@ func TestRemoveCollectorTasks(t *testing.T) {
 		},
 	}, removeCollectorTasks(plan1))
 }
+
+func TestValidateBlueprintTriggerFilter(t *testing.T) {
+	blueprint := &coreModels.Blueprint{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*coreModels.BlueprintScope{{ScopeId: "1"}, {ScopeId: "2"}}},
+			{PluginName: "gitlab", ConnectionId: 1, Scopes: []*coreModels.BlueprintScope{{ScopeId: "3"}}},
+		},
+	}
+	assert.Nil(t, validateBlueprintTriggerFilter(blueprint, &coreModels.BlueprintTriggerFilter{
+		Subtasks: []string{"collectApiIssues"},
+	}))
+	assert.Nil(t, validateBlueprintTriggerFilter(blueprint, &coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*coreModels.BlueprintScope{{ScopeId: "2"}}},
+			{PluginName: "gitlab", ConnectionId: 1},
+		},
+	}))
+	// connection not in the blueprint
+	assert.NotNil(t, validateBlueprintTriggerFilter(blueprint, &coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 2},
+		},
+	}))
+	// scope of another connection
+	assert.NotNil(t, validateBlueprintTriggerFilter(blueprint, &coreModels.BlueprintTriggerFilter{
+		Connections: []*coreModels.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*coreModels.BlueprintScope{{ScopeId: "3"}}},
+		},
+	}))
+}
@@ -0,0 +1,291 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedTable describes the columns encrypted by the `encdec` serializer in a table
+type EncryptedTable struct {
+	Name        string
+	PrimaryKeys []string
+	Columns     []string
+	// Probed means the columns were detected by decrypting the values, instead of declared by a model
+	Probed bool
+}
+
+// EncryptionKeyRotation is the result of the rotation, number of values re-encrypted by table
+type EncryptionKeyRotation struct {
+	Values map[string]int
+}
+
+// encrypted columns of the framework
+var frameworkEncryptedModels = []dal.Tabler{
+	&models.Blueprint{},
+	&models.Pipeline{},
+	&models.Task{},
+	&models.SourceEventSetting{},
+}
+
+// RotateEncryptionKey re-encrypts every value encrypted by the `encdec` serializer from the old secret to the
+// new one in a single transaction, all changes are rolled back if any value fails to be decrypted by the old secret.
+// The database is locked during the rotation, so it must not be shared by a running devlake instance.
+func RotateEncryptionKey(oldSecret, newSecret string) (*EncryptionKeyRotation, errors.Error) {
+	if oldSecret == "" || newSecret == "" {
+		return nil, errors.BadInput.New("both the old and the new encryption secret are required")
+	}
+	if oldSecret == newSecret {
+		return nil, errors.BadInput.New("the new encryption secret is the same as the old one")
+	}
+	InitResources()
+	lockDatabase()
+	// remote plugins are skipped, their connection tables would be probed instead
+	if err := runner.LoadGoPlugins(basicRes); err != nil {
+		return nil, err
+	}
+	tables, err := findEncryptedTables(oldSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Error(rollbackErr, "failed to rollback the encryption key rotation")
+			}
+		}
+	}()
+	rotation := &EncryptionKeyRotation{Values: make(map[string]int)}
+	for _, table := range tables {
+		var count int
+		count, err = reencryptTable(tx, table, oldSecret, newSecret)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to re-encrypt table %s", table.Name))
+		}
+		rotation.Values[table.Name] = count
+		logger.Info("re-encrypted %d values of %s", count, table.Name)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// findEncryptedTables collects the encrypted columns declared by the framework and plugin models, and probes the
+// connection tables not covered by any loaded model, i.e. those of remote plugins
+func findEncryptedTables(oldSecret string) ([]*EncryptedTable, errors.Error) {
+	tableModels := append([]dal.Tabler{}, frameworkEncryptedModels...)
+	for _, p := range plugin.AllPlugins() {
+		if pluginModel, ok := p.(plugin.PluginModel); ok {
+			tableModels = append(tableModels, pluginModel.GetTablesInfo()...)
+		}
+		if pluginSource, ok := p.(plugin.PluginSource); ok && pluginSource.Connection() != nil {
+			tableModels = append(tableModels, pluginSource.Connection())
+		}
+	}
+	tables := make(map[string]*EncryptedTable)
+	for _, model := range tableModels {
+		table, err := encryptedTableOf(model)
+		if err != nil {
+			return nil, err
+		}
+		if table == nil || !db.HasTable(table.Name) {
+			continue
+		}
+		if existing, ok := tables[table.Name]; ok {
+			existing.Columns = mergeColumns(existing.Columns, table.Columns)
+			continue
+		}
+		tables[table.Name] = table
+	}
+
+	allTables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, tableName := range allTables {
+		if _, ok := tables[tableName]; ok || !strings.HasPrefix(tableName, "_tool_") || !strings.HasSuffix(tableName, "connections") {
+			continue
+		}
+		table, err := probeEncryptedTable(tableName, oldSecret)
+		if err != nil {
+			return nil, err
+		}
+		if table != nil {
+			tables[tableName] = table
+		}
+	}
+
+	result := make([]*EncryptedTable, 0, len(tables))
+	for _, table := range tables {
+		result = append(result, table)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// encryptedTableOf returns the encrypted columns declared by the model, nil if there is none
+func encryptedTableOf(model dal.Tabler) (*EncryptedTable, errors.Error) {
+	s, err := schema.Parse(models.UnwrapObject(model), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse the model of %s", model.TableName()))
+	}
+	table := &EncryptedTable{Name: model.TableName()}
+	for _, field := range s.Fields {
+		if field.DBName != "" && strings.EqualFold(field.TagSettings["SERIALIZER"], "encdec") {
+			table.Columns = append(table.Columns, field.DBName)
+		}
+	}
+	if len(table.Columns) == 0 {
+		return nil, nil
+	}
+	for _, field := range s.PrimaryFields {
+		table.PrimaryKeys = append(table.PrimaryKeys, field.DBName)
+	}
+	return table, nil
+}
+
+// probeEncryptedTable detects the encrypted columns by decrypting the first non-empty value of every column
+func probeEncryptedTable(tableName string, oldSecret string) (*EncryptedTable, errors.Error) {
+	columns, err := db.GetColumns(dal.DefaultTabler{Name: tableName}, nil)
+	if err != nil {
+		return nil, err
+	}
+	table := &EncryptedTable{Name: tableName, Probed: true}
+	for _, column := range columns {
+		if isPrimaryKey, ok := column.PrimaryKey(); ok && isPrimaryKey {
+			table.PrimaryKeys = append(table.PrimaryKeys, column.Name())
+			continue
+		}
+		var values []string
+		err = db.Pluck(column.Name(), &values, dal.From(tableName), dal.Where("? IS NOT NULL", dal.ClauseColumn{Name: column.Name()}), dal.Limit(1))
+		if err != nil || len(values) == 0 || values[0] == "" {
+			// not a string column
+			continue
+		}
+		if _, decryptErr := plugin.Decrypt(oldSecret, values[0]); decryptErr == nil {
+			table.Columns = append(table.Columns, column.Name())
+		}
+	}
+	if len(table.Columns) == 0 || len(table.PrimaryKeys) == 0 {
+		return nil, nil
+	}
+	return table, nil
+}
+
+// reencryptTable decrypts the values with the old secret and encrypts them with the new one, values of probed
+// columns failed to be decrypted are left untouched
+func reencryptTable(tx dal.Transaction, table *EncryptedTable, oldSecret, newSecret string) (int, errors.Error) {
+	if len(table.PrimaryKeys) == 0 {
+		return 0, errors.Default.New("primary key is required for re-encryption")
+	}
+	columns := append(append([]string{}, table.PrimaryKeys...), table.Columns...)
+	cursor, err := tx.Cursor(dal.Select(strings.Join(columns, ", ")), dal.From(table.Name))
+	if err != nil {
+		return 0, err
+	}
+	// read all rows before updating, some drivers can't execute statements while a cursor is open
+	var rows [][]interface{}
+	for cursor.Next() {
+		row := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range row {
+			pointers[i] = &row[i]
+		}
+		if e := cursor.Scan(pointers...); e != nil {
+			cursor.Close()
+			return 0, errors.Convert(e)
+		}
+		rows = append(rows, row)
+	}
+	cursor.Close()
+
+	pkCondition := strings.Join(table.PrimaryKeys, " = ? AND ") + " = ?"
+	count := 0
+	for _, row := range rows {
+		pks := row[:len(table.PrimaryKeys)]
+		var sets []dal.DalSet
+		for i, column := range table.Columns {
+			value := row[len(table.PrimaryKeys)+i]
+			var encrypted string
+			switch v := value.(type) {
+			case nil:
+				continue
+			case []byte:
+				encrypted = string(v)
+			case string:
+				encrypted = v
+			default:
+				return 0, errors.Default.New(fmt.Sprintf("unexpected value type %T of column %s", value, column))
+			}
+			if encrypted == "" {
+				continue
+			}
+			plainText, err := plugin.Decrypt(oldSecret, encrypted)
+			if err != nil {
+				if table.Probed {
+					continue
+				}
+				return 0, errors.Default.Wrap(err, fmt.Sprintf("failed to decrypt column %s of %v, is the old secret correct?", column, pks))
+			}
+			reencrypted, err := plugin.Encrypt(newSecret, plainText)
+			if err != nil {
+				return 0, err
+			}
+			sets = append(sets, dal.DalSet{ColumnName: column, Value: reencrypted})
+		}
+		if len(sets) == 0 {
+			continue
+		}
+		err = tx.UpdateColumns(table.Name, sets, dal.Where(pkCondition, pks...))
+		if err != nil {
+			return 0, err
+		}
+		count += len(sets)
+	}
+	return count, nil
+}
+
+func mergeColumns(columns []string, others []string) []string {
+	for _, other := range others {
+		found := false
+		for _, column := range columns {
+			if column == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			columns = append(columns, other)
+		}
+	}
+	return columns
+}
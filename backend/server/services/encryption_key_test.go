@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedTableOf(t *testing.T) {
+	dalgorm.Init("secret")
+	table, err := encryptedTableOf(&models.Blueprint{})
+	assert.Nil(t, err)
+	assert.Equal(t, "_devlake_blueprints", table.Name)
+	assert.Equal(t, []string{"id"}, table.PrimaryKeys)
+	assert.ElementsMatch(t, []string{"plan", "before_plan", "after_plan"}, table.Columns)
+
+	table, err = encryptedTableOf(&models.SourceEventSetting{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"plugin_name", "connection_id"}, table.PrimaryKeys)
+	assert.Equal(t, []string{"secret"}, table.Columns)
+
+	table, err = encryptedTableOf(&models.BlueprintLabel{})
+	assert.Nil(t, err)
+	assert.Nil(t, table)
+}
+
+func TestMergeColumns(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, mergeColumns([]string{"a", "b"}, []string{"b", "c"}))
+}
@@ -43,3 +43,21 @@ func GetPluginsApiResources() (map[string]map[string]map[string]plugin.ApiResour
 	}
 	return res, nil
 }
+
+// GetPluginRawBodyApiResources returns the APIs of the plugin which need the raw body of json requests, by path and method
+func GetPluginRawBodyApiResources(pluginName string) map[string]map[string]bool {
+	res := make(map[string]map[string]bool)
+	pluginEntry, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return res
+	}
+	if pluginApi, ok := pluginEntry.(plugin.PluginRawBodyApi); ok {
+		for resourcePath, methods := range pluginApi.RawBodyApiResources() {
+			res[resourcePath] = make(map[string]bool, len(methods))
+			for _, method := range methods {
+				res[resourcePath][method] = true
+			}
+		}
+	}
+	return res
+}
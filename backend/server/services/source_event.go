@@ -0,0 +1,235 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// SourceEventSettingInput is the body for updating the source event setting of a connection
+type SourceEventSettingInput struct {
+	Enable          bool   `json:"enable"`
+	Secret          string `json:"secret"`
+	DebounceSeconds int    `json:"debounceSeconds" validate:"min=0"`
+}
+
+// SourceEventOutput tells which blueprints were scheduled for the event
+type SourceEventOutput struct {
+	ScopeIds     []string `json:"scopeIds"`
+	BlueprintIds []uint64 `json:"blueprintIds"`
+}
+
+type pendingSourceEventTrigger struct {
+	filter *models.BlueprintTriggerFilter
+	timer  *time.Timer
+}
+
+// sourceEventDebouncer accumulates scopes affected by events for each blueprint, and triggers the
+// blueprint for the accumulated scopes once no more events come in within the debounce period
+type sourceEventDebouncer struct {
+	sync.Mutex
+	pending map[uint64]*pendingSourceEventTrigger
+	trigger func(blueprintId uint64, filter *models.BlueprintTriggerFilter)
+}
+
+var sourceEventsDebouncer = &sourceEventDebouncer{
+	pending: make(map[uint64]*pendingSourceEventTrigger),
+	trigger: triggerBlueprintForSourceEvent,
+}
+
+func (d *sourceEventDebouncer) enqueue(blueprintId uint64, pluginName string, connectionId uint64, scopeIds []string, delay time.Duration) {
+	d.Lock()
+	defer d.Unlock()
+	pending, ok := d.pending[blueprintId]
+	if !ok {
+		pending = &pendingSourceEventTrigger{filter: &models.BlueprintTriggerFilter{}}
+		d.pending[blueprintId] = pending
+	} else {
+		pending.timer.Stop()
+	}
+	mergeScopesIntoFilter(pending.filter, pluginName, connectionId, scopeIds)
+	pending.timer = time.AfterFunc(delay, func() {
+		d.Lock()
+		// the timer might have been replaced by a newer event
+		if d.pending[blueprintId] != pending {
+			d.Unlock()
+			return
+		}
+		delete(d.pending, blueprintId)
+		d.Unlock()
+		d.trigger(blueprintId, pending.filter)
+	})
+}
+
+func mergeScopesIntoFilter(filter *models.BlueprintTriggerFilter, pluginName string, connectionId uint64, scopeIds []string) {
+	var connection *models.BlueprintConnection
+	for _, c := range filter.Connections {
+		if c.PluginName == pluginName && c.ConnectionId == connectionId {
+			connection = c
+			break
+		}
+	}
+	if connection == nil {
+		connection = &models.BlueprintConnection{PluginName: pluginName, ConnectionId: connectionId}
+		filter.Connections = append(filter.Connections, connection)
+	}
+	for _, scopeId := range scopeIds {
+		exists := false
+		for _, s := range connection.Scopes {
+			if s.ScopeId == scopeId {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			connection.Scopes = append(connection.Scopes, &models.BlueprintScope{ScopeId: scopeId})
+		}
+	}
+}
+
+func triggerBlueprintForSourceEvent(blueprintId uint64, filter *models.BlueprintTriggerFilter) {
+	pipeline, err := TriggerBlueprint(blueprintId, &models.SyncPolicy{}, filter, false)
+	if err == ErrEmptyPlan {
+		blueprintLog.Info("Empty plan for source event, blueprint id:[%d]", blueprintId)
+		return
+	}
+	if err != nil {
+		blueprintLog.Error(err, fmt.Sprintf("failed to trigger blueprint:[%d] for source event", blueprintId))
+		return
+	}
+	blueprintLog.Info("Triggered blueprint id:[%d] for source event, pipeline id:[%d]", blueprintId, pipeline.ID)
+}
+
+// GetSourceEventSetting returns the source event setting of the connection with the secret sanitized
+func GetSourceEventSetting(pluginName string, connectionId uint64) (*models.SourceEventSetting, errors.Error) {
+	setting, err := getSourceEventSetting(pluginName, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	setting.Secret = ""
+	return setting, nil
+}
+
+func getSourceEventSetting(pluginName string, connectionId uint64) (*models.SourceEventSetting, errors.Error) {
+	setting := &models.SourceEventSetting{}
+	err := db.First(setting, dal.Where("plugin_name = ? AND connection_id = ?", pluginName, connectionId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("source event setting for connection [%s:%d] not found", pluginName, connectionId))
+		}
+		return nil, errors.Default.Wrap(err, "error getting source event setting")
+	}
+	return setting, nil
+}
+
+// PutSourceEventSetting creates or updates the source event setting of the connection, the secret would
+// be kept untouched if it is empty in the input
+func PutSourceEventSetting(pluginName string, connectionId uint64, input *SourceEventSettingInput) (*models.SourceEventSetting, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	p, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.(plugin.DataSourceEventReceiver); !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s does not support receiving source events", pluginName))
+	}
+	setting, err := getSourceEventSetting(pluginName, connectionId)
+	if err != nil {
+		if err.GetType() != errors.NotFound {
+			return nil, err
+		}
+		setting = &models.SourceEventSetting{PluginName: pluginName, ConnectionId: connectionId}
+	}
+	setting.Enable = input.Enable
+	setting.DebounceSeconds = input.DebounceSeconds
+	if input.Secret != "" {
+		setting.Secret = input.Secret
+	}
+	if setting.Enable && setting.Secret == "" {
+		return nil, errors.BadInput.New("secret is required to enable source events")
+	}
+	err = db.CreateOrUpdate(setting)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving source event setting")
+	}
+	setting.Secret = ""
+	return setting, nil
+}
+
+// ReceiveSourceEvent verifies the event pushed by the source system of the connection, and schedules
+// incremental pipelines limited to the affected scopes for all enabled blueprints containing them
+func ReceiveSourceEvent(pluginName string, connectionId uint64, header http.Header, body []byte) (*SourceEventOutput, errors.Error) {
+	setting, err := getSourceEventSetting(pluginName, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	if !setting.Enable {
+		return nil, errors.Forbidden.New(fmt.Sprintf("source events are disabled for connection [%s:%d]", pluginName, connectionId))
+	}
+	p, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	receiver, ok := p.(plugin.DataSourceEventReceiver)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s does not support receiving source events", pluginName))
+	}
+	scopeIds, err := receiver.ReceiveSourceEvent(connectionId, setting.Secret, header, body)
+	if err != nil {
+		return nil, err
+	}
+	output := &SourceEventOutput{ScopeIds: scopeIds, BlueprintIds: make([]uint64, 0)}
+	if len(scopeIds) == 0 {
+		return output, nil
+	}
+	var blueprintScopes []*models.BlueprintScope
+	err = db.All(
+		&blueprintScopes,
+		dal.Where("plugin_name = ? AND connection_id = ? AND scope_id IN ?", pluginName, connectionId, scopeIds),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding blueprints for the source event")
+	}
+	scopesByBlueprint := make(map[uint64][]string)
+	for _, bs := range blueprintScopes {
+		scopesByBlueprint[bs.BlueprintId] = append(scopesByBlueprint[bs.BlueprintId], bs.ScopeId)
+	}
+	delay := time.Duration(setting.GetDebounceSeconds()) * time.Second
+	for blueprintId, ids := range scopesByBlueprint {
+		blueprint, err := bpManager.GetDbBlueprint(blueprintId)
+		if err != nil {
+			return nil, err
+		}
+		if !blueprint.Enable || blueprint.Mode != models.BLUEPRINT_MODE_NORMAL {
+			continue
+		}
+		sourceEventsDebouncer.enqueue(blueprintId, pluginName, connectionId, ids, delay)
+		output.BlueprintIds = append(output.BlueprintIds, blueprintId)
+	}
+	return output, nil
+}
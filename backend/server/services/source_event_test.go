@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceEventDebouncer(t *testing.T) {
+	var mu sync.Mutex
+	triggered := make(map[uint64]*models.BlueprintTriggerFilter)
+	done := make(chan struct{}, 2)
+	debouncer := &sourceEventDebouncer{
+		pending: make(map[uint64]*pendingSourceEventTrigger),
+		trigger: func(blueprintId uint64, filter *models.BlueprintTriggerFilter) {
+			mu.Lock()
+			triggered[blueprintId] = filter
+			mu.Unlock()
+			done <- struct{}{}
+		},
+	}
+	delay := 50 * time.Millisecond
+	debouncer.enqueue(1, "github", 1, []string{"123"}, delay)
+	debouncer.enqueue(1, "github", 1, []string{"123", "456"}, delay)
+	debouncer.enqueue(1, "gitlab", 2, []string{"789"}, delay)
+	debouncer.enqueue(2, "github", 1, []string{"123"}, delay)
+	<-done
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, triggered, 2)
+	assert.Equal(t, &models.BlueprintTriggerFilter{
+		Connections: []*models.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "123"}, {ScopeId: "456"}}},
+			{PluginName: "gitlab", ConnectionId: 2, Scopes: []*models.BlueprintScope{{ScopeId: "789"}}},
+		},
+	}, triggered[1])
+	assert.Equal(t, &models.BlueprintTriggerFilter{
+		Connections: []*models.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "123"}}},
+		},
+	}, triggered[2])
+	assert.Empty(t, debouncer.pending)
+}
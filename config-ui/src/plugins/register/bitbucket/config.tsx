@@ -73,6 +73,7 @@ export const BitBucketConfig: IPluginConfig = {
       issueStatusOther: 'on hold,wontfix,duplicate,invalid',
       deploymentPattern: '',
       productionPattern: '',
+      coverageReportPath: '',
       refdiff: {
         tagsLimit: 10,
         tagsPattern: '/v\\d+\\.\\d+(\\.\\d+(-rc)*\\d*)*$/',
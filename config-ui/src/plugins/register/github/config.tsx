@@ -118,6 +118,7 @@ export const GitHubConfig: IPluginConfig = {
       envNamePattern: '(?i)prod(.*)',
       deploymentPattern: '',
       productionPattern: '',
+      coverageReportPath: '',
       prType: 'type(.*)',
       prComponent: 'component(.*)',
       prBodyClosePattern:
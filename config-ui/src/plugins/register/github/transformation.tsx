@@ -445,4 +445,33 @@ const renderCollapseItems = ({
         </>
       ),
     },
+    {
+      key: 'CODE',
+      label: 'Source Code Management',
+      style: panelStyle,
+      children: (
+        <>
+          <p>Tell DevLake where the test coverage report is committed in the repository to collect code coverage.</p>
+          <Form.Item
+            label={
+              <>
+                <span style={{ marginRight: 4 }}>Coverage Report Path</span>
+                <HelpTooltip content="The path of the coverage report (LCOV, Cobertura or Go cover profile) relative to the repository root. Leave it empty to skip collecting coverage." />
+              </>
+            }
+          >
+            <Input
+              placeholder="coverage/lcov.info"
+              value={transformation.coverageReportPath ?? ''}
+              onChange={(e) =>
+                onChangeTransformation({
+                  ...transformation,
+                  coverageReportPath: e.target.value,
+                })
+              }
+            />
+          </Form.Item>
+        </>
+      ),
+    },
   ].filter((it) => entities.includes(it.key) || it.key === 'ADDITIONAL');
@@ -52,7 +52,7 @@ export const PipelineTask = ({ task }: Props) => {
       case ['gitextractor'].includes(config.plugin):
         name = `${name}:${options.name}`;
         break;
-      case ['dora'].includes(config.plugin):
+      case ['dora', 'issue_trace'].includes(config.plugin):
         name = `${name}:${options.projectName}`;
         break;
       case ['gitlab'].includes(config.plugin):
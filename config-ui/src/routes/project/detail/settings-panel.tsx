@@ -39,6 +39,7 @@ interface Props {
 export const SettingsPanel = ({ project, onRefresh }: Props) => {
   const [name, setName] = useState('');
   const [enableDora, setEnableDora] = useState(false);
+  const [enableIssueTrace, setEnableIssueTrace] = useState(false);
   const [operating, setOperating] = useState(false);
   const [open, setOpen] = useState(false);
 
@@ -46,9 +47,11 @@ export const SettingsPanel = ({ project, onRefresh }: Props) => {
 
   useEffect(() => {
     const doraMetrics = project.metrics.find((ms: any) => ms.pluginName === 'dora');
+    const issueTraceMetrics = project.metrics.find((ms: any) => ms.pluginName === 'issue_trace');
 
     setName(project.name);
     setEnableDora(doraMetrics?.enable ?? false);
+    setEnableIssueTrace(issueTraceMetrics?.enable ?? false);
   }, [project]);
 
   const handleUpdate = async () => {
@@ -68,6 +71,11 @@ export const SettingsPanel = ({ project, onRefresh }: Props) => {
               pluginOption: '',
               enable: enableDora,
             },
+            {
+              pluginName: 'issue_trace',
+              pluginOption: '',
+              enable: enableIssueTrace,
+            },
           ],
         }),
       {
@@ -112,6 +120,11 @@ export const SettingsPanel = ({ project, onRefresh }: Props) => {
               Enable DORA Metrics
             </Checkbox>
           </Block>
+          <Block description="Flow metrics trace the status history of issues to measure cycle time, wait time, flow efficiency and reopens.">
+            <Checkbox checked={enableIssueTrace} onChange={(e) => setEnableIssueTrace(e.target.checked)}>
+              Enable Flow Metrics
+            </Checkbox>
+          </Block>
           <Block>
             <Button type="primary" loading={operating} disabled={!name} onClick={handleUpdate}>
               Save